@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/conn"
+	"github.com/vivasoft-ltd/go-ems/health"
+)
+
+// healthChecker checks every dependency shared by the serve and worker processes.
+func healthChecker() *health.Checker {
+	return health.NewChecker(config.App().HealthCheckTimeout*time.Second).
+		Add("mysql", health.MySQL(conn.Db())).
+		Add("redis", health.Redis(conn.Redis())).
+		Add("asynq", health.Asynq(conn.Asynq())).
+		Add("mail", health.HTTP(conn.EmailClient(), config.Email().Url))
+}
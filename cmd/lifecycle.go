@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/conn"
+	"github.com/vivasoft-ltd/go-ems/lifecycle"
+)
+
+func newLifecycle() *lifecycle.Manager {
+	return lifecycle.New(config.App().DrainTimeout * time.Second)
+}
+
+// closeConnections closes the shared clients once everything using them has
+// stopped, so it must be registered last. The worker pool goes first, its
+// queued mails still need the database.
+func closeConnections(lc *lifecycle.Manager) {
+	lc.OnStop("worker pool", conn.CloseWorker)
+	lc.OnStop("mysql", func(ctx context.Context) error { return conn.CloseDb() })
+	lc.OnStop("redis", func(ctx context.Context) error { return conn.CloseRedis() })
+	lc.OnStop("asynq clients", func(ctx context.Context) error { return conn.CloseAsynq() })
+}
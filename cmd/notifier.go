@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/conn"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	notifier_repo "github.com/vivasoft-ltd/go-ems/repositories/notifier"
+)
+
+// notifierChannels builds the SMS and push channels for the configured provider.
+func notifierChannels() []domain.NotifierChannel {
+	conf := config.Notifier()
+	client := conn.NotifierClient()
+
+	sms := notifier_repo.NewSmsChannel(client, conf.SmsUrl)
+	push := notifier_repo.NewPushChannel(client, conf.PushUrl)
+
+	if conf.Provider == consts.NotifierProviderHttp {
+		return []domain.NotifierChannel{sms, push}
+	}
+
+	return []domain.NotifierChannel{
+		notifier_repo.NewStubChannel(sms, conf.StubFilePath),
+		notifier_repo.NewStubChannel(push, conf.StubFilePath),
+	}
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/conn"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 )
 
 var RootCmd = &cobra.Command{
@@ -32,6 +35,8 @@ func Execute() {
 	conn.InitAsynqClient()
 	conn.InitAsyncInspector()
 	conn.ConnectEmail()
+	conn.ConnectNotifier()
+	conn.ConnectWorker()
 
 	// asynq connections
 	conn.InitAsynqClient()
@@ -43,8 +48,27 @@ func Execute() {
 	}
 }
 
+// initLogger makes the JSON logger the default one, writing to stdout and to
+// the configured log file when there is one.
 func initLogger() {
-	fmt.Println("Initializing logger...")
-	fmt.Println("Logger file path:", config.Logger().FilePath)
-	logger.SetFileLogger(config.Logger().FilePath)
+	conf := config.Logger()
+
+	var out io.Writer = os.Stdout
+	if conf.FilePath != "" {
+		file, err := openLogFile(conf.FilePath)
+		if err != nil {
+			fmt.Println("failed to open log file, logging to stdout only:", err)
+		} else {
+			out = io.MultiWriter(os.Stdout, file)
+		}
+	}
+
+	slog.SetDefault(logutil.New(out, conf.Level))
+}
+
+func openLogFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 }
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"context"
+	"os"
+
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/cobra"
 	"github.com/vivasoft-ltd/go-ems/config"
@@ -14,6 +17,7 @@ import (
 	"github.com/vivasoft-ltd/go-ems/routes"
 	"github.com/vivasoft-ltd/go-ems/server"
 	"github.com/vivasoft-ltd/go-ems/services"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
 )
 
 var serveCmd = &cobra.Command{
@@ -22,6 +26,8 @@ var serveCmd = &cobra.Command{
 }
 
 func serve(cmd *cobra.Command, args []string) {
+	shutdownTracing := initTracing("serve")
+
 	// clients
 	dbClient := conn.Db()
 	redisClient := conn.Redis()
@@ -36,29 +42,62 @@ func serve(cmd *cobra.Command, args []string) {
 
 	// services
 	redisSvc := services.NewRedisService(redisClient)
-	eventSvc := services.NewEventServiceImpl(dbRepo, dbRepo)
-	userSvc := services.NewUserServiceImpl(redisSvc, dbRepo)
+	eventSvc := services.NewEventServiceImpl(config.Event(), dbRepo, dbRepo)
+	notifierSvc := services.NewNotifierServiceImpl(dbRepo, notifierChannels()...)
+	userSvc := services.NewUserServiceImpl(redisSvc, dbRepo, notifierSvc)
 	tokenSvc := services.NewTokenServiceImpl(redisSvc)
 	authSvc := services.NewAuthServiceImpl(userSvc, tokenSvc)
-	mailSvc := services.NewMailService(dbRepo, dbRepo, mailRepo)
-	asynqSvc := services.NewAsynqService(config.Asynq(), asynqRepo, dbRepo, dbRepo)
+	mailSvc := services.NewMailService(dbRepo, dbRepo, mailRepo, dbRepo, conn.WorkerPool())
+	notificationHub := services.NewNotificationHub(redisClient, methodutil.NotificationChannel())
+	notificationSvc := services.NewNotificationServiceImpl(dbRepo, notificationHub)
+	asynqSvc := services.NewAsynqService(config.Asynq(), asynqRepo, dbRepo, dbRepo, notificationSvc, notifierSvc, dbRepo, dbRepo)
+	taskAdminSvc := services.NewTaskAdminServiceImpl(asynqRepo)
+	trashSvc := services.NewTrashServiceImpl(dbRepo)
+	taxonomySvc := services.NewTaxonomyServiceImpl(dbRepo)
+	venueSvc := services.NewVenueServiceImpl(dbRepo)
 
 	// controllers
 	eventCtrl := controllers.NewEventController(eventSvc, mailSvc, asynqSvc)
 	userCtrl := controllers.NewUserController(userSvc)
 	authCtrl := controllers.NewAuthController(authSvc)
+	notificationCtrl := controllers.NewNotificationController(notificationSvc, notificationHub)
+	webhookCtrl := controllers.NewWebhookController(mailSvc, config.Email())
+	taskAdminCtrl := controllers.NewTaskAdminController(taskAdminSvc)
+	trashCtrl := controllers.NewTrashController(trashSvc)
+	taxonomyCtrl := controllers.NewTaxonomyController(taxonomySvc)
+	venueCtrl := controllers.NewVenueController(venueSvc)
+	healthCtrl := controllers.NewHealthController(healthChecker())
 
 	// middlewares
 	authMiddleware := middlewares.NewAuthMiddleware(authSvc, userSvc)
+	apiLimiter := services.NewSlidingWindowLimiter(redisClient, methodutil.RateLimitKey("api_"))
+	rateLimitMiddleware := middlewares.NewRateLimitMiddleware(apiLimiter, config.RateLimit())
+	idempotencyMiddleware := middlewares.NewIdempotencyMiddleware(services.NewIdempotencyStore(redisClient), config.Idempotency())
 
 	// Server
 	var echo_ = echo.New()
-	var Routes = routes.New(echo_, eventCtrl, userCtrl, authCtrl, authMiddleware)
-	var Server = server.New(echo_)
+	var Routes = routes.New(echo_, eventCtrl, userCtrl, authCtrl, notificationCtrl, webhookCtrl, taskAdminCtrl, trashCtrl, taxonomyCtrl, venueCtrl, healthCtrl, authMiddleware, rateLimitMiddleware, idempotencyMiddleware)
+	var Server = server.New(echo_, config.App().Port)
 
 	// Spooling
 	Routes.Init()
 
-	// Stopping running workers
-	Server.Start()
+	lc := newLifecycle()
+	lc.Go("notification hub", func() error {
+		notificationHub.Run()
+		return nil
+	})
+	lc.Go("http server", Server.Start)
+
+	// closing the hub ends the open notification streams, which would otherwise
+	// hold the server's drain until the timeout
+	lc.OnStop("notification hub", func(ctx context.Context) error { return notificationHub.Close() })
+	lc.OnStop("http server", Server.Shutdown)
+	closeConnections(lc)
+	// last, to flush the spans of the shutdown too
+	lc.OnStop("tracing", shutdownTracing)
+
+	if err := lc.Wait(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/tracing"
+)
+
+// initTracing starts exporting the spans of the component and returns the func
+// flushing them. A broken exporter setup only costs the traces, the component
+// still runs.
+func initTracing(component string) func(ctx context.Context) error {
+	shutdown, err := tracing.Init(config.Tracing(), config.App().Name+"-"+component)
+	if err != nil {
+		slog.Error("failed to initialize tracing, running without it", "err", err)
+		return func(ctx context.Context) error { return nil }
+	}
+	return shutdown
+}
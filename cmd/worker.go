@@ -1,16 +1,26 @@
 package cmd
 
 import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
 	asynq_ "github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
 	"github.com/spf13/cobra"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/conn"
 	"github.com/vivasoft-ltd/go-ems/controllers"
+	"github.com/vivasoft-ltd/go-ems/metrics"
 	asynq_repo "github.com/vivasoft-ltd/go-ems/repositories/asynq"
 	db_repo "github.com/vivasoft-ltd/go-ems/repositories/db"
 	mail_repo "github.com/vivasoft-ltd/go-ems/repositories/mail"
+	"github.com/vivasoft-ltd/go-ems/routes"
+	"github.com/vivasoft-ltd/go-ems/server"
 	"github.com/vivasoft-ltd/go-ems/services"
 	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
 	"github.com/vivasoft-ltd/go-ems/worker"
 )
 
@@ -19,9 +29,18 @@ var workerCmd = &cobra.Command{
 	Run: runWorker,
 }
 
+var workerQueues []string
+
+func init() {
+	workerCmd.Flags().StringSliceVar(&workerQueues, "queues", nil, "comma separated queues to serve, defaults to every configured queue")
+}
+
 func runWorker(cmd *cobra.Command, args []string) {
+	shutdownTracing := initTracing("worker")
+
 	// clients
 	dbClient := conn.Db()
+	redisClient := conn.Redis()
 	emailClient := conn.EmailClient()
 	asynqClient := conn.Asynq()
 	asynqInspector := conn.AsynqInspector()
@@ -32,18 +51,68 @@ func runWorker(cmd *cobra.Command, args []string) {
 	mailRepo := mail_repo.NewRepository(emailClient, config.Email())
 
 	// services
-	mailSvc := services.NewMailService(dbRepo, dbRepo, mailRepo)
-	asynqSvc := services.NewAsynqService(config.Asynq(), asynqRepo, dbRepo, dbRepo)
+	mailSvc := services.NewMailService(dbRepo, dbRepo, mailRepo, dbRepo, conn.WorkerPool())
+	// the worker only publishes, serve replicas run the hub subscription
+	notificationHub := services.NewNotificationHub(redisClient, methodutil.NotificationChannel())
+	notificationSvc := services.NewNotificationServiceImpl(dbRepo, notificationHub)
+	notifierSvc := services.NewNotifierServiceImpl(dbRepo, notifierChannels()...)
+	asynqSvc := services.NewAsynqService(config.Asynq(), asynqRepo, dbRepo, dbRepo, notificationSvc, notifierSvc, dbRepo, dbRepo)
+	trashSvc := services.NewTrashServiceImpl(dbRepo)
+	emailLimiter := services.NewTokenBucketLimiter(redisClient, methodutil.RateLimitKey("email"), config.Email().RateLimit, config.Email().RateBurst)
 
 	// controllers
-	asynqCtrl := controllers.NewAsynqController(mailSvc, asynqSvc)
+	asynqCtrl := controllers.NewAsynqController(mailSvc, asynqSvc, notifierSvc, trashSvc, emailLimiter)
 
 	mux := asynq_.NewServeMux()
+	mux.Use(metrics.TaskMiddleware)
 
 	mux.HandleFunc(types.AsynqTaskTypeInvitationEmail.String(), asynqCtrl.ProcessInvitationEmailTask)
 	mux.HandleFunc(types.AsynqTaskTypeEventReminder.String(), asynqCtrl.ProcessEventReminderTask)
-	mux.HandleFunc(types.AsynqTaskTypeEventReminderEmail.String(), asynqCtrl.ProcessEventReminderTask)
-	// Start the Asynq worker
-	worker.StartAsynqWorker(mux)
+	// the reminder task fans out to one reminder email task per attendee, which
+	// carry an email payload and must not go back to the fan-out handler
+	mux.HandleFunc(types.AsynqTaskTypeEventReminderEmail.String(), asynqCtrl.ProcessEventReminderEmailTask)
+	mux.HandleFunc(types.AsynqTaskTypeEventCancellation.String(), asynqCtrl.ProcessEventCancellationTask)
+	mux.HandleFunc(types.AsynqTaskTypeCancellationEmail.String(), asynqCtrl.ProcessCancellationEmailTask)
+	mux.HandleFunc(types.AsynqTaskTypeChannelNotification.String(), asynqCtrl.ProcessChannelNotificationTask)
+	mux.HandleFunc(types.AsynqTaskTypeInvitationBatch.String(), asynqCtrl.ProcessInvitationBatchTask)
+	mux.HandleFunc(types.AsynqTaskTypePurgeTrash.String(), asynqCtrl.ProcessPurgeTrashTask)
+
+	lc := newLifecycle()
+	asynqWorker := worker.NewAsynqWorker(workerQueues, config.App().DrainTimeout*time.Second)
+	if err := asynqWorker.Start(mux); err != nil {
+		slog.Error("failed to start the asynq worker", "err", err)
+		os.Exit(1)
+	}
+
+	// every worker schedules the purge, the task ID keeps it to one run per interval
+	purgeInterval := config.Trash().PurgeInterval * time.Second
+	purgeScheduler := worker.NewScheduler(purgeInterval)
+	purgeScheduler.Start(func() {
+		before := time.Now().Truncate(purgeInterval).Add(-config.Trash().Retention * time.Second)
+		if err := asynqSvc.CreatePurgeTrashTask(context.Background(), before); err != nil {
+			slog.Error("failed to schedule trash purge", "err", err)
+		}
+	})
+
+	// health and metrics server
+	healthEcho := echo.New()
+	healthEcho.HideBanner = true
+	routes.Metrics(healthEcho)
+	routes.Health(healthEcho, controllers.NewHealthController(healthChecker()))
+	healthServer := server.New(healthEcho, config.App().WorkerHealthPort)
+	lc.Go("health server", healthServer.Start)
+
+	lc.OnStop("purge scheduler", func(ctx context.Context) error {
+		purgeScheduler.Stop()
+		return nil
+	})
+	lc.OnStop("asynq worker", asynqWorker.Shutdown)
+	lc.OnStop("health server", healthServer.Shutdown)
+	closeConnections(lc)
+	// last, to flush the spans of the shutdown too
+	lc.OnStop("tracing", shutdownTracing)
 
+	if err := lc.Wait(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -1,19 +1,23 @@
 package config
 
 import (
-	"encoding/json"
-	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/spf13/viper"
 	_ "github.com/spf13/viper/remote"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 )
 
 type AppConfig struct {
 	Name            string
 	Port            string
 	NumberOfWorkers int
+	DrainTimeout    time.Duration // in seconds
+
+	WorkerHealthPort   string
+	HealthCheckTimeout time.Duration // in seconds
 }
 
 type DbConfig struct {
@@ -40,25 +44,67 @@ type RedisConfig struct {
 	PermissionPrefix   string
 	UserCacheTTL       time.Duration
 	PermissionCacheTTL time.Duration
+	NotificationPrefix string
+	RateLimitPrefix    string
+	IdempotencyPrefix  string
 }
 
 type AsynqConfig struct {
-	RedisAddr                        string
-	DB                               int
-	Pass                             string
-	Concurrency                      int
-	Queue                            string
-	Retention                        time.Duration // in hours
-	RetryCount                       int
-	Delay                            time.Duration // in seconds
-	EmailInvitationTaskDelay         time.Duration // in seconds
-	EmailInvitationTaskRetryCount    int
-	EmailInvitationTaskRetryDelay    time.Duration // in seconds
-	EventReminderTaskRetryCount      int
-	EventReminderTaskRetryDelay      time.Duration // in seconds
-	EventReminderEmailTaskDelay      time.Duration // in seconds
-	EventReminderEmailTaskRetryCount int
-	EventReminderEmailTaskRetryDelay time.Duration // in seconds
+	RedisAddr                         string
+	DB                                int
+	Pass                              string
+	Concurrency                       int
+	Queue                             string
+	Retention                         time.Duration // in hours
+	RetryCount                        int
+	Delay                             time.Duration // in seconds
+	EmailInvitationTaskDelay          time.Duration // in seconds
+	EmailInvitationTaskRetryCount     int
+	EmailInvitationTaskRetryDelay     time.Duration // in seconds
+	EventReminderTaskRetryCount       int
+	EventReminderTaskRetryDelay       time.Duration // in seconds
+	EventReminderEmailTaskDelay       time.Duration // in seconds
+	EventReminderEmailTaskRetryCount  int
+	EventReminderEmailTaskRetryDelay  time.Duration // in seconds
+	EventCancellationTaskRetryCount   int
+	EventCancellationTaskRetryDelay   time.Duration // in seconds
+	CancellationEmailTaskRetryCount   int
+	CancellationEmailTaskRetryDelay   time.Duration // in seconds
+	ChannelNotificationTaskRetryCount int
+	ChannelNotificationTaskRetryDelay time.Duration // in seconds
+	InvitationBatchSize               int
+	InvitationBatchTaskRetryCount     int
+	InvitationBatchTaskRetryDelay     time.Duration // in seconds
+
+	TaskQueues       map[string]string // task type -> queue, unlisted types go to Queue
+	QueuePriorities  map[string]int    // queue -> weight, unlisted queues weigh 1
+	StrictPriority   bool
+	QueueConcurrency map[string]int // queue -> concurrency of a dedicated worker server
+}
+
+// TaskQueue returns the queue the given task type is routed to.
+func (c *AsynqConfig) TaskQueue(taskType string) string {
+	if queue, ok := c.TaskQueues[taskType]; ok && queue != "" {
+		return queue
+	}
+	return c.Queue
+}
+
+// QueueWeights returns every configured queue with its priority weight.
+func (c *AsynqConfig) QueueWeights() map[string]int {
+	weights := map[string]int{c.Queue: 1}
+	for _, queue := range c.TaskQueues {
+		weights[queue] = 1
+	}
+	for queue := range c.QueueConcurrency {
+		weights[queue] = 1
+	}
+	for queue, weight := range c.QueuePriorities {
+		if weight > 0 {
+			weights[queue] = weight
+		}
+	}
+	return weights
 }
 
 type JwtConfig struct {
@@ -74,18 +120,93 @@ type LoggerConfig struct {
 }
 
 type EmailConfig struct {
-	Url     string
-	Timeout time.Duration
+	Url           string
+	Timeout       time.Duration
+	WebhookSecret string
+	RateLimit     float64 // emails per second shared by all workers, 0 disables the limit
+	RateBurst     int
+}
+
+type NotifierConfig struct {
+	Provider       string // "http" or "stub"
+	SmsUrl         string
+	PushUrl        string
+	StubFilePath   string
+	Timeout        time.Duration // in seconds
+	PhoneOtpPrefix string
+	PhoneOtpTTL    time.Duration // in seconds
+	PhoneOtpLength int
+	// PhoneOtpMaxAttempts is the number of wrong codes after which the code is
+	// invalidated and a new one has to be requested
+	PhoneOtpMaxAttempts int
+}
+
+type TracingConfig struct {
+	Enabled     bool
+	Endpoint    string  // OTLP/HTTP collector host:port
+	Insecure    bool    // plain HTTP to the collector
+	SampleRatio float64 // share of new traces sampled, 0 to 1
+}
+
+type RateLimitRule struct {
+	Limit  int           // requests per window, 0 disables the limit
+	Window time.Duration // in seconds
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	// Groups holds the rule of each route group, "default" applies to the
+	// groups without one.
+	Groups map[string]RateLimitRule
+	// ApiKeys are the X-API-Key values that get their own limit instead of
+	// sharing the one of their IP.
+	ApiKeys []string
+	// TrustProxyHeaders takes the client IP from X-Forwarded-For and X-Real-IP,
+	// only enable it behind a proxy that sets them.
+	TrustProxyHeaders bool
+}
+
+// Rule returns the rule of the route group.
+func (c *RateLimitConfig) Rule(group string) RateLimitRule {
+	if rule, ok := c.Groups[group]; ok {
+		return rule
+	}
+	return c.Groups["default"]
+}
+
+type IdempotencyConfig struct {
+	Window      time.Duration // in seconds, how long responses are replayed
+	LockTimeout time.Duration // in seconds, how long a retry waits for the first request
+}
+
+type TrashConfig struct {
+	Retention     time.Duration // in seconds, how long deleted events and users can be restored
+	PurgeInterval time.Duration // in seconds, how often the worker purges the expired ones
+}
+
+type PaginationConfig struct {
+	CursorSecret string // signs the cursor tokens of keyset pagination
+}
+
+type EventConfig struct {
+	RequireApproval bool // events of users without event.approve wait for approval before they are published
 }
 
 type Config struct {
-	App    *AppConfig
-	DB     *DbConfig
-	Redis  *RedisConfig
-	Asynq  *AsynqConfig
-	Logger *LoggerConfig
-	Jwt    *JwtConfig
-	Email  *EmailConfig
+	App         *AppConfig
+	DB          *DbConfig
+	Redis       *RedisConfig
+	Asynq       *AsynqConfig
+	Logger      *LoggerConfig
+	Jwt         *JwtConfig
+	Email       *EmailConfig
+	Notifier    *NotifierConfig
+	Tracing     *TracingConfig
+	RateLimit   *RateLimitConfig
+	Idempotency *IdempotencyConfig
+	Trash       *TrashConfig
+	Pagination  *PaginationConfig
+	Event       *EventConfig
 }
 
 var config Config
@@ -122,6 +243,34 @@ func Email() *EmailConfig {
 	return config.Email
 }
 
+func Notifier() *NotifierConfig {
+	return config.Notifier
+}
+
+func Tracing() *TracingConfig {
+	return config.Tracing
+}
+
+func RateLimit() *RateLimitConfig {
+	return config.RateLimit
+}
+
+func Idempotency() *IdempotencyConfig {
+	return config.Idempotency
+}
+
+func Trash() *TrashConfig {
+	return config.Trash
+}
+
+func Pagination() *PaginationConfig {
+	return config.Pagination
+}
+
+func Event() *EventConfig {
+	return config.Event
+}
+
 func LoadConfig() {
 	setDefaultConfig()
 
@@ -147,15 +296,13 @@ func LoadConfig() {
 			panic(err)
 		}
 
-		config = Config{}
-
+		// decoded onto the defaults, sections and keys missing in consul keep them
 		if err := viper.Unmarshal(&config); err != nil {
 			panic(err)
 		}
 
-		if r, err := json.MarshalIndent(&config, "", "  "); err == nil {
-			fmt.Println(string(r))
-		}
+		// printed before the default logger is set up, secrets are redacted
+		logutil.New(os.Stdout, "info").Info("loaded config", "config", config)
 	} else {
 		log.Println("CONSUL_URL or CONSUL_PATH missing! Serving with default config...")
 	}
@@ -166,6 +313,10 @@ func setDefaultConfig() {
 		Name:            "app",
 		Port:            "8080",
 		NumberOfWorkers: 5,
+		DrainTimeout:    30,
+
+		WorkerHealthPort:   "8081",
+		HealthCheckTimeout: 2,
 	}
 
 	config.DB = &DbConfig{
@@ -192,6 +343,9 @@ func setDefaultConfig() {
 		PermissionPrefix:   "permissions_",
 		UserCacheTTL:       3600,
 		PermissionCacheTTL: 86400,
+		NotificationPrefix: "notifications",
+		RateLimitPrefix:    "rate-limit_",
+		IdempotencyPrefix:  "idempotency_",
 	}
 
 	config.Asynq = &AsynqConfig{
@@ -214,4 +368,42 @@ func setDefaultConfig() {
 		AccessTokenExpiry:  3600,
 		RefreshTokenExpiry: 24 * time.Hour,
 	}
+	config.Notifier = &NotifierConfig{
+		Provider:            "stub",
+		StubFilePath:        "logs/notifier_stub.log",
+		Timeout:             5,
+		PhoneOtpPrefix:      "phone-otp_",
+		PhoneOtpTTL:         600,
+		PhoneOtpLength:      6,
+		PhoneOtpMaxAttempts: 5,
+	}
+	config.Tracing = &TracingConfig{
+		Enabled:     false,
+		Endpoint:    "127.0.0.1:4318",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+	config.RateLimit = &RateLimitConfig{
+		Enabled: true,
+		Groups: map[string]RateLimitRule{
+			"default": {Limit: 300, Window: 60},
+			"public":  {Limit: 60, Window: 60},
+			"auth":    {Limit: 10, Window: 60},
+			"client":  {Limit: 1200, Window: 60},
+		},
+	}
+	config.Idempotency = &IdempotencyConfig{
+		Window:      86400,
+		LockTimeout: 30,
+	}
+	config.Trash = &TrashConfig{
+		Retention:     2592000,
+		PurgeInterval: 3600,
+	}
+	config.Pagination = &PaginationConfig{
+		CursorSecret: "secret_cursor",
+	}
+	config.Event = &EventConfig{
+		RequireApproval: false,
+	}
 }
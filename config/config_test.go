@@ -0,0 +1,65 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestAsynqConfigTaskQueue(t *testing.T) {
+	conf := &AsynqConfig{
+		Queue:      "default",
+		TaskQueues: map[string]string{"event:reminder_email": "critical", "event:invitation_email": ""},
+	}
+
+	tests := map[string]string{
+		"event:reminder_email":   "critical",
+		"event:invitation_email": "default",
+		"trash:purge":            "default",
+	}
+	for taskType, want := range tests {
+		if got := conf.TaskQueue(taskType); got != want {
+			t.Errorf("TaskQueue(%q) = %q, want %q", taskType, got, want)
+		}
+	}
+}
+
+func TestAsynqConfigQueueWeights(t *testing.T) {
+	conf := &AsynqConfig{
+		Queue:            "default",
+		TaskQueues:       map[string]string{"event:reminder_email": "critical", "event:invitation_batch": "bulk"},
+		QueuePriorities:  map[string]int{"critical": 6, "bulk": 0},
+		QueueConcurrency: map[string]int{"reports": 2},
+	}
+
+	want := map[string]int{"default": 1, "critical": 6, "bulk": 1, "reports": 1}
+	if got := conf.QueueWeights(); !reflect.DeepEqual(got, want) {
+		t.Errorf("QueueWeights() = %v, want %v", got, want)
+	}
+}
+
+func TestRemoteConfigKeepsDefaults(t *testing.T) {
+	setDefaultConfig()
+	defer setDefaultConfig()
+
+	v := viper.New()
+	v.SetConfigType("json")
+	if err := v.ReadConfig(strings.NewReader(`{"app": {"port": "9090"}, "db": {"host": "db"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Unmarshal(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.App.Port != "9090" || config.DB.Host != "db" {
+		t.Errorf("remote values were not applied: port %q, host %q", config.App.Port, config.DB.Host)
+	}
+	if config.App.Name != "app" {
+		t.Errorf("App.Name = %q, want the default", config.App.Name)
+	}
+	if config.Event == nil || config.Trash == nil || config.RateLimit == nil || config.Idempotency == nil || config.Pagination == nil {
+		t.Errorf("sections missing from the remote config lost their defaults: %+v", config)
+	}
+}
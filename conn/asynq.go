@@ -31,3 +31,16 @@ func Asynq() *asynq.Client {
 func AsynqInspector() *asynq.Inspector {
 	return asynqInspector
 }
+
+func CloseAsynq() error {
+	var err error
+	if asyncClient != nil {
+		err = asyncClient.Close()
+	}
+	if asynqInspector != nil {
+		if inspectorErr := asynqInspector.Close(); err == nil {
+			err = inspectorErr
+		}
+	}
+	return err
+}
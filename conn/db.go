@@ -2,10 +2,11 @@ package conn
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/vivasoft-ltd/go-ems/config"
-	log "github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/tracing"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -16,14 +17,16 @@ var db *gorm.DB
 func ConnectDb() {
 	conf := config.Db()
 
-	log.Info("connecting to mysql at ", conf.Host, ":", conf.Port, "...")
+	slog.Info("connecting to mysql...", "host", conf.Host, "port", conf.Port)
 
 	logMode := logger.Silent
 	if conf.Debug {
 		logMode = logger.Info
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", conf.User, conf.Pass, conf.Host, conf.Port, conf.Schema)
+	// times are written and read in UTC, and the session time zone makes the
+	// CURRENT_TIMESTAMP defaults UTC as well
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=UTC&time_zone=%%27%%2B00%%3A00%%27", conf.User, conf.Pass, conf.Host, conf.Port, conf.Schema)
 
 	dB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		//PrepareStmt: true,
@@ -33,6 +36,10 @@ func ConnectDb() {
 		panic(err)
 	}
 
+	if err := dB.Use(tracing.NewGormPlugin()); err != nil {
+		panic(err)
+	}
+
 	sqlDb, err := dB.DB()
 	if err != nil {
 		panic(err)
@@ -50,9 +57,20 @@ func ConnectDb() {
 
 	db = dB
 
-	log.Info("mysql connection successful...")
+	slog.Info("mysql connection successful...")
 }
 
 func Db() *gorm.DB {
 	return db
 }
+
+func CloseDb() error {
+	if db == nil {
+		return nil
+	}
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.Close()
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/vivasoft-ltd/go-ems/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 var emailClient *http.Client
@@ -13,6 +14,8 @@ func ConnectEmail() {
 	config := config.Email()
 	timeout := config.Timeout * time.Second
 	emailClient = newHTTPClient(timeout, 50)
+	// records the request span and sends traceparent to the mail service
+	emailClient.Transport = otelhttp.NewTransport(emailClient.Transport)
 }
 
 func EmailClient() *http.Client {
@@ -0,0 +1,18 @@
+package conn
+
+import (
+	"net/http"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+)
+
+var notifierClient *http.Client
+
+func ConnectNotifier() {
+	conf := config.Notifier()
+	notifierClient = newHTTPClient(toSecond(conf.Timeout), DefaultMaxIdleConnsPerHost*10)
+}
+
+func NotifierClient() *http.Client {
+	return notifierClient
+}
@@ -1,8 +1,9 @@
 package conn
 
 import (
+	"log/slog"
+
 	"github.com/vivasoft-ltd/go-ems/config"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
 
 	"github.com/go-redis/redis"
 )
@@ -12,7 +13,7 @@ var client *redis.Client
 func ConnectRedis() {
 	conf := config.Redis()
 
-	logger.Info("connecting to redis at ", conf.Host, ":", conf.Port, "...")
+	slog.Info("connecting to redis...", "host", conf.Host, "port", conf.Port)
 
 	client = redis.NewClient(&redis.Options{
 		Addr:     conf.Host + ":" + conf.Port,
@@ -21,13 +22,20 @@ func ConnectRedis() {
 	})
 
 	if _, err := client.Ping().Result(); err != nil {
-		logger.Error("failed to connect redis: ", err)
+		slog.Error("failed to connect redis", "err", err)
 		panic(err)
 	}
 
-	logger.Info("redis connection successful...")
+	slog.Info("redis connection successful...")
 }
 
 func Redis() *redis.Client {
 	return client
 }
+
+func CloseRedis() error {
+	if client == nil {
+		return nil
+	}
+	return client.Close()
+}
@@ -1,6 +1,8 @@
 package conn
 
 import (
+	"context"
+
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/worker"
 )
@@ -8,10 +10,18 @@ import (
 var workerPool *worker.Pool
 
 func ConnectWorker() {
-	workerPool = worker.NewPool(config.App().NumberOfWorkers, 2*config.App().NumberOfWorkers)
+	workerPool = worker.NewPool(config.App().NumberOfWorkers, 2*config.App().NumberOfWorkers, worker.WithName("app"))
 	workerPool.Start()
 }
 
 func WorkerPool() *worker.Pool {
 	return workerPool
 }
+
+// CloseWorker stops the pool, waiting for the queued tasks until ctx is done.
+func CloseWorker(ctx context.Context) error {
+	if workerPool == nil {
+		return nil
+	}
+	return workerPool.Shutdown(ctx)
+}
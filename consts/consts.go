@@ -14,6 +14,12 @@ const (
 	DefaultPageSize = 10
 	DefaultPage     = 1
 
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	MaxEventTags = 10
+	MaxTagLength = 50
+
 	PermissionUserCreate             = "user.create"       // Permission to create a new user
 	PermissionUserUpdate             = "user.update"       // Permission to update an existing user's information
 	PermissionUserFetch              = "user.fetch"        // Permission to fetch a specific user's data
@@ -30,12 +36,67 @@ const (
 	PermissionFetchAllEvent     = "event.fetchAllEvent"
 	PermissionFetchOwnEvent     = "event.fetchOwnEvent"
 	PermissionFetchInvitedEvent = "event.fetchInvitedEvent"
+	PermissionEventApprove      = "event.approve" // Permission to approve or reject events submitted for publishing
+
+	PermissionTaskManage  = "task.manage"  // Permission to inspect and manage background task queues
+	PermissionTrashManage = "trash.manage" // Permission to list and restore deleted events and users
+
+	PermissionTaxonomyManage = "taxonomy.manage" // Permission to manage event categories and tags
+	PermissionVenueManage    = "venue.manage"    // Permission to manage venues and rooms
+
+	EventStatusDraft           = "draft"
+	EventStatusPendingApproval = "pending_approval"
+	EventStatusPublished       = "published"
+	EventStatusCancelled       = "cancelled"
+	EventStatusCompleted       = "completed"
+
+	EventReviewApproved = "approved"
+	EventReviewRejected = "rejected"
 
 	StatusInvited  = 1
 	StatusAccepted = 2
 	StatusRejected = 3
 
 	EventReminderInterval = time.Duration(10 * time.Minute)
+
+	NotificationTypeEventInvitation = "event_invitation"
+	NotificationTypeEventReminder   = "event_reminder"
+	NotificationTypeEventCancelled  = "event_cancelled"
+
+	NotificationStreamHeartbeat = 30 * time.Second
+
+	NotifierChannelSms  = "sms"
+	NotifierChannelPush = "push"
+
+	NotifierProviderHttp = "http"
+	NotifierProviderStub = "stub"
+
+	EmailDeliveryStatusQueued     = "queued"
+	EmailDeliveryStatusSent       = "sent"
+	EmailDeliveryStatusFailed     = "failed"
+	EmailDeliveryStatusBounced    = "bounced"
+	EmailDeliveryStatusComplained = "complained"
+
+	InvitationBatchStatusPending   = "pending"
+	InvitationBatchStatusRunning   = "running"
+	InvitationBatchStatusCompleted = "completed"
+	DefaultInvitationBatchSize     = 500
+
+	EmailDeliveryEventBounce    = "bounce"
+	EmailDeliveryEventComplaint = "complaint"
+
+	HeaderWebhookSecret = "X-Webhook-Secret"
+
+	TaskStatePending   = "pending"
+	TaskStateActive    = "active"
+	TaskStateScheduled = "scheduled"
+	TaskStateRetry     = "retry"
+	TaskStateArchived  = "archived"
+	TaskStateCompleted = "completed"
+
+	TaskActionRun     = "run"
+	TaskActionArchive = "archive"
+	TaskActionDelete  = "delete"
 )
 
 var RoleMap = map[int]string{
@@ -43,3 +104,7 @@ var RoleMap = map[int]string{
 	RoleIdManager:  RoleManager,
 	RoleIdAttendee: RoleAttendee,
 }
+
+// PublishedEventStatuses are the statuses of events that have been published,
+// the events other users than the creator may see.
+var PublishedEventStatuses = []string{EventStatusPublished, EventStatusCancelled, EventStatusCompleted}
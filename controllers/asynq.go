@@ -3,55 +3,101 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/metrics"
 	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/tracing"
 	"github.com/vivasoft-ltd/go-ems/types"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type AsynqController struct {
-	mailSvc  domain.MailService
-	asynqSvc domain.AsynqService
+	mailSvc      domain.MailService
+	asynqSvc     domain.AsynqService
+	notifierSvc  domain.NotifierService
+	trashSvc     domain.TrashService
+	emailLimiter domain.RateLimiter
 }
 
-func NewAsynqController(mailSvc domain.MailService, asynqSvc domain.AsynqService) *AsynqController {
+func NewAsynqController(mailSvc domain.MailService, asynqSvc domain.AsynqService, notifierSvc domain.NotifierService, trashSvc domain.TrashService, emailLimiter domain.RateLimiter) *AsynqController {
 	return &AsynqController{
-		mailSvc:  mailSvc,
-		asynqSvc: asynqSvc,
+		mailSvc:      mailSvc,
+		asynqSvc:     asynqSvc,
+		notifierSvc:  notifierSvc,
+		trashSvc:     trashSvc,
+		emailLimiter: emailLimiter,
 	}
 }
 
-func (ac *AsynqController) ProcessInvitationEmailTask(ctx context.Context, t *asynq.Task) (err error) {
-	logger.Info(fmt.Sprintf("Received task event [%s] with ID [%s]", t.Type(), t.ResultWriter().TaskID()))
-	var payload types.EmailPayload
+func (ac *AsynqController) ProcessInvitationEmailTask(ctx context.Context, t *asynq.Task) error {
+	return ac.processEmailTask(ctx, t, "invitation email")
+}
+
+func (ac *AsynqController) ProcessInvitationBatchTask(ctx context.Context, t *asynq.Task) (err error) {
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
+	var payload types.InvitationBatchPayload
+
+	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
+		log.Error("failed to decode task payload", "err", err)
+		return
+	}
+
+	err = ac.asynqSvc.ProcessInvitationBatch(ctx, payload.EventID)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		// the event or its batch is gone, there is nobody left to invite
+		return fmt.Errorf("%w: %v", asynq.SkipRetry, err)
+	}
+	if err != nil {
+		log.Error("failed to process invitation batch", "err", err, "event_id", payload.EventID)
+		return err
+	}
+	t.ResultWriter().Write([]byte(fmt.Sprintf("Invitation batch processed successfully for event: %d", payload.EventID)))
+	return
+}
+
+func (ac *AsynqController) ProcessPurgeTrashTask(ctx context.Context, t *asynq.Task) (err error) {
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
+	var payload types.PurgeTrashPayload
 
 	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
-		logger.Error(err)
+		log.Error("failed to decode task payload", "err", err)
 		return
 	}
 
-	if err = ac.mailSvc.SendEmail(payload); err != nil {
-		logger.Error(fmt.Sprintf("err: [%v] occurred while sending email to: %s", err, payload.MailTo))
+	if err = ac.trashSvc.Purge(ctx, payload.Before); err != nil {
+		log.Error("failed to purge trash", "err", err, "before", payload.Before)
 		return err
 	}
-	t.ResultWriter().Write([]byte(fmt.Sprintf("Email sent successfully to %s", payload.MailTo)))
+	t.ResultWriter().Write([]byte(fmt.Sprintf("Trash purged up to %s", payload.Before.Format(time.RFC3339))))
 	return
 }
 
 func (ac *AsynqController) ProcessEventReminderTask(ctx context.Context, t *asynq.Task) (err error) {
-	logger.Info(fmt.Sprintf("Received task event [%s] with ID [%s]", t.Type(), t.ResultWriter().TaskID()))
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
 	var payload models.Event
 
 	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
-		logger.Error(err)
+		log.Error("failed to decode task payload", "err", err)
 		return
 	}
 
-	if err = ac.asynqSvc.CreateEventReminderEmailTasks(&payload); err != nil {
-		logger.Error(fmt.Sprintf("err: [%v] occurred while creating event reminder email tasks for event: %s", err, payload.Title))
+	if err = ac.asynqSvc.CreateEventReminderEmailTasks(ctx, &payload); err != nil {
+		log.Error("failed to create event reminder email tasks", "err", err, "event_id", payload.ID)
 		return err
 	}
 	t.ResultWriter().Write([]byte(fmt.Sprintf("Event reminder email tasks created successfully for event: %s", payload.Title)))
@@ -59,19 +105,134 @@ func (ac *AsynqController) ProcessEventReminderTask(ctx context.Context, t *asyn
 	return
 }
 
-func (ac *AsynqController) ProcessEventReminderEmailTask(ctx context.Context, t *asynq.Task) (err error) {
-	logger.Info(fmt.Sprintf("Received task event [%s] with ID [%s]", t.Type(), t.ResultWriter().TaskID()))
+func (ac *AsynqController) ProcessEventReminderEmailTask(ctx context.Context, t *asynq.Task) error {
+	return ac.processEmailTask(ctx, t, "event reminder email")
+}
+
+func (ac *AsynqController) ProcessEventCancellationTask(ctx context.Context, t *asynq.Task) (err error) {
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
+	var payload types.EventCancellationPayload
+
+	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
+		log.Error("failed to decode task payload", "err", err)
+		return
+	}
+
+	err = ac.asynqSvc.CreateEventCancellationTasks(ctx, payload.EventID)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		// the event is gone, there is nobody left to tell
+		return fmt.Errorf("%w: %v", asynq.SkipRetry, err)
+	}
+	if err != nil {
+		log.Error("failed to create event cancellation tasks", "err", err, "event_id", payload.EventID)
+		return err
+	}
+	t.ResultWriter().Write([]byte(fmt.Sprintf("Event cancellation tasks created successfully for event: %d", payload.EventID)))
+	return
+}
+
+func (ac *AsynqController) ProcessCancellationEmailTask(ctx context.Context, t *asynq.Task) error {
+	return ac.processEmailTask(ctx, t, "cancellation email")
+}
+
+func (ac *AsynqController) ProcessChannelNotificationTask(ctx context.Context, t *asynq.Task) (err error) {
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
+	var payload types.ChannelNotificationPayload
+
+	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
+		log.Error("failed to decode task payload", "err", err)
+		return
+	}
+
+	err = ac.notifierSvc.Notify(ctx, payload.Channel, payload.UserID, &payload.Message)
+	switch {
+	case errutil.Exists(err, []error{errutil.ErrNotifierChannelDisabled, errutil.ErrUserNotFound}):
+		// the user opted out or is gone since the task was enqueued
+		t.ResultWriter().Write([]byte(fmt.Sprintf("Skipped %s notification for user %d: %v", payload.Channel, payload.UserID, err)))
+		return nil
+	case errors.Is(err, errutil.ErrUnknownNotifierChannel):
+		log.Error("failed to send channel notification", "err", err, "channel", payload.Channel, "user_id", payload.UserID)
+		return fmt.Errorf("%w: %v", asynq.SkipRetry, err)
+	case err != nil:
+		log.Error("failed to send channel notification", "err", err, "channel", payload.Channel, "user_id", payload.UserID)
+		return err
+	}
+
+	t.ResultWriter().Write([]byte(fmt.Sprintf("%s notification sent successfully to user %d", payload.Channel, payload.UserID)))
+	return
+}
+
+// startTask continues the trace of the request that enqueued the task and tags
+// the task's logs with its ID and type.
+func startTask(ctx context.Context, t *asynq.Task) (context.Context, trace.Span) {
+	taskID := t.ResultWriter().TaskID()
+
+	ctx = tracing.ExtractPayload(ctx, t.Payload())
+	ctx, span := tracing.Start(ctx, "asynq "+t.Type(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "asynq"),
+			attribute.String("messaging.message.id", taskID),
+		),
+	)
+
+	ctx = logutil.With(ctx, "task_id", taskID, "task_type", t.Type())
+	logutil.FromContext(ctx).Info("received task")
+	return ctx, span
+}
+
+// processEmailTask sends the email of the task, kind names the email in the
+// task's logs and result.
+func (ac *AsynqController) processEmailTask(ctx context.Context, t *asynq.Task, kind string) (err error) {
+	ctx, span := startTask(ctx, t)
+	defer func() { tracing.End(span, err) }()
+	log := logutil.FromContext(ctx)
 	var payload types.EmailPayload
 
 	if err = json.Unmarshal(t.Payload(), &payload); err != nil {
-		logger.Error(err)
+		log.Error("failed to decode task payload", "err", err)
 		return
 	}
 
-	if err = ac.mailSvc.SendEmail(payload); err != nil {
-		logger.Error(fmt.Sprintf("err: [%v] occurred while sending email to: %s", err, payload.MailTo))
+	err = ac.sendEmail(ctx, t.Type(), payload)
+	if errors.Is(err, errutil.ErrEmailSuppressed) {
+		t.ResultWriter().Write([]byte(fmt.Sprintf("Skipped suppressed recipient %s", payload.MailTo)))
+		return nil
+	}
+	if err != nil {
+		log.Error("failed to send "+kind, "err", err, "message_id", payload.MessageID)
 		return err
 	}
-	t.ResultWriter().Write([]byte(fmt.Sprintf("Event reminder email sent successfully to %s", payload.MailTo)))
+	t.ResultWriter().Write([]byte(fmt.Sprintf("%s sent successfully to %s", kind, payload.MailTo)))
 	return
 }
+
+// sendEmail takes a token from the shared email rate limiter before sending. When
+// the bucket is empty the task is handed back to asynq to retry once a token is
+// expected to be available.
+func (ac *AsynqController) sendEmail(ctx context.Context, taskType string, payload types.EmailPayload) error {
+	wait, err := ac.emailLimiter.Take(ctx)
+	if err != nil {
+		// don't hold emails back on a limiter failure, the provider still has its own limit
+		logutil.FromContext(ctx).Error("failed to take email rate limit token", "err", err)
+	}
+	if wait > 0 {
+		// spread the retries so that the waiting tasks don't come back all at once
+		wait += time.Duration(rand.Int63n(int64(wait)))
+		return &errutil.RetryAfterError{
+			Err:        errutil.ErrEmailRateLimited,
+			RetryAfter: wait,
+		}
+	}
+
+	err = ac.mailSvc.SendEmail(ctx, payload)
+	// suppressed recipients are skipped, not sent to
+	if !errors.Is(err, errutil.ErrEmailSuppressed) {
+		metrics.EmailSent(taskType, err)
+	}
+	return err
+}
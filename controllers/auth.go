@@ -31,7 +31,7 @@ func (ctrl *AuthController) Login(c echo.Context) error {
 		})
 	}
 
-	resp, err := ctrl.authSvc.Login(&req)
+	resp, err := ctrl.authSvc.Login(c.Request().Context(), &req)
 	if err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserNotFound):
@@ -51,7 +51,7 @@ func (ctrl *AuthController) Logout(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 	}
 
-	if err := ctrl.authSvc.Logout(user.AccessUuid, user.RefreshUuid); err != nil {
+	if err := ctrl.authSvc.Logout(c.Request().Context(), user.AccessUuid, user.RefreshUuid); err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
 
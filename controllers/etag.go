@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// etag is the strong ETag of a resource at the version.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// eventETag is the strong ETag of the event as read in the timezone. The
+// version only covers the event's own fields, the attendees are hashed in as
+// they change without a new version, and the timezone as the times are
+// localized to it.
+func eventETag(event *models.Event, timezone string) string {
+	ids := make([]int, 0, len(event.Attendees))
+	for _, attendee := range event.Attendees {
+		ids = append(ids, attendee.ID)
+	}
+	slices.Sort(ids)
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s;", timezone)
+	for _, id := range ids {
+		fmt.Fprintf(h, "%d,", id)
+	}
+	return fmt.Sprintf(`"%d-%x"`, event.Version, h.Sum32())
+}
+
+// ifMatchVersion returns the version required by If-Match, 0 when any version
+// goes. ok is false when the header can't match any version, e.g. a weak tag.
+// Anything after the version in the tag, like the attendees of an event, is
+// ignored.
+func ifMatchVersion(c echo.Context) (version int, ok bool) {
+	header := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	tag, _, _ := strings.Cut(header[1:len(header)-1], "-")
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// notModified reports whether If-None-Match already names the current tag.
+func notModified(c echo.Context, tag string) bool {
+	header := c.Request().Header.Get(headerIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,22 +1,29 @@
 package controllers
 
 import (
+	"context"
 	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/middlewares"
+	"github.com/vivasoft-ltd/go-ems/models"
 
 	v "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/vivasoft-ltd/go-ems/domain"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
 )
 
+const mimeMergePatchJSON = "application/merge-patch+json"
+
 type EventController struct {
 	eventSvc domain.EventService
 	mailSvc  domain.MailService
@@ -38,7 +45,7 @@ func (ctrl *EventController) CreateEvent(c echo.Context) error {
 	}
 
 	if err := req.Validate(); err != nil {
-		logger.Error("validation error: %v", err)
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
 		return c.JSON(http.StatusBadRequest, &types.ValidationError{
 			Error: err,
 		})
@@ -51,34 +58,19 @@ func (ctrl *EventController) CreateEvent(c echo.Context) error {
 
 	req.CreatedBy = user.ID
 
-	resp, err := ctrl.eventSvc.CreateEvent(&req)
-
+	resp, err := ctrl.eventSvc.CreateEvent(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, msgutil.CategoryNotFound())
+	}
+	if status, msg, ok := roomBookingResponse(err); ok {
+		return c.JSON(status, msg)
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
 
-	// go func() {
-	if !req.IsPublic {
-		// if err := ctrl.mailSvc.SendInvitationEmail(req.Attendees, resp.Event); err != nil {
-		// 	logger.Error("failed to send email: %v", err)
-		// }
-		if err := ctrl.asynqSvc.CreateEmailInvitationTasks(req.Attendees, resp.Event); err != nil {
-			logger.Error("failed to enqueue email invitation tasks: %v", err)
-		}
-	}
-	// }()
-
-	// Enqueue event reminder email notification before the event starts
-	go func() {
-		// if err := ctrl.mailSvc.EnqueueEventReminderEmailNotification(resp.Event); err != nil {
-		// 	logger.Error("failed to enqueue event reminder email notification: %v", err)
-		// }
-
-		if err := ctrl.asynqSvc.CreateEventReminderTask(resp.Event); err != nil {
-			logger.Error("failed to enqueue event reminder email notification: %v", err)
-		}
-	}()
-
+	// the event starts as a draft, invitations and reminders wait for PublishEvent
+	resp.Event = localizeEvent(resp.Event, user)
 	return c.JSON(http.StatusCreated, resp)
 }
 
@@ -91,16 +83,26 @@ func (ctrl *EventController) ListEvents(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
 	}
+	if err := req.Validate(); err != nil {
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
 	if req.Limit <= 0 {
 		req.Limit = consts.DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = consts.DefaultPage
 	}
-	events, err := ctrl.eventSvc.ListEvents(req, user)
+	if req.Cursor != nil {
+		return ctrl.listEventsByCursor(c, req, user)
+	}
+	events, err := ctrl.eventSvc.ListEvents(c.Request().Context(), req, user)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
+	events.Events = localizeEvents(events.Events, user)
 	return c.JSON(http.StatusOK, events)
 }
 
@@ -112,20 +114,29 @@ func (ctrl *EventController) ReadEventByID(c echo.Context) error {
 
 	// Validate ID
 	if err := v.Validate(id, v.Required); err != nil {
-		logger.Error("validation error: %v", err)
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
 		return c.JSON(http.StatusBadRequest, &types.ValidationError{
 			Error: err,
 		})
 	}
 
-	event, err := ctrl.eventSvc.ReadEventByID(id)
+	event, err := ctrl.eventSvc.ReadEventByID(c.Request().Context(), id)
 	if errors.Is(err, errutil.ErrRecordNotFound) {
 		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
 	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
-	return c.JSON(http.StatusOK, event)
+
+	user, _ := middlewares.CurrentUserFromCtx(c)
+	loc := viewerLocation(event, user)
+	// a new timezone of the viewer changes the body without a new version
+	tag := eventETag(event, loc.String())
+	c.Response().Header().Set(headerETag, tag)
+	if notModified(c, tag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, types.LocalizeEvent(event, loc))
 }
 
 func (ctrl *EventController) UpdateEvent(c echo.Context) error {
@@ -135,22 +146,122 @@ func (ctrl *EventController) UpdateEvent(c echo.Context) error {
 	}
 
 	if err := req.Validate(); err != nil {
-		logger.Error("validation error: %v", err)
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
 		return c.JSON(http.StatusBadRequest, &types.ValidationError{
 			Error: err,
 		})
 	}
 
-	resp, err := ctrl.eventSvc.UpdateEvent(&req)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	resp, err := ctrl.eventSvc.UpdateEvent(c.Request().Context(), &req)
 	if errors.Is(err, errutil.ErrRecordNotFound) {
 		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
 	}
+	if errors.Is(err, errutil.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, msgutil.CategoryNotFound())
+	}
+	if status, msg, ok := roomBookingResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
+	c.Response().Header().Set(headerETag, etag(resp.Event.Version))
+	user, _ := middlewares.CurrentUserFromCtx(c)
+	resp.Event = localizeEvent(resp.Event, user)
 	return c.JSON(http.StatusOK, resp)
 }
 
+// PatchEvent applies a JSON Merge Patch (RFC 7396) to the event. Fields set to
+// null are cleared and the attendee list replaces the current invitees.
+func (ctrl *EventController) PatchEvent(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || (mediaType != mimeMergePatchJSON && mediaType != echo.MIMEApplicationJSON) {
+		return c.JSON(http.StatusUnsupportedMediaType, msgutil.UnsupportedMediaTypeMsg())
+	}
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+
+	resp, err := ctrl.eventSvc.PatchEvent(c.Request().Context(), &types.PatchEventRequest{
+		ID:      id,
+		Version: version,
+		Patch:   patch,
+	})
+	var validationErr v.Errors
+	if errors.As(err, &validationErr) {
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: validationErr,
+		})
+	}
+	if errors.Is(err, errutil.ErrInvalidMergePatch) {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if errors.Is(err, errutil.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, msgutil.CategoryNotFound())
+	}
+	if status, msg, ok := roomBookingResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
+	}
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	// invitees of an unpublished event are invited when it is published
+	if resp.Event.Status == consts.EventStatusPublished {
+		if err := ctrl.asynqSvc.InviteUsers(c.Request().Context(), resp.Event, resp.Invitees); err != nil {
+			logutil.FromContext(c.Request().Context()).Error("failed to enqueue invitations", "err", err, "event_id", resp.Event.ID)
+		}
+	}
+
+	c.Response().Header().Set(headerETag, etag(resp.Event.Version))
+	user, _ := middlewares.CurrentUserFromCtx(c)
+	resp.Event = localizeEvent(resp.Event, user)
+	return c.JSON(http.StatusOK, resp.UpdateEventResponse)
+}
+
+// roomBookingResponse returns the answer to an error booking the room of the
+// event, ok is false for other errors. A double booking lists the events that
+// hold the room.
+func roomBookingResponse(err error) (status int, msg msgutil.Data, ok bool) {
+	var conflict *types.RoomConflictError
+	switch {
+	case errors.As(err, &conflict):
+		return http.StatusConflict, msgutil.RoomDoubleBooked(conflict.Bookings), true
+	case errors.Is(err, errutil.ErrRoomNotFound):
+		return http.StatusBadRequest, msgutil.RoomNotFound(), true
+	case errors.Is(err, errutil.ErrRoomCapacityExceeded):
+		return http.StatusBadRequest, msgutil.RoomCapacityExceeded(), true
+	}
+	return 0, nil, false
+}
+
 func (ctrl *EventController) DeleteEvent(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -159,16 +270,24 @@ func (ctrl *EventController) DeleteEvent(c echo.Context) error {
 
 	// Validate ID
 	if err := v.Validate(id, v.Required); err != nil {
-		logger.Error("validation error: %v", err)
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
 		return c.JSON(http.StatusBadRequest, &types.ValidationError{
 			Error: err,
 		})
 	}
 
-	resp, err := ctrl.eventSvc.DeleteEvent(id)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+
+	resp, err := ctrl.eventSvc.DeleteEvent(c.Request().Context(), id, version)
 	if errors.Is(err, errutil.ErrRecordNotFound) {
 		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
 	}
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
@@ -191,13 +310,19 @@ func (ctrl *EventController) Rsvp(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 	}
 	req.UserID = user.ID
-	if err := ctrl.eventSvc.RsvpEvent(req); err != nil {
+	if err := ctrl.eventSvc.RsvpEvent(c.Request().Context(), req); err != nil {
 		if errors.Is(err, errutil.ErrRecordNotFound) {
 			return c.JSON(http.StatusNotFound, msgutil.EventNotAllowed())
 		}
 		if errors.Is(err, errutil.ErrEventCapacityExceeded) {
 			return c.JSON(http.StatusBadRequest, msgutil.EventCapacityExceeded())
 		}
+		if errors.Is(err, errutil.ErrEventCancelled) {
+			return c.JSON(http.StatusConflict, msgutil.EventCancelled())
+		}
+		if errors.Is(err, errutil.ErrEventNotPublished) {
+			return c.JSON(http.StatusConflict, msgutil.EventNotPublished())
+		}
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
 
@@ -209,15 +334,306 @@ func (ctrl *EventController) ListPublicEvents(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
 	}
+	if err := req.Validate(); err != nil {
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
 	if req.Limit <= 0 {
 		req.Limit = consts.DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = consts.DefaultPage
 	}
-	events, err := ctrl.eventSvc.ListEvents(req, nil)
+	if req.Cursor != nil {
+		return ctrl.listEventsByCursor(c, req, nil)
+	}
+	events, err := ctrl.eventSvc.ListEvents(c.Request().Context(), req, nil)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
+	events.Events = localizeEvents(events.Events, nil)
 	return c.JSON(http.StatusOK, events)
 }
+
+func (ctrl *EventController) listEventsByCursor(c echo.Context, req types.ListEventRequest, user *types.CurrentUser) error {
+	cursor, err := decodeCursor(*req.Cursor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidCursorMsg())
+	}
+	resp, err := ctrl.eventSvc.ListEventsByCursor(c.Request().Context(), req, cursor, user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	if err := setCursorLinks(c, &resp.CursorPage); err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	resp.Events = localizeEvents(resp.Events, user)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// localizeEvent returns the event with its times in the preferred time zone of
+// the user, in the time zone of the event when the user has none or there is
+// no user.
+func localizeEvent(event *models.Event, user *types.CurrentUser) *models.Event {
+	if event == nil {
+		return nil
+	}
+	return types.LocalizeEvent(event, viewerLocation(event, user))
+}
+
+// viewerLocation is the timezone of the user, or of the event when the user has
+// none or isn't known.
+func viewerLocation(event *models.Event, user *types.CurrentUser) *time.Location {
+	if user == nil {
+		return types.Location(event.Timezone)
+	}
+	return types.Location(user.Timezone, event.Timezone)
+}
+
+func localizeEvents(events []*models.Event, user *types.CurrentUser) []*models.Event {
+	for i, event := range events {
+		events[i] = localizeEvent(event, user)
+	}
+	return events
+}
+
+func (ctrl *EventController) ListEventAttendees(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	attendees, err := ctrl.eventSvc.ListEventAttendees(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, attendees)
+}
+
+func (ctrl *EventController) InvitationProgress(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	resp, err := ctrl.asynqSvc.ReadInvitationProgress(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.InvitationsNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// PublishEvent publishes a draft, or submits it for approval when the user
+// can't publish without one. Publishing sends the invitations and schedules the
+// reminder.
+func (ctrl *EventController) PublishEvent(c echo.Context) error {
+	var req types.EventStatusRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	resp, err := ctrl.eventSvc.PublishEvent(c.Request().Context(), &req, user)
+	if status, msg, ok := eventStatusResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	if resp.Event.Status == consts.EventStatusPublished {
+		ctrl.enqueuePublishTasks(c, resp.Event)
+	}
+	return ctrl.eventStatusChanged(c, resp, user)
+}
+
+func (ctrl *EventController) ApproveEvent(c echo.Context) error {
+	return ctrl.reviewEvent(c, true)
+}
+
+func (ctrl *EventController) RejectEvent(c echo.Context) error {
+	return ctrl.reviewEvent(c, false)
+}
+
+func (ctrl *EventController) reviewEvent(c echo.Context, approve bool) error {
+	var req types.EventReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	req.Approve = approve
+
+	if err := req.Validate(); err != nil {
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+	req.ReviewerID = user.ID
+
+	resp, err := ctrl.eventSvc.ReviewEvent(c.Request().Context(), &req)
+	if status, msg, ok := eventStatusResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	if resp.Event.Status == consts.EventStatusPublished {
+		ctrl.enqueuePublishTasks(c, resp.Event)
+	}
+	return ctrl.eventStatusChanged(c, resp, user)
+}
+
+func (ctrl *EventController) CompleteEvent(c echo.Context) error {
+	var req types.EventStatusRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	resp, err := ctrl.eventSvc.CompleteEvent(c.Request().Context(), &req)
+	if status, msg, ok := eventStatusResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return ctrl.eventStatusChanged(c, resp, user)
+}
+
+func (ctrl *EventController) CancelEvent(c echo.Context) error {
+	var req types.CancelEventRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		logutil.FromContext(c.Request().Context()).Warn("validation error", "err", err)
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	resp, err := ctrl.eventSvc.CancelEvent(c.Request().Context(), &req)
+	if status, msg, ok := eventStatusResponse(err); ok {
+		return c.JSON(status, msg)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	// nobody was told of a draft, so nobody is told of its cancellation
+	if resp.WasPublished {
+		if err := ctrl.asynqSvc.CreateEventCancellationTask(c.Request().Context(), resp.Event); err != nil {
+			logutil.FromContext(c.Request().Context()).Error("failed to enqueue event cancellation task", "err", err, "event_id", resp.Event.ID)
+		}
+	}
+	return ctrl.eventStatusChanged(c, &resp.UpdateEventResponse, user)
+}
+
+func (ctrl *EventController) ListEventReviews(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	reviews, err := ctrl.eventSvc.ListEventReviews(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, reviews)
+}
+
+// enqueuePublishTasks sends the invitations of a private event and schedules
+// the reminder of the event once it is published.
+func (ctrl *EventController) enqueuePublishTasks(c echo.Context, event *models.Event) {
+	if !event.IsPublic {
+		if err := ctrl.asynqSvc.CreateInvitationBatchTask(c.Request().Context(), event); err != nil {
+			logutil.FromContext(c.Request().Context()).Error("failed to enqueue invitation batch task", "err", err, "event_id", event.ID)
+		}
+	}
+
+	if event.StartTime == nil {
+		return
+	}
+	// keep the trace but not the cancellation of the request, which ends first
+	ctx := context.WithoutCancel(c.Request().Context())
+	go func() {
+		if err := ctrl.asynqSvc.CreateEventReminderTask(ctx, event); err != nil {
+			logutil.FromContext(ctx).Error("failed to enqueue event reminder email notification", "err", err, "event_id", event.ID)
+		}
+	}()
+}
+
+func (ctrl *EventController) eventStatusChanged(c echo.Context, resp *types.UpdateEventResponse, user *types.CurrentUser) error {
+	c.Response().Header().Set(headerETag, etag(resp.Event.Version))
+	resp.Event = localizeEvent(resp.Event, user)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// eventStatusResponse returns the answer to an error changing the status of
+// the event, ok is false for other errors.
+func eventStatusResponse(err error) (status int, msg msgutil.Data, ok bool) {
+	var transitionErr *types.EventTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return http.StatusConflict, msgutil.EventTransitionNotAllowed(transitionErr.From), true
+	case errors.Is(err, errutil.ErrRecordNotFound):
+		return http.StatusNotFound, msgutil.EventNotFound(), true
+	case errors.Is(err, errutil.ErrVersionConflict):
+		return http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg(), true
+	}
+	return 0, nil, false
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/health"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{
+		checker: checker,
+	}
+}
+
+// Healthz reports that the process is up, it doesn't touch any dependency.
+func (ctrl *HealthController) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+// Readyz reports whether every dependency the process needs is reachable.
+func (ctrl *HealthController) Readyz(c echo.Context) error {
+	report := ctrl.checker.Run(c.Request().Context())
+	if report.Status != health.StatusUp {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
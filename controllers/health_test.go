@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/health"
+)
+
+// Test cases for HealthController.Readyz
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		check      health.Check
+		wantStatus int
+	}{
+		{name: "Ready", check: func(context.Context) error { return nil }, wantStatus: http.StatusOK},
+		{name: "NotReady", check: func(context.Context) error { return errors.New("connection refused") }, wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(time.Second).Add("redis", tt.check)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+
+			if err := NewHealthController(checker).Readyz(echo.New().NewContext(req, rec)); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
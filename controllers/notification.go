@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/middlewares"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type NotificationController struct {
+	notificationSvc domain.NotificationService
+	broker          domain.NotificationBroker
+}
+
+func NewNotificationController(notificationSvc domain.NotificationService, broker domain.NotificationBroker) *NotificationController {
+	return &NotificationController{
+		notificationSvc: notificationSvc,
+		broker:          broker,
+	}
+}
+
+func (ctrl *NotificationController) ListNotifications(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.ListNotificationReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if req.Limit <= 0 {
+		req.Limit = consts.DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = consts.DefaultPage
+	}
+
+	resp, err := ctrl.notificationSvc.ListNotifications(c.Request().Context(), req, user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *NotificationController) MarkNotificationRead(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.NotificationReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.notificationSvc.MarkNotificationsRead(c.Request().Context(), user.ID, []int{req.ID}); err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.NotificationsMarkedRead())
+}
+
+func (ctrl *NotificationController) MarkAllNotificationsRead(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	if err := ctrl.notificationSvc.MarkNotificationsRead(c.Request().Context(), user.ID, nil); err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.NotificationsMarkedRead())
+}
+
+// StreamNotifications pushes the user's new notifications as server-sent events
+// until the client disconnects.
+func (ctrl *NotificationController) StreamNotifications(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	notifications, unsubscribe := ctrl.broker.Subscribe(user.ID)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(consts.NotificationStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case notification, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(notification)
+			if err != nil {
+				logutil.FromContext(c.Request().Context()).Error("failed to encode notification", "err", err, "notification_id", notification.ID)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "id: %d\nevent: notification\ndata: %s\n\n", notification.ID, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
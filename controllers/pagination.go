@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
+)
+
+const queryCursor = "cursor"
+
+// decodeCursor reads the position out of the cursor token, nil for the empty
+// token of the first page.
+func decodeCursor(token string) (*types.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var cursor types.Cursor
+	if err := methodutil.DecodeCursor(config.Pagination().CursorSecret, token, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// setCursorLinks links the page to its neighbours with the URL of the request,
+// only the cursor changes.
+func setCursorLinks(c echo.Context, page *types.CursorPage) error {
+	var err error
+	if page.NextCursor != nil {
+		if page.Next, err = cursorLink(c, page.NextCursor); err != nil {
+			return err
+		}
+	}
+	if page.PrevCursor != nil {
+		if page.Prev, err = cursorLink(c, page.PrevCursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cursorLink(c echo.Context, cursor *types.Cursor) (string, error) {
+	token, err := methodutil.EncodeCursor(config.Pagination().CursorSecret, cursor)
+	if err != nil {
+		return "", err
+	}
+	link := *c.Request().URL
+	query := link.Query()
+	query.Set(queryCursor, token)
+	link.RawQuery = query.Encode()
+	return link.RequestURI(), nil
+}
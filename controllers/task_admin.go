@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type TaskAdminController struct {
+	taskAdminSvc domain.TaskAdminService
+}
+
+func NewTaskAdminController(taskAdminSvc domain.TaskAdminService) *TaskAdminController {
+	return &TaskAdminController{
+		taskAdminSvc: taskAdminSvc,
+	}
+}
+
+func (ctrl *TaskAdminController) ListQueues(c echo.Context) error {
+	resp, err := ctrl.taskAdminSvc.ListQueues(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *TaskAdminController) ListTasks(c echo.Context) error {
+	var req types.ListTasksReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+	if req.Limit <= 0 {
+		req.Limit = consts.DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = consts.DefaultPage
+	}
+
+	resp, err := ctrl.taskAdminSvc.ListTasks(c.Request().Context(), &req)
+	if err != nil {
+		return taskAdminError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *TaskAdminController) ApplyTaskAction(c echo.Context) error {
+	var req types.TaskActionReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	resp, err := ctrl.taskAdminSvc.ApplyTaskAction(c.Request().Context(), &req)
+	if err != nil {
+		return taskAdminError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *TaskAdminController) PauseQueue(c echo.Context) error {
+	return ctrl.setQueuePaused(c, true)
+}
+
+func (ctrl *TaskAdminController) UnpauseQueue(c echo.Context) error {
+	return ctrl.setQueuePaused(c, false)
+}
+
+func (ctrl *TaskAdminController) setQueuePaused(c echo.Context, pause bool) error {
+	var req types.QueueReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.taskAdminSvc.PauseQueue(c.Request().Context(), req.Queue, pause); err != nil {
+		return taskAdminError(c, err)
+	}
+	if pause {
+		return c.JSON(http.StatusOK, msgutil.QueuePaused())
+	}
+	return c.JSON(http.StatusOK, msgutil.QueueUnpaused())
+}
+
+func taskAdminError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, asynq.ErrQueueNotFound):
+		return c.JSON(http.StatusNotFound, msgutil.QueueNotFound())
+	case errors.Is(err, errutil.ErrUnsupportedTaskState), errors.Is(err, errutil.ErrUnsupportedTaskAction):
+		return c.JSON(http.StatusBadRequest, msgutil.UnsupportedTaskAction())
+	}
+	return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type TaxonomyController struct {
+	taxonomySvc domain.TaxonomyService
+}
+
+func NewTaxonomyController(taxonomySvc domain.TaxonomyService) *TaxonomyController {
+	return &TaxonomyController{
+		taxonomySvc: taxonomySvc,
+	}
+}
+
+func (ctrl *TaxonomyController) ListCategories(c echo.Context) error {
+	categories, err := ctrl.taxonomySvc.ListCategories(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, categories)
+}
+
+func (ctrl *TaxonomyController) CreateCategory(c echo.Context) error {
+	var req types.CategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	category, err := ctrl.taxonomySvc.CreateCategory(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrCategoryAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.CategoryAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusCreated, category)
+}
+
+func (ctrl *TaxonomyController) UpdateCategory(c echo.Context) error {
+	var req types.CategoryRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	category, err := ctrl.taxonomySvc.UpdateCategory(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.CategoryNotFound())
+	}
+	if errors.Is(err, errutil.ErrCategoryAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.CategoryAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, category)
+}
+
+func (ctrl *TaxonomyController) DeleteCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.taxonomySvc.DeleteCategory(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.CategoryNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.CategoryDeleted())
+}
+
+// ListTags suggests tags for autocomplete, with the number of public events
+// that have each of them.
+func (ctrl *TaxonomyController) ListTags(c echo.Context) error {
+	var req types.ListTagReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	tags, err := ctrl.taxonomySvc.ListTags(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (ctrl *TaxonomyController) DeleteTag(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.taxonomySvc.DeleteTag(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.TagNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.TagDeleted())
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type TrashController struct {
+	trashSvc domain.TrashService
+}
+
+func NewTrashController(trashSvc domain.TrashService) *TrashController {
+	return &TrashController{
+		trashSvc: trashSvc,
+	}
+}
+
+func (ctrl *TrashController) ListEvents(c echo.Context) error {
+	req, err := bindListTrashReq(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	resp, err := ctrl.trashSvc.ListEvents(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *TrashController) RestoreEvent(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.trashSvc.RestoreEvent(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.EventNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.EventRestored())
+}
+
+func (ctrl *TrashController) ListUsers(c echo.Context) error {
+	req, err := bindListTrashReq(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	resp, err := ctrl.trashSvc.ListUsers(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (ctrl *TrashController) RestoreUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.trashSvc.RestoreUser(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.UserRestored())
+}
+
+func bindListTrashReq(c echo.Context) (types.ListTrashReq, error) {
+	var req types.ListTrashReq
+	if err := c.Bind(&req); err != nil {
+		return req, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = consts.DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = consts.DefaultPage
+	}
+	return req, nil
+}
@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"errors"
-	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/domain"
@@ -34,7 +33,7 @@ func (ctrl *UserController) Signup(c echo.Context) error {
 		})
 	}
 
-	if err := ctrl.userSvc.CreateUser(&req); err != nil {
+	if err := ctrl.userSvc.CreateUser(c.Request().Context(), &req); err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserAlreadyExist):
 			return c.JSON(http.StatusConflict, msgutil.UserAlreadyExists())
@@ -51,7 +50,7 @@ func (ctrl *UserController) Profile(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 	}
 
-	resp, err := ctrl.userSvc.ReadUser(user.ID, false)
+	resp, err := ctrl.userSvc.ReadUser(c.Request().Context(), user.ID, false)
 	if err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserNotFound):
@@ -64,7 +63,6 @@ func (ctrl *UserController) Profile(c echo.Context) error {
 }
 
 func (ctrl *UserController) CreateUser(c echo.Context) error {
-	fmt.Println("here")
 	var req types.CreateUserReq
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
@@ -76,7 +74,7 @@ func (ctrl *UserController) CreateUser(c echo.Context) error {
 		})
 	}
 
-	if err := ctrl.userSvc.CreateUser(&req); err != nil {
+	if err := ctrl.userSvc.CreateUser(c.Request().Context(), &req); err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserAlreadyExist):
 			return c.JSON(http.StatusConflict, msgutil.UserAlreadyExists())
@@ -97,10 +95,19 @@ func (ctrl *UserController) UpdateUser(c echo.Context) error {
 			Error: err,
 		})
 	}
-	if err := ctrl.userSvc.UpdateUser(&req); err != nil {
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+	req.Version = version
+
+	if err := ctrl.userSvc.UpdateUser(c.Request().Context(), &req); err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserNotFound):
 			return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+		case errors.Is(err, errutil.ErrVersionConflict):
+			return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
 		}
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
@@ -120,10 +127,17 @@ func (ctrl *UserController) DeleteUser(c echo.Context) error {
 		})
 	}
 
-	if err := ctrl.userSvc.DeleteUser(req.ID); err != nil {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
+	}
+
+	if err := ctrl.userSvc.DeleteUser(c.Request().Context(), req.ID, version); err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserNotFound):
 			return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+		case errors.Is(err, errutil.ErrVersionConflict):
+			return c.JSON(http.StatusPreconditionFailed, msgutil.PreconditionFailedMsg())
 		}
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
@@ -143,7 +157,7 @@ func (ctrl *UserController) ReadUser(c echo.Context) error {
 		})
 	}
 
-	user, err := ctrl.userSvc.ReadUser(req.ID, false)
+	user, err := ctrl.userSvc.ReadUser(c.Request().Context(), req.ID, false)
 	if err != nil {
 		switch {
 		case errors.Is(err, errutil.ErrUserNotFound):
@@ -152,6 +166,11 @@ func (ctrl *UserController) ReadUser(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
 
+	tag := etag(user.Version)
+	c.Response().Header().Set(headerETag, tag)
+	if notModified(c, tag) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(http.StatusOK, user)
 }
 
@@ -168,7 +187,10 @@ func (ctrl *UserController) ListUsers(c echo.Context) error {
 	if req.Page <= 0 {
 		req.Page = consts.DefaultPage
 	}
-	resp, err := ctrl.userSvc.ListUsers(req)
+	if req.Cursor != nil {
+		return ctrl.listUsersByCursor(c, req)
+	}
+	resp, err := ctrl.userSvc.ListUsers(c.Request().Context(), req)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
@@ -176,15 +198,151 @@ func (ctrl *UserController) ListUsers(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (ctrl *UserController) listUsersByCursor(c echo.Context, req types.ListUserReq) error {
+	cursor, err := decodeCursor(*req.Cursor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidCursorMsg())
+	}
+	resp, err := ctrl.userSvc.ListUsersByCursor(c.Request().Context(), req, cursor)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	if err := setCursorLinks(c, &resp.CursorPage); err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (ctrl *UserController) ListAttendees(c echo.Context) error {
 	user, err := middlewares.CurrentUserFromCtx(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 	}
-	users, err := ctrl.userSvc.ListAttendees(*user)
+	users, err := ctrl.userSvc.ListAttendees(c.Request().Context(), *user)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 	}
 
 	return c.JSON(http.StatusOK, users)
 }
+
+func (ctrl *UserController) UpdatePhone(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.UpdatePhoneReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.userSvc.RequestPhoneVerification(c.Request().Context(), user.ID, req.Phone); err != nil {
+		switch {
+		case errors.Is(err, errutil.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+		case errors.Is(err, errutil.ErrPhoneAlreadyVerified):
+			return c.JSON(http.StatusConflict, msgutil.PhoneAlreadyVerified())
+		case errors.Is(err, errutil.ErrPhoneAlreadyInUse):
+			return c.JSON(http.StatusConflict, msgutil.PhoneAlreadyInUse())
+		}
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	return c.JSON(http.StatusOK, msgutil.PhoneVerificationCodeSent())
+}
+
+func (ctrl *UserController) VerifyPhone(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.VerifyPhoneReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.userSvc.VerifyPhone(c.Request().Context(), user.ID, req.Code); err != nil {
+		switch {
+		case errors.Is(err, errutil.ErrInvalidOtp):
+			return c.JSON(http.StatusBadRequest, msgutil.InvalidOtp())
+		case errors.Is(err, errutil.ErrPhoneAlreadyInUse):
+			return c.JSON(http.StatusConflict, msgutil.PhoneAlreadyInUse())
+		}
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	return c.JSON(http.StatusOK, msgutil.PhoneVerifiedSuccessfully())
+}
+
+func (ctrl *UserController) UpdateNotificationChannels(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.UpdateNotificationChannelsReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.userSvc.UpdateNotificationChannels(c.Request().Context(), user.ID, &req); err != nil {
+		switch {
+		case errors.Is(err, errutil.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+		case errors.Is(err, errutil.ErrNoPhoneNumberIsSet):
+			return c.JSON(http.StatusBadRequest, msgutil.NoVerifiedPhoneNumber())
+		case errors.Is(err, errutil.ErrNoPushTokenIsSet):
+			return c.JSON(http.StatusBadRequest, msgutil.NoPushToken())
+		}
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	return c.JSON(http.StatusOK, msgutil.UserUpdatedSuccessfully())
+}
+
+func (ctrl *UserController) UpdateTimezone(c echo.Context) error {
+	user, err := middlewares.CurrentUserFromCtx(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.UpdateTimezoneReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.userSvc.UpdateTimezone(c.Request().Context(), user.ID, &req); err != nil {
+		if errors.Is(err, errutil.ErrUserNotFound) {
+			return c.JSON(http.StatusNotFound, msgutil.UserNotFound())
+		}
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	return c.JSON(http.StatusOK, msgutil.UserUpdatedSuccessfully())
+}
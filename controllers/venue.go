@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type VenueController struct {
+	venueSvc domain.VenueService
+}
+
+func NewVenueController(venueSvc domain.VenueService) *VenueController {
+	return &VenueController{
+		venueSvc: venueSvc,
+	}
+}
+
+// ListVenues lists the venues with their rooms.
+func (ctrl *VenueController) ListVenues(c echo.Context) error {
+	venues, err := ctrl.venueSvc.ListVenues(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, venues)
+}
+
+func (ctrl *VenueController) CreateVenue(c echo.Context) error {
+	var req types.VenueRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	venue, err := ctrl.venueSvc.CreateVenue(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrVenueAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.VenueAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusCreated, venue)
+}
+
+func (ctrl *VenueController) UpdateVenue(c echo.Context) error {
+	var req types.VenueRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	venue, err := ctrl.venueSvc.UpdateVenue(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.VenueNotFound())
+	}
+	if errors.Is(err, errutil.ErrVenueAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.VenueAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, venue)
+}
+
+func (ctrl *VenueController) DeleteVenue(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.venueSvc.DeleteVenue(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.VenueNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.VenueDeleted())
+}
+
+func (ctrl *VenueController) CreateRoom(c echo.Context) error {
+	var req types.RoomRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	room, err := ctrl.venueSvc.CreateRoom(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrRecordNotFound) {
+		return c.JSON(http.StatusBadRequest, msgutil.VenueNotFound())
+	}
+	if errors.Is(err, errutil.ErrRoomAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.RoomAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusCreated, room)
+}
+
+func (ctrl *VenueController) UpdateRoom(c echo.Context) error {
+	var req types.RoomRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	room, err := ctrl.venueSvc.UpdateRoom(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrRoomNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.RoomNotFound())
+	}
+	if errors.Is(err, errutil.ErrRoomAlreadyExist) {
+		return c.JSON(http.StatusConflict, msgutil.RoomAlreadyExists())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, room)
+}
+
+func (ctrl *VenueController) DeleteRoom(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	err = ctrl.venueSvc.DeleteRoom(c.Request().Context(), id)
+	if errors.Is(err, errutil.ErrRoomNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.RoomNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, msgutil.RoomDeleted())
+}
+
+// RoomAvailability lists the events that book the room between from and to.
+func (ctrl *VenueController) RoomAvailability(c echo.Context) error {
+	var req types.RoomAvailabilityReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	resp, err := ctrl.venueSvc.RoomAvailability(c.Request().Context(), &req)
+	if errors.Is(err, errutil.ErrRoomNotFound) {
+		return c.JSON(http.StatusNotFound, msgutil.RoomNotFound())
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+type WebhookController struct {
+	mailSvc domain.MailService
+	conf    *config.EmailConfig
+}
+
+func NewWebhookController(mailSvc domain.MailService, conf *config.EmailConfig) *WebhookController {
+	return &WebhookController{
+		mailSvc: mailSvc,
+		conf:    conf,
+	}
+}
+
+// EmailDeliveryEvent receives bounce and complaint notifications from the mail provider.
+func (ctrl *WebhookController) EmailDeliveryEvent(c echo.Context) error {
+	secret := ctrl.conf.WebhookSecret
+	given := c.Request().Header.Get(consts.HeaderWebhookSecret)
+	if secret == "" || subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+		return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+	}
+
+	var req types.EmailDeliveryEventReq
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, &types.ValidationError{
+			Error: err,
+		})
+	}
+
+	if err := ctrl.mailSvc.HandleDeliveryEvent(c.Request().Context(), &req); err != nil {
+		return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
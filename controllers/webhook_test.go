@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// Test cases for WebhookController.EmailDeliveryEvent
+func TestEmailDeliveryEvent(t *testing.T) {
+	body := `{"type": "bounce", "email": "user@example.com", "message_id": "msg-1"}`
+	tests := []struct {
+		name       string
+		secret     string // configured secret
+		given      string // X-Webhook-Secret sent
+		body       string
+		wantStatus int
+	}{
+		{name: "Successful", secret: "s3cret", given: "s3cret", body: body, wantStatus: http.StatusNoContent},
+		{name: "ErrorMissingSecret", secret: "s3cret", body: body, wantStatus: http.StatusUnauthorized},
+		{name: "ErrorWrongSecret", secret: "s3cret", given: "guess", body: body, wantStatus: http.StatusUnauthorized},
+		{name: "ErrorSecretNotConfigured", body: body, wantStatus: http.StatusUnauthorized},
+		{name: "ErrorInvalidEvent", secret: "s3cret", given: "s3cret", body: `{"type": "opened", "email": "user@example.com"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMailSvc := mocks.NewMockMailService(ctrl)
+			if tt.wantStatus == http.StatusNoContent {
+				mockMailSvc.EXPECT().HandleDeliveryEvent(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/email", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.given != "" {
+				req.Header.Set(consts.HeaderWebhookSecret, tt.given)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			if err := NewWebhookController(mockMailSvc, &config.EmailConfig{WebhookSecret: tt.secret}).EmailDeliveryEvent(c); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
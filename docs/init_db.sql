@@ -17,6 +17,47 @@ CREATE TABLE `attendee_status` (
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=4 DEFAULT CHARSET=utf8mb3;
 
+DROP TABLE IF EXISTS `categories`;
+CREATE TABLE `categories` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `categories_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `email_deliveries`;
+CREATE TABLE `email_deliveries` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `message_id` varchar(64) NOT NULL,
+  `provider_message_id` varchar(255) DEFAULT NULL,
+  `task_type` varchar(100) NOT NULL,
+  `event_id` int DEFAULT NULL,
+  `user_id` int DEFAULT NULL,
+  `email` varchar(50) NOT NULL,
+  `subject` varchar(255) NOT NULL DEFAULT '',
+  `status` varchar(20) NOT NULL DEFAULT 'queued',
+  `error` text,
+  `attempts` int NOT NULL DEFAULT '0',
+  `sent_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `email_deliveries_message_id` (`message_id`),
+  KEY `idx_email_deliveries_provider_message_id` (`provider_message_id`),
+  KEY `idx_email_deliveries_event_user` (`event_id`,`user_id`,`task_type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `email_suppressions`;
+CREATE TABLE `email_suppressions` (
+  `email` varchar(50) NOT NULL,
+  `reason` varchar(20) NOT NULL,
+  `details` varchar(500) DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`email`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
 DROP TABLE IF EXISTS `event_attendees`;
 CREATE TABLE `event_attendees` (
   `event_id` int NOT NULL,
@@ -25,6 +66,31 @@ CREATE TABLE `event_attendees` (
   UNIQUE KEY `event_user_unique` (`event_id`,`user_id`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
 
+DROP TABLE IF EXISTS `event_reviews`;
+CREATE TABLE `event_reviews` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `event_id` int NOT NULL,
+  `reviewer_id` int DEFAULT NULL,
+  `decision` varchar(20) NOT NULL,
+  `comment` varchar(500) DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_event_reviews_event_id` (`event_id`),
+  KEY `fk_event_reviews_reviewer_id` (`reviewer_id`),
+  CONSTRAINT `fk_event_reviews_event_id` FOREIGN KEY (`event_id`) REFERENCES `events` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_event_reviews_reviewer_id` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `event_tags`;
+CREATE TABLE `event_tags` (
+  `event_id` int NOT NULL,
+  `tag_id` int NOT NULL,
+  PRIMARY KEY (`event_id`,`tag_id`),
+  KEY `fk_event_tags_tag_id` (`tag_id`),
+  CONSTRAINT `fk_event_tags_event_id` FOREIGN KEY (`event_id`) REFERENCES `events` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_event_tags_tag_id` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
 DROP TABLE IF EXISTS `events`;
 CREATE TABLE `events` (
   `id` int NOT NULL AUTO_INCREMENT,
@@ -38,11 +104,59 @@ CREATE TABLE `events` (
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   `is_public` tinyint(1) NOT NULL DEFAULT '0',
   `attendee_limit` int DEFAULT NULL,
+  `category_id` int DEFAULT NULL,
+  `room_id` int DEFAULT NULL,
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
+  `status` varchar(20) NOT NULL DEFAULT 'draft',
+  `cancel_reason` varchar(500) DEFAULT NULL,
+  `cancelled_at` datetime DEFAULT NULL,
+  `version` int NOT NULL DEFAULT '1',
+  `deleted_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `fk_events_created_by` (`created_by`),
-  CONSTRAINT `fk_events_created_by` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+  KEY `idx_events_status` (`status`),
+  KEY `fk_events_category_id` (`category_id`),
+  KEY `idx_events_room_time` (`room_id`,`start_time`,`end_time`),
+  KEY `idx_events_deleted_at` (`deleted_at`),
+  FULLTEXT KEY `ft_events_search` (`title`,`description`,`location`),
+  CONSTRAINT `fk_events_created_by` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`),
+  CONSTRAINT `fk_events_category_id` FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_events_room_id` FOREIGN KEY (`room_id`) REFERENCES `rooms` (`id`) ON DELETE SET NULL
 ) ENGINE=InnoDB AUTO_INCREMENT=34 DEFAULT CHARSET=utf8mb3;
 
+DROP TABLE IF EXISTS `invitation_batches`;
+CREATE TABLE `invitation_batches` (
+  `event_id` int NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `total` int NOT NULL DEFAULT '0',
+  `enqueued` int NOT NULL DEFAULT '0',
+  `skipped` int NOT NULL DEFAULT '0',
+  `failed` int NOT NULL DEFAULT '0',
+  `last_user_id` int NOT NULL DEFAULT '0',
+  `error` text,
+  `started_at` datetime DEFAULT NULL,
+  `finished_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`),
+  CONSTRAINT `fk_invitation_batches_event_id` FOREIGN KEY (`event_id`) REFERENCES `events` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `notifications`;
+CREATE TABLE `notifications` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `event_id` int DEFAULT NULL,
+  `type` varchar(50) NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `body` text,
+  `read_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_notifications_user_read` (`user_id`,`read_at`),
+  CONSTRAINT `fk_notifications_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
 DROP TABLE IF EXISTS `permissions`;
 CREATE TABLE `permissions` (
   `id` int NOT NULL AUTO_INCREMENT,
@@ -73,6 +187,40 @@ CREATE TABLE `roles` (
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=4 DEFAULT CHARSET=utf8mb3;
 
+DROP TABLE IF EXISTS `rooms`;
+CREATE TABLE `rooms` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `venue_id` int NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `capacity` int NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `rooms_venue_name` (`venue_id`,`name`),
+  CONSTRAINT `fk_rooms_venue_id` FOREIGN KEY (`venue_id`) REFERENCES `venues` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `tags`;
+CREATE TABLE `tags` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `tags_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
+DROP TABLE IF EXISTS `venues`;
+CREATE TABLE `venues` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `address` varchar(255) DEFAULT NULL,
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `venues_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3;
+
 DROP TABLE IF EXISTS `users`;
 CREATE TABLE `users` (
   `id` int NOT NULL AUTO_INCREMENT,
@@ -81,9 +229,19 @@ CREATE TABLE `users` (
   `first_name` varchar(50) NOT NULL,
   `last_name` varchar(50) NOT NULL,
   `role_id` int NOT NULL,
+  `phone` varchar(20) DEFAULT NULL,
+  `phone_verified_at` datetime DEFAULT NULL,
+  `sms_enabled` tinyint(1) NOT NULL DEFAULT '0',
+  `push_enabled` tinyint(1) NOT NULL DEFAULT '0',
+  `push_token` varchar(255) DEFAULT NULL,
+  `timezone` varchar(64) DEFAULT NULL,
+  `version` int NOT NULL DEFAULT '1',
   `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
   `updated_at` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
+  KEY `idx_users_phone` (`phone`),
+  KEY `idx_users_deleted_at` (`deleted_at`),
   KEY `fk_users_role_id` (`role_id`),
   CONSTRAINT `fk_users_role_id` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=6 DEFAULT CHARSET=utf8mb3;
@@ -93,6 +251,11 @@ INSERT INTO `attendee_status` (`id`, `title`) VALUES
 (2, 'Accepted'),
 (3, 'Rejected');
 
+INSERT INTO `categories` (`id`, `name`) VALUES
+(1, 'Workshop'),
+(2, 'Social'),
+(3, 'Conference');
+
 INSERT INTO `event_attendees` (`event_id`, `user_id`, `status_id`) VALUES
 (2, 1, 0),
 (9, 2, 0),
@@ -116,38 +279,38 @@ INSERT INTO `event_attendees` (`event_id`, `user_id`, `status_id`) VALUES
 (33, 1, 2),
 (33, 4, 2);
 
-INSERT INTO `events` (`id`, `title`, `description`, `location`, `start_time`, `end_time`, `created_by`, `created_at`, `updated_at`, `is_public`, `attendee_limit`) VALUES
-(1, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:11:38', '2025-05-29 02:11:38', 1, NULL),
-(2, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:02', '2025-05-29 02:12:02', 1, 100),
-(3, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:36', '2025-05-29 02:12:36', 1, 100),
-(4, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:37', '2025-05-29 02:12:37', 1, 100),
-(5, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:38', '2025-05-29 02:12:38', 1, 100),
-(6, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:17:00', '2025-05-29 02:17:00', 0, 100),
-(7, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:17:44', '2025-05-29 02:17:44', 0, 100),
-(9, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 03:04:16', '2025-05-29 03:04:16', 0, 100),
-(10, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:47', '2025-05-29 06:41:47', 0, 100),
-(11, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:50', '2025-05-29 06:41:50', 0, 100),
-(12, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:50', '2025-05-29 06:41:50', 0, 100),
-(13, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:51', '2025-05-29 06:41:51', 0, 100),
-(14, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:52', '2025-05-29 06:41:52', 0, 100),
-(15, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:31', '2025-05-29 09:57:31', 0, 100),
-(16, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:41', '2025-05-29 09:57:41', 0, 100),
-(17, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:52', '2025-05-29 09:57:52', 0, 100),
-(18, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:59:45', '2025-05-29 09:59:45', 0, 100),
-(19, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:17:06', '2025-05-30 17:17:06', 0, NULL),
-(20, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:18:45', '2025-05-30 17:18:45', 0, NULL),
-(21, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:20:56', '2025-05-30 17:20:56', 0, NULL),
-(22, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:48:37', '2025-05-30 17:48:37', 0, NULL),
-(23, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-06-14 11:00:00', 1, '2025-05-30 17:48:51', '2025-05-31 04:56:28', 1, NULL),
-(24, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:50:59', '2025-05-30 17:50:59', 1, NULL),
-(25, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 18:12:37', '2025-05-30 18:12:37', 0, NULL),
-(26, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-31 04:11:42', '2025-05-31 04:11:42', 0, NULL),
-(27, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-31 04:17:29', '2025-05-31 04:17:29', 0, NULL),
-(28, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:26:50', '2025-05-31 09:26:50', 0, 0),
-(29, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:27:08', '2025-05-31 09:27:08', 0, 100),
-(30, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:27:19', '2025-05-31 09:27:19', 1, 100),
-(32, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:44:22', '2025-05-31 09:44:22', 0, 100),
-(33, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 11:00:31', '2025-05-31 11:08:37', 1, 1);
+INSERT INTO `events` (`id`, `title`, `description`, `location`, `start_time`, `end_time`, `created_by`, `created_at`, `updated_at`, `is_public`, `attendee_limit`, `status`) VALUES
+(1, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:11:38', '2025-05-29 02:11:38', 1, NULL, 'published'),
+(2, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:02', '2025-05-29 02:12:02', 1, 100, 'published'),
+(3, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:36', '2025-05-29 02:12:36', 1, 100, 'published'),
+(4, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:37', '2025-05-29 02:12:37', 1, 100, 'published'),
+(5, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:12:38', '2025-05-29 02:12:38', 1, 100, 'published'),
+(6, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:17:00', '2025-05-29 02:17:00', 0, 100, 'published'),
+(7, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 02:17:44', '2025-05-29 02:17:44', 0, 100, 'published'),
+(9, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 03:04:16', '2025-05-29 03:04:16', 0, 100, 'published'),
+(10, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:47', '2025-05-29 06:41:47', 0, 100, 'published'),
+(11, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:50', '2025-05-29 06:41:50', 0, 100, 'published'),
+(12, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:50', '2025-05-29 06:41:50', 0, 100, 'published'),
+(13, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:51', '2025-05-29 06:41:51', 0, 100, 'published'),
+(14, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-29 06:41:52', '2025-05-29 06:41:52', 0, 100, 'published'),
+(15, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:31', '2025-05-29 09:57:31', 0, 100, 'published'),
+(16, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:41', '2025-05-29 09:57:41', 0, 100, 'published'),
+(17, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:57:52', '2025-05-29 09:57:52', 0, 100, 'published'),
+(18, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 3, '2025-05-29 09:59:45', '2025-05-29 09:59:45', 0, 100, 'published'),
+(19, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:17:06', '2025-05-30 17:17:06', 0, NULL, 'published'),
+(20, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:18:45', '2025-05-30 17:18:45', 0, NULL, 'published'),
+(21, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:20:56', '2025-05-30 17:20:56', 0, NULL, 'published'),
+(22, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:48:37', '2025-05-30 17:48:37', 0, NULL, 'published'),
+(23, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-06-14 11:00:00', 1, '2025-05-30 17:48:51', '2025-05-31 04:56:28', 1, NULL, 'published'),
+(24, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 17:50:59', '2025-05-30 17:50:59', 1, NULL, 'published'),
+(25, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-30 18:12:37', '2025-05-30 18:12:37', 0, NULL, 'published'),
+(26, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-31 04:11:42', '2025-05-31 04:11:42', 0, NULL, 'published'),
+(27, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-05-14 10:00:00', '2025-05-14 11:00:00', 1, '2025-05-31 04:17:29', '2025-05-31 04:17:29', 0, NULL, 'published'),
+(28, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:26:50', '2025-05-31 09:26:50', 0, 0, 'published'),
+(29, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:27:08', '2025-05-31 09:27:08', 0, 100, 'published'),
+(30, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:27:19', '2025-05-31 09:27:19', 1, 100, 'published'),
+(32, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 09:44:22', '2025-05-31 09:44:22', 0, 100, 'published'),
+(33, 'Project Structure kickoff', 'Introduction to the course. Incebreaking seesion for trainer and students', 'Office', '2025-06-10 10:00:00', '2025-06-10 11:00:00', 1, '2025-05-31 11:00:31', '2025-05-31 11:08:37', 1, 1, 'published');
 
 INSERT INTO `permissions` (`id`, `permission`, `description`, `created_at`, `updated_at`) VALUES
 (1, 'user.create', 'Permission to create a new user', '2025-05-28 18:02:52', NULL),
@@ -164,7 +327,12 @@ INSERT INTO `permissions` (`id`, `permission`, `description`, `created_at`, `upd
 (12, 'user.listAttendee', 'permission to list attendee', '2025-05-29 11:18:19', NULL),
 (13, 'event.fetchAllEvent', 'admin permission for fetch event', '2025-05-29 12:33:35', NULL),
 (14, 'event.fetchOwnEvent', 'fetch event created by own', '2025-05-29 12:34:18', NULL),
-(15, 'event.fetchInvitedEvent', 'fetch invited event', '2025-05-29 12:35:19', NULL);
+(15, 'event.fetchInvitedEvent', 'fetch invited event', '2025-05-29 12:35:19', NULL),
+(16, 'task.manage', 'Permission to inspect and manage background task queues', '2025-06-20 10:00:00', NULL),
+(17, 'trash.manage', 'Permission to list and restore deleted events and users', '2025-07-01 10:00:00', NULL),
+(18, 'taxonomy.manage', 'Permission to manage event categories and tags', '2025-07-05 10:00:00', NULL),
+(19, 'venue.manage', 'Permission to manage venues and rooms', '2025-07-08 10:00:00', NULL),
+(20, 'event.approve', 'Permission to approve or reject events submitted for publishing', '2025-07-12 10:00:00', NULL);
 
 INSERT INTO `role_permissions` (`role_id`, `permission_id`, `created_at`, `updated_at`) VALUES
 (1, 1, '2025-05-28 18:02:52', NULL),
@@ -179,6 +347,11 @@ INSERT INTO `role_permissions` (`role_id`, `permission_id`, `created_at`, `updat
 (1, 10, '2025-05-28 18:02:52', NULL),
 (1, 11, '2025-05-29 11:12:56', NULL),
 (1, 13, '2025-05-29 12:40:48', NULL),
+(1, 16, '2025-06-20 10:00:00', NULL),
+(1, 17, '2025-07-01 10:00:00', NULL),
+(1, 18, '2025-07-05 10:00:00', NULL),
+(1, 19, '2025-07-08 10:00:00', NULL),
+(1, 20, '2025-07-12 10:00:00', NULL),
 (2, 3, '2025-05-28 18:02:52', NULL),
 (2, 4, '2025-05-28 18:02:52', NULL),
 (2, 6, '2025-05-28 18:02:52', NULL),
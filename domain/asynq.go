@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"github.com/hibiken/asynq"
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
@@ -8,14 +11,36 @@ import (
 
 type (
 	AsynqRepository interface {
-		CreateTask(event types.AsynqTaskType, payload interface{}) (*asynq.Task, error)
-		EnqueueTask(task *asynq.Task, customOpts *types.AsynqOption) (string, error)
-		DequeueTask(taskID string) error
+		CreateTask(ctx context.Context, event types.AsynqTaskType, payload interface{}) (*asynq.Task, error)
+		EnqueueTask(ctx context.Context, task *asynq.Task, customOpts *types.AsynqOption) (string, error)
+		DequeueTask(ctx context.Context, queue, taskID string) error
+	}
+
+	AsynqInspectorRepository interface {
+		ListQueues() ([]*asynq.QueueInfo, error)
+		ListTasks(queue, state string, page, size int) ([]*asynq.TaskInfo, error)
+		ApplyTaskAction(queue, action, taskID string) error
+		ApplyTaskActionToAll(queue, action, state string) (int, error)
+		PauseQueue(queue string) error
+		UnpauseQueue(queue string) error
+	}
+
+	TaskAdminService interface {
+		ListQueues(ctx context.Context) ([]types.QueueInfoResp, error)
+		ListTasks(ctx context.Context, req *types.ListTasksReq) ([]types.TaskInfoResp, error)
+		ApplyTaskAction(ctx context.Context, req *types.TaskActionReq) (*types.TaskActionResp, error)
+		PauseQueue(ctx context.Context, queue string, pause bool) error
 	}
 
 	AsynqService interface {
-		CreateEmailInvitationTasks(userIds []int, event *models.Event) error
-		CreateEventReminderTask(event *models.Event) error
-		CreateEventReminderEmailTasks(event *models.Event) error
+		CreateInvitationBatchTask(ctx context.Context, event *models.Event) error
+		InviteUsers(ctx context.Context, event *models.Event, users []models.User) error
+		CreatePurgeTrashTask(ctx context.Context, before time.Time) error
+		ProcessInvitationBatch(ctx context.Context, eventID int) error
+		ReadInvitationProgress(ctx context.Context, eventID int) (*types.InvitationProgressResp, error)
+		CreateEventReminderTask(ctx context.Context, event *models.Event) error
+		CreateEventReminderEmailTasks(ctx context.Context, event *models.Event) error
+		CreateEventCancellationTask(ctx context.Context, event *models.Event) error
+		CreateEventCancellationTasks(ctx context.Context, eventID int) error
 	}
 )
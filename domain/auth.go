@@ -1,13 +1,15 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/vivasoft-ltd/go-ems/types"
 )
 
 type (
 	AuthService interface {
-		Login(req *types.LoginReq) (*types.LoginResp, error)
-		VerifyAccessToken(accessToken string) (*types.UserInfo, *types.Token, error)
-		Logout(accessTokenUuid, refreshTokenUuid string) error
+		Login(ctx context.Context, req *types.LoginReq) (*types.LoginResp, error)
+		VerifyAccessToken(ctx context.Context, accessToken string) (*types.UserInfo, *types.Token, error)
+		Logout(ctx context.Context, accessTokenUuid, refreshTokenUuid string) error
 	}
 )
@@ -1,29 +1,55 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 )
 
 type (
 	EventRepository interface {
-		CreateEvent(event *models.Event) (*models.Event, error)
-		ListEvents(filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error)
-		ReadEventByID(id int) (*models.Event, error)
-		UpdateEvent(event *models.Event) (*models.Event, error)
-		DeleteEvent(id int) error
-		ReadEventInvitation(eventID int, userID int) (*models.EventAttendee, error)
-		UpsertEventInvitation(event *models.EventAttendee) error
-		GetEventAttendeesCount(eventID int) (int, error)
-		GetAcceptedEventAttendees(eventID int) ([]models.EventAttendee, error)
+		CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error)
+		ListEvents(ctx context.Context, filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error)
+		ListEventsAfter(ctx context.Context, filter *types.EventFilter, cursor *types.Cursor, limit int) ([]*models.Event, error)
+		CountEvents(ctx context.Context, filter *types.EventFilter) (int, error)
+		ReadEventByID(ctx context.Context, id int) (*models.Event, error)
+		ReadEventStatus(ctx context.Context, id int) (string, error)
+		UpdateEvent(ctx context.Context, event *models.Event) (*models.Event, error)
+		ReplaceEvent(ctx context.Context, event *models.Event, addedAttendees, removedAttendees []int) (*models.Event, error)
+		UpdateEventStatus(ctx context.Context, event *models.Event, status string, review *models.EventReview) (*models.Event, error)
+		ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error)
+		CancelEvent(ctx context.Context, event *models.Event, reason string) (*models.Event, error)
+		DeleteEvent(ctx context.Context, id, version int) error
+		ReadEventInvitation(ctx context.Context, eventID int, userID int) (*models.EventAttendee, error)
+		UpsertEventInvitation(ctx context.Context, event *models.EventAttendee) error
+		GetEventAttendeesCount(ctx context.Context, eventID int) (int, error)
+		GetAcceptedEventAttendees(ctx context.Context, eventID int) ([]models.EventAttendee, error)
+		ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error)
+		ListEventInvitees(ctx context.Context, eventID, afterUserID, limit int) ([]models.User, error)
+		ListEventUsersByStatus(ctx context.Context, eventID int, statusIDs []int, afterUserID, limit int) ([]models.User, error)
+	}
+
+	InvitationBatchRepository interface {
+		CreateInvitationBatch(ctx context.Context, batch *models.InvitationBatch) error
+		ReadInvitationBatch(ctx context.Context, eventID int) (*models.InvitationBatch, error)
+		UpdateInvitationBatch(ctx context.Context, eventID int, updates map[string]interface{}) error
 	}
 
 	EventService interface {
-		CreateEvent(eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error)
-		ListEvents(req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error)
-		ReadEventByID(id int) (*models.Event, error)
-		DeleteEvent(id int) (*types.DeleteEventResponse, error)
-		UpdateEvent(eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error)
-		RsvpEvent(request types.RsvpEventRequest) error
+		CreateEvent(ctx context.Context, eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error)
+		ListEvents(ctx context.Context, req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error)
+		ListEventsByCursor(ctx context.Context, req types.ListEventRequest, cursor *types.Cursor, user *types.CurrentUser) (*types.CursorEventResponse, error)
+		ReadEventByID(ctx context.Context, id int) (*models.Event, error)
+		DeleteEvent(ctx context.Context, id, version int) (*types.DeleteEventResponse, error)
+		UpdateEvent(ctx context.Context, eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error)
+		PatchEvent(ctx context.Context, req *types.PatchEventRequest) (*types.PatchEventResponse, error)
+		RsvpEvent(ctx context.Context, request types.RsvpEventRequest) error
+		ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error)
+		PublishEvent(ctx context.Context, req *types.EventStatusRequest, user *types.CurrentUser) (*types.UpdateEventResponse, error)
+		ReviewEvent(ctx context.Context, req *types.EventReviewRequest) (*types.UpdateEventResponse, error)
+		CompleteEvent(ctx context.Context, req *types.EventStatusRequest) (*types.UpdateEventResponse, error)
+		CancelEvent(ctx context.Context, req *types.CancelEventRequest) (*types.CancelEventResponse, error)
+		ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error)
 	}
 )
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	IdempotencyStore interface {
+		// Reserve stores an in progress record for key unless there already is one.
+		// It returns the token owning the reservation, empty when the key is taken.
+		Reserve(ctx context.Context, key, bodyHash string, timeout time.Duration) (string, error)
+		// Read returns the record of key, nil when there is none.
+		Read(ctx context.Context, key string) (*types.IdempotencyRecord, error)
+		// Complete replaces the reservation owned by token with the response.
+		Complete(ctx context.Context, key, token string, record *types.IdempotencyRecord, ttl time.Duration) error
+		// Release drops the reservation owned by token so that the request can be retried.
+		Release(ctx context.Context, key, token string) error
+	}
+)
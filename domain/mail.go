@@ -1,18 +1,32 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 )
 
 type (
 	MailService interface {
-		SendEmail(reqData types.EmailPayload) error
-		SendInvitationEmail(userIds []int, event *models.Event) error
-		EnqueueEventReminderEmailNotification(event *models.Event) error
+		SendEmail(ctx context.Context, reqData types.EmailPayload) error
+		SendInvitationEmail(ctx context.Context, userIds []int, event *models.Event) error
+		EnqueueEventReminderEmailNotification(ctx context.Context, event *models.Event) error
+		HandleDeliveryEvent(ctx context.Context, req *types.EmailDeliveryEventReq) error
 	}
 
 	MailRepository interface {
-		SendEmail(reqData *types.EmailPayload) error
+		// SendEmail returns the message ID assigned by the provider, if any.
+		SendEmail(ctx context.Context, reqData *types.EmailPayload) (string, error)
+	}
+
+	EmailDeliveryRepository interface {
+		CreateEmailDelivery(ctx context.Context, delivery *models.EmailDelivery) error
+		UpdateEmailDelivery(ctx context.Context, messageID string, updates map[string]interface{}) error
+		ReadEmailDelivery(ctx context.Context, messageID, providerMessageID string) (*models.EmailDelivery, error)
+		IsEmailSuppressed(ctx context.Context, email string) (bool, error)
+		SuppressEmail(ctx context.Context, suppression *models.EmailSuppression) error
+		DeleteEmailDelivery(ctx context.Context, messageID string) error
+		CountEmailDeliveriesByStatus(ctx context.Context, eventID int, taskType string) (map[string]int, error)
 	}
 )
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	NotificationRepository interface {
+		CreateNotifications(ctx context.Context, notifications []*models.Notification) error
+		ListNotifications(ctx context.Context, userID int, filter *types.NotificationFilter, limit, offset int) ([]*models.Notification, int, error)
+		CountUnreadNotifications(ctx context.Context, userID int) (int, error)
+		MarkNotificationsRead(ctx context.Context, userID int, ids []int) error
+	}
+
+	NotificationService interface {
+		Notify(ctx context.Context, notifications []*models.Notification) error
+		ListNotifications(ctx context.Context, req types.ListNotificationReq, userID int) (*types.PaginatedNotificationResp, error)
+		MarkNotificationsRead(ctx context.Context, userID int, ids []int) error
+	}
+
+	// NotificationBroker fans notifications out to every serve replica so that
+	// connected SSE clients receive them regardless of which process created them.
+	NotificationBroker interface {
+		Publish(ctx context.Context, notification *models.Notification) error
+		Subscribe(userID int) (<-chan *models.Notification, func())
+	}
+)
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	// NotifierChannel delivers short messages to a user outside of email.
+	NotifierChannel interface {
+		Name() string
+		// Enabled reports whether the user opted in and can be reached on the channel.
+		Enabled(user *models.User) bool
+		Send(user *models.User, msg *types.ChannelMessage) error
+	}
+
+	NotifierService interface {
+		EnabledChannels(user *models.User) []string
+		Notify(ctx context.Context, channel string, userID int, msg *types.ChannelMessage) error
+		SendSms(ctx context.Context, phone string, text string) error
+	}
+)
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	RateLimiter interface {
+		// Take takes a token and returns how long to wait when none is available.
+		Take(ctx context.Context) (time.Duration, error)
+	}
+
+	SlidingWindowLimiter interface {
+		// Allow records a hit of key unless it already had limit hits in the last window.
+		Allow(ctx context.Context, key string, limit int, window time.Duration) (*types.RateLimitResult, error)
+	}
+)
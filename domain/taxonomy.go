@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	TaxonomyRepository interface {
+		ListCategories(ctx context.Context) ([]models.Category, error)
+		ReadCategory(ctx context.Context, id int) (*models.Category, error)
+		CategoryCountByName(ctx context.Context, name string, excludeID int) (int, error)
+		CreateCategory(ctx context.Context, category *models.Category) error
+		UpdateCategory(ctx context.Context, category *models.Category) error
+		DeleteCategory(ctx context.Context, id int) error
+		ListTags(ctx context.Context, prefix string, limit int) ([]types.TagResp, error)
+		DeleteTag(ctx context.Context, id int) error
+	}
+
+	TaxonomyService interface {
+		ListCategories(ctx context.Context) ([]models.Category, error)
+		CreateCategory(ctx context.Context, req *types.CategoryRequest) (*models.Category, error)
+		UpdateCategory(ctx context.Context, req *types.CategoryRequest) (*models.Category, error)
+		DeleteCategory(ctx context.Context, id int) error
+		ListTags(ctx context.Context, req types.ListTagReq) ([]types.TagResp, error)
+		DeleteTag(ctx context.Context, id int) error
+	}
+)
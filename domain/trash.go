@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	TrashRepository interface {
+		ListDeletedEvents(ctx context.Context, limit, offset int) ([]types.TrashedEvent, int, error)
+		RestoreEvent(ctx context.Context, id int) error
+		PurgeDeletedEvents(ctx context.Context, before time.Time) (int, error)
+		ListDeletedUsers(ctx context.Context, limit, offset int) ([]types.TrashedUser, int, error)
+		RestoreUser(ctx context.Context, id int) error
+		PurgeDeletedUsers(ctx context.Context, before time.Time) (int, error)
+	}
+
+	TrashService interface {
+		ListEvents(ctx context.Context, req types.ListTrashReq) (*types.PaginatedTrashedEventResp, error)
+		RestoreEvent(ctx context.Context, id int) error
+		ListUsers(ctx context.Context, req types.ListTrashReq) (*types.PaginatedTrashedUserResp, error)
+		RestoreUser(ctx context.Context, id int) error
+		Purge(ctx context.Context, before time.Time) error
+	}
+)
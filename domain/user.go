@@ -1,32 +1,46 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 )
 
 type (
 	UserService interface {
-		CreateUser(req *types.CreateUserReq) error
-		UpdateUser(req *types.UpdateUserReq) error
-		ReadUser(id int, fromCache bool) (*types.UserInfo, error)
-		DeleteUser(id int) error
-		ReadUserByEmail(email string) (*models.User, error)
-		StoreInCache(user *types.UserInfo) error
-		ListUsers(req types.ListUserReq) (*types.PaginatedUserResp, error)
-		ReadPermissionsByRole(roleID int) ([]*models.Permission, error)
-		ListAttendees(user types.CurrentUser) ([]types.AttendeeResp, error)
+		CreateUser(ctx context.Context, req *types.CreateUserReq) error
+		UpdateUser(ctx context.Context, req *types.UpdateUserReq) error
+		ReadUser(ctx context.Context, id int, fromCache bool) (*types.UserInfo, error)
+		DeleteUser(ctx context.Context, id, version int) error
+		ReadUserByEmail(ctx context.Context, email string) (*models.User, error)
+		StoreInCache(ctx context.Context, user *types.UserInfo) error
+		ListUsers(ctx context.Context, req types.ListUserReq) (*types.PaginatedUserResp, error)
+		ListUsersByCursor(ctx context.Context, req types.ListUserReq, cursor *types.Cursor) (*types.CursorUserResponse, error)
+		ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error)
+		ListAttendees(ctx context.Context, user types.CurrentUser) ([]types.AttendeeResp, error)
+		RequestPhoneVerification(ctx context.Context, userID int, phone string) error
+		VerifyPhone(ctx context.Context, userID int, code string) error
+		UpdateNotificationChannels(ctx context.Context, userID int, req *types.UpdateNotificationChannelsReq) error
+		UpdateTimezone(ctx context.Context, userID int, req *types.UpdateTimezoneReq) error
 	}
 	UserRepository interface {
-		CreateUser(user *models.User) (*models.User, error)
-		ReadUserById(id int) (*models.User, error)
-		ReadUsers(id []int) ([]models.User, error)
-		ReadPaginatedUsers(limit, offset int) ([]*types.UserInfo, int, error)
-		UpdateUser(user *models.User) error
-		DeleteUser(id int) error
-		ReadUserByEmail(email string) (*models.User, error)
-		UserCountByEmail(email string) (int, error)
-		ReadPermissionsByRole(roleID int) ([]*models.Permission, error)
-		ListAttendees(filter *types.AttendeeFilter) ([]types.AttendeeResp, error)
+		CreateUser(ctx context.Context, user *models.User) (*models.User, error)
+		ReadUserById(ctx context.Context, id int) (*models.User, error)
+		ReadUsers(ctx context.Context, id []int) ([]models.User, error)
+		ReadPaginatedUsers(ctx context.Context, limit, offset int) ([]*types.UserInfo, int, error)
+		ReadUsersAfter(ctx context.Context, cursor *types.Cursor, limit int) ([]*types.UserInfo, error)
+		CountUsers(ctx context.Context) (int, error)
+		UpdateUser(ctx context.Context, user *models.User) error
+		DeleteUser(ctx context.Context, id, version int) error
+		ReadUserByEmail(ctx context.Context, email string) (*models.User, error)
+		UserCountByEmail(ctx context.Context, email string) (int, error)
+		UserCountByPhone(ctx context.Context, phone string) (int, error)
+		UpdateUserPhone(ctx context.Context, id int, phone string, verifiedAt time.Time) error
+		UpdateNotificationChannels(ctx context.Context, id int, smsEnabled, pushEnabled bool, pushToken *string) error
+		UpdateUserTimezone(ctx context.Context, id int, timezone *string) error
+		ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error)
+		ListAttendees(ctx context.Context, filter *types.AttendeeFilter) ([]types.AttendeeResp, error)
 	}
 )
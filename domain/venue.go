@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+type (
+	VenueRepository interface {
+		ListVenues(ctx context.Context) ([]models.Venue, error)
+		ReadVenue(ctx context.Context, id int) (*models.Venue, error)
+		VenueCountByName(ctx context.Context, name string, excludeID int) (int, error)
+		CreateVenue(ctx context.Context, venue *models.Venue) error
+		UpdateVenue(ctx context.Context, venue *models.Venue) error
+		DeleteVenue(ctx context.Context, id int) error
+		ReadRoom(ctx context.Context, id int) (*models.Room, error)
+		RoomCountByName(ctx context.Context, venueID int, name string, excludeID int) (int, error)
+		CreateRoom(ctx context.Context, room *models.Room) error
+		UpdateRoom(ctx context.Context, room *models.Room) error
+		DeleteRoom(ctx context.Context, id int) error
+		ListRoomBookings(ctx context.Context, roomID int, from, to time.Time) ([]types.RoomBooking, error)
+	}
+
+	VenueService interface {
+		ListVenues(ctx context.Context) ([]models.Venue, error)
+		CreateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error)
+		UpdateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error)
+		DeleteVenue(ctx context.Context, id int) error
+		CreateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error)
+		UpdateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error)
+		DeleteRoom(ctx context.Context, id int) error
+		RoomAvailability(ctx context.Context, req *types.RoomAvailabilityReq) (*types.RoomAvailabilityResp, error)
+	}
+)
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/hibiken/asynq"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newDependencies returns the MySQL, Redis and asynq probes against in-memory
+// servers, and the Redis server so that it can be taken down.
+func newDependencies(t *testing.T) (*Checker, *miniredis.Miniredis) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sql mock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	// gorm pings on open, the check pings again
+	mock.ExpectPing()
+	mock.ExpectPing()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	asynqClient := asynq.NewClient(asynq.RedisClientOpt{Addr: srv.Addr()})
+	t.Cleanup(func() { asynqClient.Close() })
+
+	checker := NewChecker(time.Second).
+		Add("mysql", MySQL(db)).
+		Add("redis", Redis(client)).
+		Add("asynq", Asynq(asynqClient))
+	return checker, srv
+}
+
+func TestCheckerHealthy(t *testing.T) {
+	checker, _ := newDependencies(t)
+
+	report := checker.Run(context.Background())
+
+	if report.Status != StatusUp {
+		t.Errorf("expected status %s, got %+v", StatusUp, report)
+	}
+	for _, name := range []string{"mysql", "redis", "asynq"} {
+		if result, ok := report.Checks[name]; !ok || result.Status != StatusUp {
+			t.Errorf("expected %s to be up, got %+v", name, result)
+		}
+	}
+}
+
+func TestCheckerDegraded(t *testing.T) {
+	checker, srv := newDependencies(t)
+	srv.Close()
+
+	report := checker.Run(context.Background())
+
+	if report.Status != StatusDown {
+		t.Errorf("expected status %s, got %+v", StatusDown, report)
+	}
+	if report.Checks["mysql"].Status != StatusUp {
+		t.Errorf("expected mysql to stay up, got %+v", report.Checks["mysql"])
+	}
+	for _, name := range []string{"redis", "asynq"} {
+		if result := report.Checks[name]; result.Status != StatusDown || result.Error == "" {
+			t.Errorf("expected %s to be down with an error, got %+v", name, result)
+		}
+	}
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	// a check ignoring its context doesn't hold the probe past the timeout
+	block := make(chan struct{})
+	defer close(block)
+	checker := NewChecker(20*time.Millisecond).
+		Add("stuck", func(context.Context) error {
+			<-block
+			return nil
+		}).
+		Add("fine", func(context.Context) error { return nil })
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("expected the run to end at the timeout, took %s", took)
+	}
+	if report.Status != StatusDown || report.Checks["fine"].Status != StatusUp {
+		t.Errorf("expected only the stuck check to be down, got %+v", report)
+	}
+	if result := report.Checks["stuck"]; !strings.Contains(result.Error, context.DeadlineExceeded.Error()) {
+		t.Errorf("expected a deadline error, got %+v", result)
+	}
+}
@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-redis/redis"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+func MySQL(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDb, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDb.PingContext(ctx)
+	}
+}
+
+func Redis(client *redis.Client) Check {
+	return func(ctx context.Context) error {
+		return client.WithContext(ctx).Ping().Err()
+	}
+}
+
+// Asynq pings the asynq broker, the client has no context support so the
+// checker's timeout bounds it.
+func Asynq(client *asynq.Client) Check {
+	return func(ctx context.Context) error {
+		return client.Ping()
+	}
+}
+
+// HTTP reports the endpoint as down when it can't be reached or answers with a
+// server error. Any other answer means the endpoint is there.
+func HTTP(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("endpoint returned status code %d", res.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Manager runs the long-lived components of a process and shuts them down in
+// order on SIGINT/SIGTERM or when one of them fails.
+type Manager struct {
+	drainTimeout time.Duration
+
+	mu    sync.Mutex
+	hooks []hook
+
+	once   sync.Once
+	done   chan struct{}
+	errMu  sync.Mutex
+	runErr error
+}
+
+func New(drainTimeout time.Duration) *Manager {
+	return &Manager{
+		drainTimeout: drainTimeout,
+		done:         make(chan struct{}),
+	}
+}
+
+// Go runs the component in the background. A component returning, with or
+// without an error, triggers the shutdown of the whole process.
+func (m *Manager) Go(name string, run func() error) {
+	go func() {
+		err := run()
+		if err != nil {
+			slog.Error("component failed", "component", name, "err", err)
+			m.errMu.Lock()
+			if m.runErr == nil {
+				m.runErr = fmt.Errorf("%s: %w", name, err)
+			}
+			m.errMu.Unlock()
+		} else {
+			slog.Info("component stopped", "component", name)
+		}
+		m.once.Do(func() { close(m.done) })
+	}()
+}
+
+// OnStop registers a shutdown step. Steps run in registration order and share
+// the drain timeout.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, stop: stop})
+}
+
+// Wait blocks until a termination signal arrives or a component stops, then
+// runs the shutdown steps. It returns the error of the failed component joined
+// with those of the steps that failed, if any.
+func (m *Manager) Wait() error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	select {
+	case sig := <-quit:
+		slog.Info("shutting down...", "signal", sig.String())
+	case <-m.done:
+		slog.Info("a component stopped, shutting down...")
+	}
+
+	stopErr := m.shutdown()
+
+	m.errMu.Lock()
+	defer m.errMu.Unlock()
+	return errors.Join(m.runErr, stopErr)
+}
+
+// shutdown runs every step, also after one failed, and returns their errors.
+func (m *Manager) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.drainTimeout)
+	defer cancel()
+
+	m.mu.Lock()
+	hooks := append([]hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, h := range hooks {
+		start := time.Now()
+		if err := h.stop(ctx); err != nil {
+			slog.Error("failed to stop component", "component", h.name, "err", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.name, err))
+			continue
+		}
+		slog.Info("component stopped", "component", h.name, "took", time.Since(start).String())
+	}
+	slog.Info("shutdown complete")
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManagerStopsInOrder(t *testing.T) {
+	m := New(time.Second)
+	var order []string
+	for _, name := range []string{"http", "workers", "db"} {
+		m.OnStop(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	m.Go("server", func() error { return nil })
+	if err := m.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if want := []string{"http", "workers", "db"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("steps ran in %v, want %v", order, want)
+	}
+}
+
+func TestManagerDrainTimeout(t *testing.T) {
+	m := New(20 * time.Millisecond)
+	var nextErr error
+	m.OnStop("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	// the timeout is shared, the steps after a stuck one get an expired context
+	m.OnStop("next", func(ctx context.Context) error {
+		nextErr = ctx.Err()
+		return nil
+	})
+
+	m.Go("server", func() error { return nil })
+	start := time.Now()
+	err := m.Wait()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !errors.Is(nextErr, context.DeadlineExceeded) {
+		t.Errorf("next step got context error %v, want %v", nextErr, context.DeadlineExceeded)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("shutdown took %s, want about the drain timeout", took)
+	}
+}
+
+func TestManagerJoinsErrors(t *testing.T) {
+	m := New(time.Second)
+	errRun := errors.New("listen failed")
+	errQueue := errors.New("queue stuck")
+	errDB := errors.New("db close failed")
+	ran := false
+	m.OnStop("queue", func(context.Context) error { return errQueue })
+	m.OnStop("db", func(context.Context) error { return errDB })
+	m.OnStop("tracing", func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	m.Go("server", func() error { return errRun })
+	err := m.Wait()
+
+	for _, want := range []error{errRun, errQueue, errDB} {
+		if !errors.Is(err, want) {
+			t.Errorf("Wait() error = %v, want it to include %v", err, want)
+		}
+	}
+	if !ran {
+		t.Error("a failed step kept the later ones from running")
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
-import "github.com/vivasoft-ltd/go-ems/cmd"
+import (
+	// venue time zones are looked up in images without a zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/vivasoft-ltd/go-ems/cmd"
+)
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vivasoft-ltd/go-ems/consts"
+)
+
+// namespace matches the subsystem of the HTTP metrics, so every metric of the
+// app shares the vivasoft_ prefix.
+const namespace = "vivasoft"
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	eventsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_created_total",
+		Help:      "Number of events created.",
+	})
+
+	rsvps = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rsvps_total",
+		Help:      "Number of RSVPs recorded, by status.",
+	}, []string{"status"})
+
+	rsvpCapacityRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rsvp_capacity_rejections_total",
+		Help:      "Number of RSVPs rejected because the event was full.",
+	})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of login attempts, by result.",
+	}, []string{"result"})
+
+	emails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_total",
+		Help:      "Number of emails handed to the mail provider, by task type and result.",
+	}, []string{"task_type", "result"})
+
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Time taken to process a task, by task type and result.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"task_type", "result"})
+
+	taskRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "task_retries_total",
+		Help:      "Number of task attempts that were retries, by task type.",
+	}, []string{"task_type"})
+)
+
+var rsvpStatuses = map[int]string{
+	consts.StatusInvited:  "invited",
+	consts.StatusAccepted: "accepted",
+	consts.StatusRejected: "rejected",
+}
+
+func EventCreated() {
+	eventsCreated.Inc()
+}
+
+func Rsvp(statusID int) {
+	status, ok := rsvpStatuses[statusID]
+	if !ok {
+		status = "unknown"
+	}
+	rsvps.WithLabelValues(status).Inc()
+}
+
+func RsvpCapacityRejected() {
+	rsvpCapacityRejections.Inc()
+}
+
+func Login(err error) {
+	logins.WithLabelValues(result(err)).Inc()
+}
+
+func EmailSent(taskType string, err error) {
+	emails.WithLabelValues(taskType, result(err)).Inc()
+}
+
+func TaskProcessed(taskType string, took time.Duration, err error) {
+	taskDuration.WithLabelValues(taskType, result(err)).Observe(took.Seconds())
+}
+
+func TaskRetried(taskType string) {
+	taskRetries.WithLabelValues(taskType).Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultFailure
+	}
+	return resultSuccess
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskMiddleware records the latency and the retries of every task the worker
+// processes.
+func TaskMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		if retried, ok := asynq.GetRetryCount(ctx); ok && retried > 0 {
+			TaskRetried(t.Type())
+		}
+
+		start := time.Now()
+		err := next.ProcessTask(ctx, t)
+		TaskProcessed(t.Type(), time.Since(start), err)
+		return err
+	})
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func taskSamples(t *testing.T, taskType, result string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := taskDuration.WithLabelValues(taskType, result).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestTaskMiddlewareRecordsLatencyByResult(t *testing.T) {
+	taskType := t.Name()
+	handler := TaskMiddleware(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		if string(t.Payload()) == "fail" {
+			return errors.New("boom")
+		}
+		return nil
+	}))
+
+	for _, payload := range []string{"ok", "ok", "fail"} {
+		_ = handler.ProcessTask(context.Background(), asynq.NewTask(taskType, []byte(payload)))
+	}
+
+	if got := taskSamples(t, taskType, resultSuccess); got != 2 {
+		t.Errorf("expected 2 successful tasks, got %d", got)
+	}
+	if got := taskSamples(t, taskType, resultFailure); got != 1 {
+		t.Errorf("expected 1 failed task, got %d", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
 	"net/http"
 	"strings"
@@ -34,7 +35,7 @@ func (m *AuthMiddleware) Authenticate(permission string) echo.MiddlewareFunc {
 				return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 			}
 
-			userInfo, token, err := m.authSvc.VerifyAccessToken(tokenString)
+			userInfo, token, err := m.authSvc.VerifyAccessToken(c.Request().Context(), tokenString)
 			if err != nil {
 				return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
 			}
@@ -47,8 +48,11 @@ func (m *AuthMiddleware) Authenticate(permission string) echo.MiddlewareFunc {
 				AccessUuid:  token.AccessUuid,
 				RefreshUuid: token.RefreshUuid,
 			}
+			if userInfo.Timezone != nil {
+				currentUser.Timezone = *userInfo.Timezone
+			}
 
-			permissions, err := m.userSvc.ReadPermissionsByRole(userInfo.RoleID)
+			permissions, err := m.userSvc.ReadPermissionsByRole(c.Request().Context(), userInfo.RoleID)
 			if err != nil {
 				return c.JSON(http.StatusInternalServerError, msgutil.SomethingWentWrongMsg())
 			}
@@ -66,6 +70,7 @@ func (m *AuthMiddleware) Authenticate(permission string) echo.MiddlewareFunc {
 
 			// Set user ID and permissions in header
 			c.Request().Header.Set("X-User-ID", fmt.Sprintf("%d", userInfo.ID))
+			c.SetRequest(c.Request().WithContext(logutil.With(c.Request().Context(), "user_id", userInfo.ID)))
 
 			return next(c)
 		}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthenticatePermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		permissions []*models.Permission
+		wantStatus  int
+	}{
+		{name: "Allowed", token: "valid", permissions: []*models.Permission{{Permission: consts.PermissionTaskManage}}, wantStatus: http.StatusOK},
+		{name: "Forbidden", token: "valid", permissions: []*models.Permission{{Permission: consts.PermissionEventFetch}}, wantStatus: http.StatusForbidden},
+		{name: "Unauthorized", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authSvc := mocks.NewMockAuthService(ctrl)
+			userSvc := mocks.NewMockUserService(ctrl)
+			if tt.token != "" {
+				authSvc.EXPECT().
+					VerifyAccessToken(gomock.Any(), gomock.Eq(tt.token)).
+					Return(&types.UserInfo{ID: 1, RoleID: 2}, &types.Token{}, nil)
+				userSvc.EXPECT().ReadPermissionsByRole(gomock.Any(), gomock.Eq(2)).Return(tt.permissions, nil)
+			}
+
+			h := NewAuthMiddleware(authSvc, userSvc).Authenticate(consts.PermissionTaskManage)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/queues/critical/pause", nil)
+			if tt.token != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			if err := h(echo.New().NewContext(req, rec)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
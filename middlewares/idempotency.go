@@ -0,0 +1,175 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencyPollInterval = 100 * time.Millisecond
+)
+
+// replayedHeaders are the response headers kept with the response, Content-Type
+// is kept on its own.
+var replayedHeaders = []string{echo.HeaderLocation, "ETag"}
+
+type IdempotencyMiddleware struct {
+	store domain.IdempotencyStore
+	conf  *config.IdempotencyConfig
+}
+
+func NewIdempotencyMiddleware(store domain.IdempotencyStore, conf *config.IdempotencyConfig) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store: store,
+		conf:  conf,
+	}
+}
+
+// Handle replays the stored response of a request retried with the same
+// Idempotency-Key, and makes concurrent requests with the key wait for the first
+// one. It must come after Authenticate as keys are scoped to the user.
+func (m *IdempotencyMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		idempotencyKey := c.Request().Header.Get(HeaderIdempotencyKey)
+		if idempotencyKey == "" {
+			return next(c)
+		}
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			return c.JSON(http.StatusBadRequest, msgutil.InvalidIdempotencyKeyMsg())
+		}
+
+		user, err := CurrentUserFromCtx(c)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, msgutil.UserUnauthorized())
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, msgutil.InvalidRequestMsg())
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		key := methodutil.IdempotencyKey(user.ID, idempotencyKey)
+		bodyHash := requestHash(c.Request(), body)
+		log := logutil.FromContext(c.Request().Context())
+
+		deadline := time.Now().Add(m.conf.LockTimeout * time.Second)
+		for {
+			token, err := m.store.Reserve(c.Request().Context(), key, bodyHash, m.conf.LockTimeout*time.Second)
+			if err != nil {
+				// without Redis the request still goes through, only unprotected
+				log.Error("failed to reserve idempotency key", "err", err)
+				return next(c)
+			}
+			if token != "" {
+				return m.record(c, next, key, token, bodyHash)
+			}
+
+			record, err := m.store.Read(c.Request().Context(), key)
+			if err != nil {
+				log.Error("failed to read idempotency key", "err", err)
+				return next(c)
+			}
+			switch {
+			case record == nil:
+				// released or expired in between, try to take it over
+				continue
+			case record.BodyHash != bodyHash:
+				return c.JSON(http.StatusConflict, msgutil.IdempotencyKeyReusedMsg())
+			case record.Completed():
+				for name, value := range record.Header {
+					c.Response().Header().Set(name, value)
+				}
+				c.Response().Header().Set(HeaderIdempotencyReplayed, "true")
+				return c.Blob(record.Status, record.ContentType, record.Body)
+			}
+
+			if time.Now().After(deadline) {
+				return c.JSON(http.StatusConflict, msgutil.IdempotentRequestInProgressMsg())
+			}
+			select {
+			case <-c.Request().Context().Done():
+				return c.Request().Context().Err()
+			case <-time.After(idempotencyPollInterval):
+			}
+		}
+	}
+}
+
+// record runs the request and stores its response. Server errors are not
+// stored so that the client can retry them.
+func (m *IdempotencyMiddleware) record(c echo.Context, next echo.HandlerFunc, key, token, bodyHash string) error {
+	res := c.Response()
+	recorder := &bodyRecorder{ResponseWriter: res.Writer}
+	res.Writer = recorder
+	err := next(c)
+	res.Writer = recorder.ResponseWriter
+
+	log := logutil.FromContext(c.Request().Context())
+	if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+		if err := m.store.Release(c.Request().Context(), key, token); err != nil {
+			log.Error("failed to release idempotency key", "err", err)
+		}
+		return err
+	}
+
+	record := &types.IdempotencyRecord{
+		BodyHash:    bodyHash,
+		Status:      res.Status,
+		ContentType: res.Header().Get(echo.HeaderContentType),
+		Body:        recorder.body.Bytes(),
+	}
+	for _, name := range replayedHeaders {
+		if value := res.Header().Get(name); value != "" {
+			if record.Header == nil {
+				record.Header = map[string]string{}
+			}
+			record.Header[name] = value
+		}
+	}
+	err = m.store.Complete(c.Request().Context(), key, token, record, m.conf.Window*time.Second)
+	switch {
+	case errors.Is(err, errutil.ErrIdempotencyKeyLost):
+		// ran past the lock timeout and a retry took the key over
+		log.Warn("idempotency key was taken over, response not stored", "err", err)
+	case err != nil:
+		log.Error("failed to store idempotent response", "err", err)
+	}
+	return nil
+}
+
+// requestHash tells apart different requests sent with the same key.
+func requestHash(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,146 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	tokens  int
+	records map[string]types.IdempotencyRecord
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key, bodyHash string, timeout time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; ok {
+		return "", nil
+	}
+	s.tokens++
+	token := strconv.Itoa(s.tokens)
+	s.records[key] = types.IdempotencyRecord{BodyHash: bodyHash, Token: token}
+	return token, nil
+}
+
+func (s *memoryIdempotencyStore) Read(_ context.Context, key string) (*types.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key, token string, record *types.IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records[key].Token != token {
+		return errutil.ErrIdempotencyKeyLost
+	}
+	s.records[key] = *record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records[key].Token == token {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func newIdempotentHandler(calls *int32) echo.HandlerFunc {
+	store := &memoryIdempotencyStore{records: map[string]types.IdempotencyRecord{}}
+	mw := NewIdempotencyMiddleware(store, &config.IdempotencyConfig{Window: 60, LockTimeout: 5})
+	return mw.Handle(func(c echo.Context) error {
+		n := atomic.AddInt32(calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		c.Response().Header().Set(echo.HeaderLocation, "/v1/events/"+strconv.Itoa(int(n)))
+		c.Response().Header().Set("ETag", `"`+strconv.Itoa(int(n))+`"`)
+		return c.JSON(http.StatusCreated, map[string]int32{"id": n})
+	})
+}
+
+func serveIdempotent(e *echo.Echo, h echo.HandlerFunc, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(body))
+	req.Header.Set(HeaderIdempotencyKey, key)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(ContextKeyCurrentUser, types.CurrentUser{ID: 1})
+	if err := h(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	return rec
+}
+
+func TestIdempotencyReplaysFirstResponse(t *testing.T) {
+	config.LoadConfig()
+	e := echo.New()
+	var calls int32
+	h := newIdempotentHandler(&calls)
+
+	first := serveIdempotent(e, h, "key-1", `{"title":"a"}`)
+	retry := serveIdempotent(e, h, "key-1", `{"title":"a"}`)
+
+	if calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected the first response to be replayed, got %d %s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get(HeaderIdempotencyReplayed) != "true" {
+		t.Fatalf("expected the replay to be marked")
+	}
+	for _, name := range []string{echo.HeaderLocation, "ETag"} {
+		if got, want := retry.Header().Get(name), first.Header().Get(name); got == "" || got != want {
+			t.Fatalf("expected %s %q to be replayed, got %q", name, want, got)
+		}
+	}
+
+	reused := serveIdempotent(e, h, "key-1", `{"title":"b"}`)
+	if reused.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a reused key, got %d", reused.Code)
+	}
+}
+
+func TestIdempotencySerializesConcurrentRequests(t *testing.T) {
+	config.LoadConfig()
+	e := echo.New()
+	var calls int32
+	h := newIdempotentHandler(&calls)
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 5)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bodies[i] = serveIdempotent(e, h, "key-2", `{"title":"a"}`).Body.String()
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", calls)
+	}
+	for _, body := range bodies {
+		if body != bodies[0] {
+			t.Fatalf("expected every request to get the same response, got %q and %q", bodies[0], body)
+		}
+	}
+}
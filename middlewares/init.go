@@ -1,9 +1,15 @@
 package middlewares
 
 import (
+	"log/slog"
+	"net/http"
+
 	"github.com/labstack/echo-contrib/echoprometheus"
 	"github.com/labstack/echo/v4"
 	m "github.com/labstack/echo/v4/middleware"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 const (
@@ -12,20 +18,62 @@ const (
 
 func Init(e *echo.Echo) {
 	var (
-		metricsPath string = "/metrics"
+		metricsPath            string = "/metrics"
+		notificationStreamPath string = "/v1/notifications/stream"
+		healthzPath            string = "/healthz"
+		readyzPath             string = "/readyz"
 	)
 
 	e.Pre(m.RemoveTrailingSlash())
-	e.Use(m.LoggerWithConfig(m.LoggerConfig{
-		Format:           `${time_custom} ${remote_ip} ${host} ${method} ${uri} ${status} ${latency_human} ${bytes_in} ${bytes_out} "${user_agent}"` + "\n",
-		CustomTimeFormat: "2006-01-02T15:04:05.00",
+	e.Use(otelecho.Middleware(config.App().Name, otelecho.WithSkipper(func(context echo.Context) bool {
+		path := context.Request().URL.Path
+		return path == metricsPath || path == healthzPath || path == readyzPath
+	})))
+	e.Use(RequestID())
+	e.Use(m.RequestLoggerWithConfig(m.RequestLoggerConfig{
+		Skipper: func(context echo.Context) bool {
+			// probes hit these every few seconds
+			path := context.Request().URL.Path
+			return path == healthzPath || path == readyzPath
+		},
+		LogStatus:    true,
+		LogMethod:    true,
+		LogURI:       true,
+		LogHost:      true,
+		LogRemoteIP:  true,
+		LogLatency:   true,
+		LogUserAgent: true,
+		LogError:     true,
+		HandleError:  true, // so the logged status is the one sent
+		LogValuesFunc: func(context echo.Context, v m.RequestLoggerValues) error {
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("uri", v.URI),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+				slog.String("remote_ip", v.RemoteIP),
+				slog.String("host", v.Host),
+				slog.String("user_agent", v.UserAgent),
+			}
+			level := slog.LevelInfo
+			if v.Error != nil {
+				attrs = append(attrs, slog.String("err", v.Error.Error()))
+			}
+			if v.Status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			// the request context by now also carries what the handlers added, e.g. the user
+			logutil.FromContext(context.Request().Context()).LogAttrs(context.Request().Context(), level, "request", attrs...)
+			return nil
+		},
 	}))
 	e.Use(m.CORS())
 	e.Use(m.Secure())
 	e.Use(m.Recover())
 	e.Use(m.GzipWithConfig(m.GzipConfig{
 		Skipper: func(context echo.Context) bool {
-			return context.Request().URL.Path == metricsPath
+			path := context.Request().URL.Path
+			return path == metricsPath || path == notificationStreamPath
 		},
 		Level: 5,
 	}))
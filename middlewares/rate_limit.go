@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"github.com/vivasoft-ltd/go-ems/utils/msgutil"
+)
+
+const HeaderXApiKey = "X-API-Key"
+
+type RateLimitMiddleware struct {
+	limiter domain.SlidingWindowLimiter
+	conf    *config.RateLimitConfig
+}
+
+func NewRateLimitMiddleware(limiter domain.SlidingWindowLimiter, conf *config.RateLimitConfig) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		limiter: limiter,
+		conf:    conf,
+	}
+}
+
+// Limit limits each client, by API key or IP, to the rule of the route group.
+// Put it before Authenticate so that requests with invalid tokens are limited
+// too.
+func (m *RateLimitMiddleware) Limit(group string) echo.MiddlewareFunc {
+	return m.limit(group, m.clientKey)
+}
+
+// LimitUser limits each authenticated user to the rule of the route group, so
+// users behind one NAT or proxy don't share a limit. Put it after Authenticate.
+func (m *RateLimitMiddleware) LimitUser(group string) echo.MiddlewareFunc {
+	return m.limit(group, m.userKey)
+}
+
+func (m *RateLimitMiddleware) limit(group string, clientKey func(echo.Context) string) echo.MiddlewareFunc {
+	rule := m.conf.Rule(group)
+	if !m.conf.Enabled || rule.Limit <= 0 || rule.Window <= 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	window := rule.Window * time.Second
+	policy := fmt.Sprintf("%d;w=%d", rule.Limit, int(rule.Window))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			result, err := m.limiter.Allow(c.Request().Context(), group+":"+clientKey(c), rule.Limit, window)
+			if err != nil {
+				// don't turn a Redis outage into an API outage
+				logutil.FromContext(c.Request().Context()).Error("failed to check rate limit", "err", err, "group", group)
+				return next(c)
+			}
+
+			reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+			header := c.Response().Header()
+			header.Set("RateLimit-Policy", policy)
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", reset)
+
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, reset)
+				return c.JSON(http.StatusTooManyRequests, msgutil.TooManyRequestsMsg())
+			}
+			return next(c)
+		}
+	}
+}
+
+// clientKey identifies the caller by a known API key, then by IP. The caller
+// isn't authenticated yet, so a user ID can't be trusted.
+func (m *RateLimitMiddleware) clientKey(c echo.Context) string {
+	if key := c.Request().Header.Get(HeaderXApiKey); key != "" && m.isApiKey(key) {
+		// keep the key itself out of Redis
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:8])
+	}
+	if m.conf.TrustProxyHeaders {
+		return "ip:" + c.RealIP()
+	}
+	return "ip:" + echo.ExtractIPDirect()(c.Request())
+}
+
+// userKey identifies the caller by the authenticated user, falling back to the
+// client key when there is none.
+func (m *RateLimitMiddleware) userKey(c echo.Context) string {
+	user, err := CurrentUserFromCtx(c)
+	if err != nil {
+		return m.clientKey(c)
+	}
+	return "user:" + strconv.Itoa(user.ID)
+}
+
+func (m *RateLimitMiddleware) isApiKey(key string) bool {
+	for _, apiKey := range m.conf.ApiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
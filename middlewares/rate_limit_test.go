@@ -0,0 +1,178 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+// countingLimiter allows limit hits per key and never frees a slot.
+type countingLimiter struct {
+	mu   sync.Mutex
+	hits map[string]int
+	err  error
+}
+
+func (l *countingLimiter) Allow(_ context.Context, key string, limit int, window time.Duration) (*types.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, l.err
+	}
+	allowed := l.hits[key] < limit
+	if allowed {
+		l.hits[key]++
+	}
+	return &types.RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: limit - l.hits[key],
+		Reset:     1500 * time.Millisecond,
+	}, nil
+}
+
+func newRateLimitedHandler(limiter *countingLimiter, next echo.HandlerFunc) echo.HandlerFunc {
+	mw := NewRateLimitMiddleware(limiter, &config.RateLimitConfig{
+		Enabled: true,
+		Groups:  map[string]config.RateLimitRule{"auth": {Limit: 2, Window: 60}},
+		ApiKeys: []string{"partner-key"},
+	})
+	return mw.Limit("auth")(next)
+}
+
+func serveRateLimited(e *echo.Echo, h echo.HandlerFunc, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/logout", nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set(HeaderXApiKey, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	if err := h(e.NewContext(req, rec)); err != nil {
+		e.HTTPErrorHandler(err, e.NewContext(req, rec))
+	}
+	return rec
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	e := echo.New()
+	limiter := &countingLimiter{hits: map[string]int{}}
+	h := newRateLimitedHandler(limiter, func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	first := serveRateLimited(e, h, "10.0.0.1:1234", "")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", first.Code)
+	}
+	want := map[string]string{
+		"RateLimit-Policy":    "2;w=60",
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "2",
+	}
+	for name, value := range want {
+		if got := first.Header().Get(name); got != value {
+			t.Errorf("expected %s %q, got %q", name, value, got)
+		}
+	}
+
+	serveRateLimited(e, h, "10.0.0.1:1234", "")
+	denied := serveRateLimited(e, h, "10.0.0.1:1234", "")
+	if denied.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", denied.Code)
+	}
+	if denied.Header().Get(echo.HeaderRetryAfter) != "2" || denied.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("expected Retry-After 2 and nothing remaining, got %v", denied.Header())
+	}
+}
+
+func TestRateLimitCountsRejectedRequests(t *testing.T) {
+	e := echo.New()
+	limiter := &countingLimiter{hits: map[string]int{}}
+	// stands in for Authenticate rejecting a guessed token
+	h := newRateLimitedHandler(limiter, func(c echo.Context) error { return c.NoContent(http.StatusUnauthorized) })
+
+	for i := 0; i < 2; i++ {
+		if rec := serveRateLimited(e, h, "10.0.0.1:1234", ""); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	}
+	if rec := serveRateLimited(e, h, "10.0.0.1:1234", ""); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected guessing to be limited, got %d", rec.Code)
+	}
+}
+
+func TestRateLimitClientKey(t *testing.T) {
+	e := echo.New()
+	limiter := &countingLimiter{hits: map[string]int{}}
+	h := newRateLimitedHandler(limiter, func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	serveRateLimited(e, h, "10.0.0.1:1234", "")
+	serveRateLimited(e, h, "10.0.0.1:1234", "unknown-key")
+	serveRateLimited(e, h, "10.0.0.1:1234", "partner-key")
+	serveRateLimited(e, h, "10.0.0.2:1234", "partner-key")
+
+	if got := limiter.hits["auth:ip:10.0.0.1"]; got != 2 {
+		t.Errorf("expected an unknown API key to count against the IP, got %d hits", got)
+	}
+	if len(limiter.hits) != 2 {
+		t.Errorf("expected one IP and one API key to be counted, got %v", limiter.hits)
+	}
+	for key, hits := range limiter.hits {
+		if key != "auth:ip:10.0.0.1" && hits != 2 {
+			t.Errorf("expected the API key to be counted across IPs, got %s: %d", key, hits)
+		}
+	}
+}
+
+func TestRateLimitUserKey(t *testing.T) {
+	e := echo.New()
+	limiter := &countingLimiter{hits: map[string]int{}}
+	mw := NewRateLimitMiddleware(limiter, &config.RateLimitConfig{
+		Enabled: true,
+		Groups:  map[string]config.RateLimitRule{"events": {Limit: 1, Window: 60}},
+	})
+	// users behind one IP are counted apart once authenticated
+	h := func(user int) echo.HandlerFunc {
+		limited := mw.LimitUser("events")(func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		return func(c echo.Context) error {
+			if user > 0 {
+				c.Set(ContextKeyCurrentUser, types.CurrentUser{ID: user})
+			}
+			return limited(c)
+		}
+	}
+
+	for _, user := range []int{1, 2} {
+		if rec := serveRateLimited(e, h(user), "10.0.0.1:1234", ""); rec.Code != http.StatusOK {
+			t.Errorf("expected user %d to be allowed, got %d", user, rec.Code)
+		}
+	}
+	if rec := serveRateLimited(e, h(1), "10.0.0.1:1234", ""); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected user 1 to be limited, got %d", rec.Code)
+	}
+	serveRateLimited(e, h(0), "10.0.0.1:1234", "")
+
+	want := map[string]int{"events:user:1": 1, "events:user:2": 1, "events:ip:10.0.0.1": 1}
+	if !reflect.DeepEqual(limiter.hits, want) {
+		t.Errorf("expected hits %v, got %v", want, limiter.hits)
+	}
+}
+
+func TestRateLimitAllowsWhenLimiterFails(t *testing.T) {
+	e := echo.New()
+	limiter := &countingLimiter{err: errors.New("redis down")}
+	h := newRateLimitedHandler(limiter, func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	rec := serveRateLimited(e, h, "10.0.0.1:1234", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("expected the request to pass without limit headers, got %d %v", rec.Code, rec.Header())
+	}
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+)
+
+// validRequestID keeps caller supplied IDs from smuggling anything into the logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID tags the request with the caller's X-Request-ID, or a new one, and
+// returns it in the response. The request context carries a logger with the ID.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID.MatchString(id) {
+				id = uuid.New().String()
+				req.Header.Set(echo.HeaderXRequestID, id)
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			ctx := logutil.With(req.Context(), "request_id", id)
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
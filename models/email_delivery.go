@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type EmailDelivery struct {
+	ID                int        `json:"id" gorm:"column:id"`
+	MessageID         string     `json:"message_id" gorm:"column:message_id"`
+	ProviderMessageID *string    `json:"provider_message_id" gorm:"column:provider_message_id"`
+	TaskType          string     `json:"task_type" gorm:"column:task_type"`
+	EventID           *int       `json:"event_id" gorm:"column:event_id"`
+	UserID            *int       `json:"user_id" gorm:"column:user_id"`
+	Email             string     `json:"email" gorm:"column:email"`
+	Subject           string     `json:"subject" gorm:"column:subject"`
+	Status            string     `json:"status" gorm:"column:status"`
+	Error             *string    `json:"error,omitempty" gorm:"column:error"`
+	Attempts          int        `json:"attempts" gorm:"column:attempts"`
+	SentAt            *time.Time `json:"sent_at" gorm:"column:sent_at"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type EmailSuppression struct {
+	Email     string    `json:"email" gorm:"column:email;primaryKey"`
+	Reason    string    `json:"reason" gorm:"column:reason"`
+	Details   *string   `json:"details" gorm:"column:details"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
@@ -1,20 +1,35 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Event struct {
-	ID          int        `json:"id" gorm:"column:id"`
-	Title       string     `json:"title" gorm:"column:title"`
-	Description *string    `json:"description" gorm:"column:description"`
-	Location    *string    `json:"location" gorm:"column:location"`
-	StartTime   *time.Time `json:"start_time" gorm:"column:start_time"`
-	EndTime     *time.Time `json:"end_time" gorm:"column:end_time"`
-	IsPublic    bool       `json:"is_public" gorm:"column:is_public"`
-	Limit       *int       `json:"limit" gorm:"column:attendee_limit"`
-	CreatedBy   int        `json:"created_by" gorm:"column:created_by"`
-	CreatedAt   time.Time  `json:"-" gorm:"column:created_at"`
-	UpdatedAt   time.Time  `json:"-" gorm:"column:updated_at"`
-	Attendees   []User     `json:"attendee,omitempty" gorm:"many2many:event_attendees;"`
+	ID           int            `json:"id" gorm:"column:id"`
+	Title        string         `json:"title" gorm:"column:title"`
+	Description  *string        `json:"description" gorm:"column:description"`
+	Location     *string        `json:"location" gorm:"column:location"`
+	StartTime    *time.Time     `json:"start_time" gorm:"column:start_time"`
+	EndTime      *time.Time     `json:"end_time" gorm:"column:end_time"`
+	IsPublic     bool           `json:"is_public" gorm:"column:is_public"`
+	Limit        *int           `json:"limit" gorm:"column:attendee_limit"`
+	CreatedBy    int            `json:"created_by" gorm:"column:created_by"`
+	CategoryID   *int           `json:"category_id" gorm:"column:category_id"`
+	RoomID       *int           `json:"room_id" gorm:"column:room_id"`
+	Timezone     string         `json:"timezone" gorm:"column:timezone"`
+	Status       string         `json:"status" gorm:"column:status"`
+	CancelReason *string        `json:"cancel_reason,omitempty" gorm:"column:cancel_reason"`
+	CancelledAt  *time.Time     `json:"cancelled_at,omitempty" gorm:"column:cancelled_at"`
+	Version      int            `json:"-" gorm:"column:version;default:1"`
+	CreatedAt    time.Time      `json:"-" gorm:"column:created_at"`
+	UpdatedAt    time.Time      `json:"-" gorm:"column:updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"column:deleted_at"`
+	Attendees    []User         `json:"attendee,omitempty" gorm:"many2many:event_attendees;"`
+	Category     *Category      `json:"category,omitempty"`
+	Room         *Room          `json:"room,omitempty"`
+	Tags         []Tag          `json:"tags,omitempty" gorm:"many2many:event_tags;"`
 }
 type EventAttendee struct {
 	EventID  int   `json:"event_id" gorm:"column:event_id"`
@@ -23,3 +38,14 @@ type EventAttendee struct {
 	Event    Event `gorm:"foreignKey:ID;references:EventID"`
 	User     User  `gorm:"foreignKey:ID;references:UserID"`
 }
+
+// EventReview is the decision of an approver on an event submitted for
+// publishing.
+type EventReview struct {
+	ID         int       `json:"id" gorm:"column:id"`
+	EventID    int       `json:"event_id" gorm:"column:event_id"`
+	ReviewerID *int      `json:"reviewer_id" gorm:"column:reviewer_id"` // nil once the reviewer is purged
+	Decision   string    `json:"decision" gorm:"column:decision"`
+	Comment    *string   `json:"comment" gorm:"column:comment"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// InvitationBatch tracks the background fan-out of an event's invitation emails.
+type InvitationBatch struct {
+	EventID    int        `json:"event_id" gorm:"column:event_id;primaryKey"`
+	Status     string     `json:"status" gorm:"column:status"`
+	Total      int        `json:"total" gorm:"column:total"`
+	Enqueued   int        `json:"enqueued" gorm:"column:enqueued"`
+	Skipped    int        `json:"skipped" gorm:"column:skipped"`
+	Failed     int        `json:"failed" gorm:"column:failed"`
+	LastUserID int        `json:"-" gorm:"column:last_user_id"`
+	Error      *string    `json:"error,omitempty" gorm:"column:error"`
+	StartedAt  *time.Time `json:"started_at" gorm:"column:started_at"`
+	FinishedAt *time.Time `json:"finished_at" gorm:"column:finished_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Notification struct {
+	ID        int        `json:"id" gorm:"column:id"`
+	UserID    int        `json:"user_id" gorm:"column:user_id"`
+	EventID   *int       `json:"event_id,omitempty" gorm:"column:event_id"`
+	Type      string     `json:"type" gorm:"column:type"`
+	Title     string     `json:"title" gorm:"column:title"`
+	Body      string     `json:"body" gorm:"column:body"`
+	ReadAt    *time.Time `json:"read_at" gorm:"column:read_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Category groups events by kind, an event has at most one.
+type Category struct {
+	ID        int       `json:"id" gorm:"column:id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// Tag is a free form label of events, names are stored lower case.
+type Tag struct {
+	ID   int    `json:"id" gorm:"column:id"`
+	Name string `json:"name" gorm:"column:name"`
+}
+
+type EventTag struct {
+	EventID int `json:"event_id" gorm:"column:event_id"`
+	TagID   int `json:"tag_id" gorm:"column:tag_id"`
+}
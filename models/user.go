@@ -1,18 +1,30 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type (
 	User struct {
-		ID        int       `json:"id"`
-		Email     string    `json:"email"`
-		Password  string    `json:"-"`
-		FirstName string    `json:"first_name"`
-		LastName  string    `json:"last_name"`
-		RoleID    int       `json:"-"`
-		CreatedAt time.Time `json:"-"`
-		UpdatedAt time.Time `json:"-"`
-		Events    []Event   `json:"events,omitempty" gorm:"many2many:event_attendees;"`
+		ID              int            `json:"id"`
+		Email           string         `json:"email"`
+		Password        string         `json:"-"`
+		FirstName       string         `json:"first_name"`
+		LastName        string         `json:"last_name"`
+		RoleID          int            `json:"-"`
+		Phone           *string        `json:"-"`
+		PhoneVerifiedAt *time.Time     `json:"-"`
+		SmsEnabled      bool           `json:"-"`
+		PushEnabled     bool           `json:"-"`
+		PushToken       *string        `json:"-"`
+		Timezone        *string        `json:"-"`
+		Version         int            `json:"-" gorm:"default:1"`
+		CreatedAt       time.Time      `json:"-"`
+		UpdatedAt       time.Time      `json:"-"`
+		DeletedAt       gorm.DeletedAt `json:"-"`
+		Events          []Event        `json:"events,omitempty" gorm:"many2many:event_attendees;"`
 	}
 
 	RolePermission struct {
@@ -25,3 +37,7 @@ type (
 		Permission string
 	}
 )
+
+func (u *User) IsPhoneVerified() bool {
+	return u.Phone != nil && u.PhoneVerifiedAt != nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Venue is a place events are held at, its rooms are what events book.
+type Venue struct {
+	ID        int       `json:"id" gorm:"column:id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Address   *string   `json:"address" gorm:"column:address"`
+	Timezone  string    `json:"timezone" gorm:"column:timezone"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+	Rooms     []Room    `json:"rooms,omitempty"`
+}
+
+type Room struct {
+	ID        int       `json:"id" gorm:"column:id"`
+	VenueID   int       `json:"venue_id" gorm:"column:venue_id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Capacity  int       `json:"capacity" gorm:"column:capacity"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+	Venue     *Venue    `json:"venue,omitempty"`
+}
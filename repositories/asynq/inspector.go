@@ -0,0 +1,106 @@
+package asynq
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+func (repo *Repository) ListQueues() ([]*asynq.QueueInfo, error) {
+	queues, err := repo.inspector.Queues()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*asynq.QueueInfo, 0, len(queues))
+	for _, queue := range queues {
+		info, err := repo.inspector.GetQueueInfo(queue)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (repo *Repository) ListTasks(queue, state string, page, size int) ([]*asynq.TaskInfo, error) {
+	opts := []asynq.ListOption{asynq.Page(page), asynq.PageSize(size)}
+
+	switch state {
+	case consts.TaskStatePending:
+		return repo.inspector.ListPendingTasks(queue, opts...)
+	case consts.TaskStateActive:
+		return repo.inspector.ListActiveTasks(queue, opts...)
+	case consts.TaskStateScheduled:
+		return repo.inspector.ListScheduledTasks(queue, opts...)
+	case consts.TaskStateRetry:
+		return repo.inspector.ListRetryTasks(queue, opts...)
+	case consts.TaskStateArchived:
+		return repo.inspector.ListArchivedTasks(queue, opts...)
+	case consts.TaskStateCompleted:
+		return repo.inspector.ListCompletedTasks(queue, opts...)
+	}
+	return nil, errutil.ErrUnsupportedTaskState
+}
+
+// ApplyTaskAction runs, archives or deletes a single task.
+func (repo *Repository) ApplyTaskAction(queue, action, taskID string) error {
+	switch action {
+	case consts.TaskActionRun:
+		return repo.inspector.RunTask(queue, taskID)
+	case consts.TaskActionArchive:
+		return repo.inspector.ArchiveTask(queue, taskID)
+	case consts.TaskActionDelete:
+		return repo.inspector.DeleteTask(queue, taskID)
+	}
+	return errutil.ErrUnsupportedTaskAction
+}
+
+// ApplyTaskActionToAll runs, archives or deletes every task of the queue in the
+// given state and returns the number of affected tasks.
+func (repo *Repository) ApplyTaskActionToAll(queue, action, state string) (int, error) {
+	switch action {
+	case consts.TaskActionRun:
+		switch state {
+		case consts.TaskStateScheduled:
+			return repo.inspector.RunAllScheduledTasks(queue)
+		case consts.TaskStateRetry:
+			return repo.inspector.RunAllRetryTasks(queue)
+		case consts.TaskStateArchived:
+			return repo.inspector.RunAllArchivedTasks(queue)
+		}
+	case consts.TaskActionArchive:
+		switch state {
+		case consts.TaskStatePending:
+			return repo.inspector.ArchiveAllPendingTasks(queue)
+		case consts.TaskStateScheduled:
+			return repo.inspector.ArchiveAllScheduledTasks(queue)
+		case consts.TaskStateRetry:
+			return repo.inspector.ArchiveAllRetryTasks(queue)
+		}
+	case consts.TaskActionDelete:
+		switch state {
+		case consts.TaskStatePending:
+			return repo.inspector.DeleteAllPendingTasks(queue)
+		case consts.TaskStateScheduled:
+			return repo.inspector.DeleteAllScheduledTasks(queue)
+		case consts.TaskStateRetry:
+			return repo.inspector.DeleteAllRetryTasks(queue)
+		case consts.TaskStateArchived:
+			return repo.inspector.DeleteAllArchivedTasks(queue)
+		case consts.TaskStateCompleted:
+			return repo.inspector.DeleteAllCompletedTasks(queue)
+		}
+	default:
+		return 0, errutil.ErrUnsupportedTaskAction
+	}
+	return 0, errutil.ErrUnsupportedTaskState
+}
+
+func (repo *Repository) PauseQueue(queue string) error {
+	return repo.inspector.PauseQueue(queue)
+}
+
+func (repo *Repository) UnpauseQueue(queue string) error {
+	return repo.inspector.UnpauseQueue(queue)
+}
@@ -1,14 +1,16 @@
 package asynq
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/tracing"
 	"github.com/vivasoft-ltd/go-ems/types"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 )
 
 type Repository struct {
@@ -25,25 +27,31 @@ func NewRepository(config *config.AsynqConfig, client *asynq.Client, inspector *
 	}
 }
 
-func (repo *Repository) CreateTask(event types.AsynqTaskType, data interface{}) (*asynq.Task, error) {
+// CreateTask encodes the data as the task payload, carrying along the trace
+// context of ctx for the worker to continue.
+func (repo *Repository) CreateTask(ctx context.Context, event types.AsynqTaskType, data interface{}) (*asynq.Task, error) {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(event.String(), payload), nil
+	return asynq.NewTask(event.String(), tracing.InjectPayload(ctx, payload)), nil
 }
 
-func (repo *Repository) EnqueueTask(task *asynq.Task, customOpts *types.AsynqOption) (string, error) {
+func (repo *Repository) EnqueueTask(ctx context.Context, task *asynq.Task, customOpts *types.AsynqOption) (string, error) {
 	opts := repo.asynqOptions(*customOpts)
-	taskInfo, err := repo.client.Enqueue(task, opts...)
+	taskInfo, err := repo.client.EnqueueContext(ctx, task, opts...)
 	if err != nil {
 		return "", err
 	}
 	return taskInfo.ID, nil
 }
 
-func (repo *Repository) DequeueTask(taskID string) error {
-	existingTask, err := repo.inspector.GetTaskInfo(repo.config.Queue, taskID)
+func (repo *Repository) DequeueTask(ctx context.Context, queue, taskID string) error {
+	if queue == "" {
+		queue = repo.config.Queue
+	}
+
+	existingTask, err := repo.inspector.GetTaskInfo(queue, taskID)
 	if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
 		return err
 	}
@@ -53,12 +61,12 @@ func (repo *Repository) DequeueTask(taskID string) error {
 
 	deleteOrCancelTask := func(task *asynq.TaskInfo) error {
 		if task.State != asynq.TaskStateActive {
-			repo.inspector.DeleteTask(repo.config.Queue, task.ID)
+			repo.inspector.DeleteTask(queue, task.ID)
 		}
 		if err := repo.inspector.CancelProcessing(task.ID); err != nil {
 			return err
 		}
-		return repo.inspector.DeleteTask(repo.config.Queue, task.ID)
+		return repo.inspector.DeleteTask(queue, task.ID)
 	}
 
 	err = deleteOrCancelTask(existingTask)
@@ -66,7 +74,7 @@ func (repo *Repository) DequeueTask(taskID string) error {
 		return nil
 	}
 	if err != nil {
-		logger.Error("error on deleting task ", taskID, " : ", err)
+		logutil.FromContext(ctx).Error("failed to delete task", "err", err, "task_id", taskID)
 		return err
 	}
 
@@ -102,7 +110,9 @@ func (repo *Repository) asynqOptions(customOpts types.AsynqOption) []asynq.Optio
 	}
 
 	// zero value not allowed
-	if customOpts.DelaySeconds > 0 {
+	if !customOpts.ProcessAt.IsZero() {
+		opts = append(opts, asynq.ProcessAt(customOpts.ProcessAt))
+	} else if customOpts.DelaySeconds > 0 {
 		opts = append(opts, asynq.ProcessIn(customOpts.DelaySeconds*time.Second))
 	}
 
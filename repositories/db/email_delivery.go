@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repo *Repository) CreateEmailDelivery(ctx context.Context, delivery *models.EmailDelivery) error {
+	if err := repo.client.WithContext(ctx).Create(delivery).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to create email delivery", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *Repository) UpdateEmailDelivery(ctx context.Context, messageID string, updates map[string]interface{}) error {
+	qry := repo.client.WithContext(ctx).Model(&models.EmailDelivery{}).Where("message_id = ?", messageID).Updates(updates)
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to update email delivery", "err", qry.Error, "message_id", messageID)
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errutil.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReadEmailDelivery looks a delivery up by our message ID or, failing that, by
+// the ID the provider assigned to it.
+func (repo *Repository) ReadEmailDelivery(ctx context.Context, messageID, providerMessageID string) (*models.EmailDelivery, error) {
+	if messageID == "" && providerMessageID == "" {
+		return nil, errutil.ErrRecordNotFound
+	}
+
+	var delivery models.EmailDelivery
+	query := repo.client.WithContext(ctx).Model(&models.EmailDelivery{})
+	if messageID != "" {
+		query = query.Where("message_id = ?", messageID)
+	} else {
+		query = query.Where("provider_message_id = ?", providerMessageID)
+	}
+
+	err := query.First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errutil.ErrRecordNotFound
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to read email delivery", "err", err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (repo *Repository) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	var count int64
+	if err := repo.client.WithContext(ctx).Model(&models.EmailSuppression{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (repo *Repository) SuppressEmail(ctx context.Context, suppression *models.EmailSuppression) error {
+	qry := repo.client.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "details"}),
+	}).Create(suppression)
+
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to suppress email", "err", qry.Error, "email", suppression.Email)
+		return qry.Error
+	}
+	return nil
+}
+
+func (repo *Repository) DeleteEmailDelivery(ctx context.Context, messageID string) error {
+	if err := repo.client.WithContext(ctx).Where("message_id = ?", messageID).Delete(&models.EmailDelivery{}).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to delete email delivery", "err", err, "message_id", messageID)
+		return err
+	}
+	return nil
+}
+
+// CountEmailDeliveriesByStatus counts the event's deliveries of the task type per status.
+func (repo *Repository) CountEmailDeliveriesByStatus(ctx context.Context, eventID int, taskType string) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	err := repo.client.WithContext(ctx).Model(&models.EmailDelivery{}).
+		Select("status, COUNT(*) AS count").
+		Where("event_id = ? AND task_type = ?", eventID, taskType).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count email deliveries", "err", err, "event_id", eventID)
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
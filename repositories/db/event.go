@@ -1,136 +1,546 @@
 package db
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-func (repo *Repository) CreateEvent(event *models.Event) (*models.Event, error) {
-	qry := repo.client.Create(event)
-	if qry.Error != nil {
-		logger.Error(fmt.Errorf("error creating event: %w", qry.Error))
-		return nil, qry.Error
+func (repo *Repository) CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategory(tx, event.CategoryID); err != nil {
+			return err
+		}
+		if err := checkRoomBooking(tx, 0, event.RoomID, event.StartTime, event.EndTime, event.Limit); err != nil {
+			return err
+		}
+		tags, err := resolveTags(tx, event.Tags)
+		if err != nil {
+			return err
+		}
+		event.Tags = tags
+		// the tags exist already, only link them
+		return tx.Omit("Tags.*").Create(event).Error
+	})
+	if errors.Is(err, errutil.ErrCategoryNotFound) || errutil.Exists(err, bookingErrors) {
+		return nil, err
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to create event", "err", err)
+		return nil, err
 	}
 
 	return event, nil
 }
 
-func (repo *Repository) ListEvents(filter *types.EventFilter, Limit, Offset int) ([]*models.Event, int, error) {
+// withEventDetails loads the category, the room and the tags of the events.
+func withEventDetails(query *gorm.DB) *gorm.DB {
+	return query.Preload("Category").Preload("Room").Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name")
+	})
+}
+
+// eventSortColumns maps the sort fields of the API to their columns, only
+// these ever reach ORDER BY.
+var eventSortColumns = map[string]string{
+	"start_time": "start_time",
+	"end_time":   "end_time",
+	"created_at": "created_at",
+	"title":      "title",
+}
+
+func (repo *Repository) ListEvents(ctx context.Context, filter *types.EventFilter, Limit, Offset int) ([]*models.Event, int, error) {
 	var events []*models.Event
 	var count int64
 
-	query := repo.client.Model(&models.Event{})
+	query := repo.client.WithContext(ctx).Model(&models.Event{})
 	repo.applyFilters(query, filter)
 
 	if err := query.Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
-	result := query.Offset(Offset).Limit(Limit).Find(&events)
+	applySort(query, filter)
+	result := withEventDetails(query).Offset(Offset).Limit(Limit).Find(&events)
 	if result.RowsAffected == 0 {
-		logger.Error("no events found")
+		logutil.FromContext(ctx).Warn("no events found")
 		return nil, 0, errutil.ErrRecordNotFound
 	}
 	if result.Error != nil {
-		logger.Error(fmt.Errorf("error listing events: %w", result.Error))
+		logutil.FromContext(ctx).Error("failed to list events", "err", result.Error)
 		return nil, 0, result.Error
 	}
 
 	return events, int(count), nil
 }
+func (repo *Repository) CountEvents(ctx context.Context, filter *types.EventFilter) (int, error) {
+	var count int64
+	query := repo.client.WithContext(ctx).Model(&models.Event{})
+	repo.applyFilters(query, filter)
+	if err := query.Count(&count).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to count events", "err", err)
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ListEventsAfter returns up to limit events past the cursor, or before it when
+// the cursor is backward, in (start_time, id) order. Without a cursor it starts
+// at the first event.
+func (repo *Repository) ListEventsAfter(ctx context.Context, filter *types.EventFilter, cursor *types.Cursor, limit int) ([]*models.Event, error) {
+	var events []*models.Event
+	query := repo.client.WithContext(ctx).Model(&models.Event{})
+	repo.applyFilters(query, filter)
+
+	desc := filter != nil && filter.Order == consts.SortDesc
+	// a backward page is read from the cursor towards the start, then reversed
+	if cursor.IsBackward() {
+		desc = !desc
+	}
+	if cursor != nil {
+		query.Where(eventKeyset(cursor, desc))
+	}
+	err := withEventDetails(query).Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: "start_time"}, Desc: desc},
+		{Column: clause.Column{Name: "id"}, Desc: desc},
+	}}).Limit(limit).Find(&events).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list events", "err", err)
+		return nil, err
+	}
+
+	if cursor.IsBackward() {
+		slices.Reverse(events)
+	}
+	return events, nil
+}
+
+// eventKeyset matches the events that come after the cursor in (start_time, id)
+// order, descending when desc. MySQL sorts missing start times first.
+func eventKeyset(cursor *types.Cursor, desc bool) clause.Expr {
+	switch {
+	case cursor.StartTime == nil && !desc:
+		return gorm.Expr("start_time IS NOT NULL OR id > ?", cursor.ID)
+	case cursor.StartTime == nil:
+		return gorm.Expr("start_time IS NULL AND id < ?", cursor.ID)
+	case !desc:
+		return gorm.Expr("start_time > ? OR (start_time = ? AND id > ?)", cursor.StartTime, cursor.StartTime, cursor.ID)
+	default:
+		return gorm.Expr("start_time < ? OR start_time IS NULL OR (start_time = ? AND id < ?)", cursor.StartTime, cursor.StartTime, cursor.ID)
+	}
+}
+
 func (repo *Repository) applyFilters(query *gorm.DB, filter *types.EventFilter) {
 	if filter == nil {
 		return
 	}
+	// times are stored in UTC, the clock of the DB server doesn't matter
+	now := time.Now().UTC()
 	if filter.IsPublic != nil {
-		query = query.Where("is_public = ? and end_time > curdate()", filter.IsPublic)
+		query = query.Where("is_public = ? and end_time > ? and status IN ?", filter.IsPublic, now, consts.PublishedEventStatuses)
 	}
 	if filter.CreatedBy != nil {
 		query = query.Where("created_by = ?", filter.CreatedBy)
 	}
 	if filter.Attendee != nil {
-		query = query.Where("(is_public = ? and end_time > curdate()) OR id IN (SELECT event_id FROM event_attendees WHERE user_id = ?)", true, filter.Attendee)
+		// invitations to drafts haven't been sent yet
+		query = query.Where("status IN ? AND ((is_public = ? and end_time > ?) OR id IN (SELECT event_id FROM event_attendees WHERE user_id = ?))", consts.PublishedEventStatuses, true, now, filter.Attendee)
 	}
+
+	// the search criteria only ever narrow the scope above down
+	if filter.From != nil {
+		query = query.Where("end_time >= ?", filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time <= ?", filter.To)
+	}
+	if search := fulltextQuery(filter.Search); search != "" {
+		query = query.Where("MATCH (title, description, location) AGAINST (? IN BOOLEAN MODE)", search)
+	}
+	if filter.Creator != nil {
+		query = query.Where("created_by = ?", filter.Creator)
+	}
+	if filter.RsvpStatus != 0 {
+		query = query.Where("id IN (SELECT event_id FROM event_attendees WHERE user_id = ? AND status_id = ?)", filter.RsvpUserID, filter.RsvpStatus)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if len(filter.Tags) > 0 {
+		tagged := repo.client.Model(&models.EventTag{}).
+			Select("event_tags.event_id").
+			Joins("JOIN tags ON tags.id = event_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags).
+			Group("event_tags.event_id").
+			Having("COUNT(*) = ?", len(filter.Tags))
+		query = query.Where("id IN (?)", tagged)
+	}
+	if filter.HasSeats {
+		// a cancelled event takes no RSVPs
+		query = query.Where("status <> ?", consts.EventStatusCancelled)
+		// counted like RsvpEvent does, a limit of 0 or less is no limit
+		attendees := repo.client.Model(&models.EventAttendee{}).
+			Select("COUNT(*)").
+			Where("event_attendees.event_id = events.id AND event_attendees.user_id IN (?)", repo.activeUserIDs())
+		query = query.Where("attendee_limit IS NULL OR attendee_limit <= 0 OR attendee_limit > (?)", attendees)
+	}
+}
+
+func applySort(query *gorm.DB, filter *types.EventFilter) {
+	column, ok := "", false
+	if filter != nil {
+		column, ok = eventSortColumns[filter.Sort]
+	}
+	if !ok {
+		query.Order("id")
+		return
+	}
+	query.Order(clause.OrderByColumn{
+		Column: clause.Column{Name: column},
+		Desc:   filter.Order == consts.SortDesc,
+	})
+	// keeps pages stable between equal values
+	query.Order("id")
 }
 
-func (repo *Repository) ReadEventByID(id int) (*models.Event, error) {
+// fulltextQuery turns the search text into a boolean mode query that requires
+// every word as a prefix. The operators of the mode are dropped from the input
+// so user text can't change the meaning of the query.
+func fulltextQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = "+" + word + "*"
+	}
+	return strings.Join(words, " ")
+}
+
+func (repo *Repository) ReadEventByID(ctx context.Context, id int) (*models.Event, error) {
 	var event models.Event
-	qry := repo.client.Preload("Attendees").First(&event, id)
+	qry := withEventDetails(repo.client.WithContext(ctx).Preload("Attendees")).First(&event, id)
 	if errors.Is(qry.Error, gorm.ErrRecordNotFound) {
-		logger.Error(fmt.Errorf("event with ID %d not found", id))
+		logutil.FromContext(ctx).Warn("event not found", "event_id", id)
 		return nil, errutil.ErrRecordNotFound
 	}
 	if qry.Error != nil {
-		logger.Error(fmt.Errorf("error getting event by ID: %w", qry.Error))
+		logutil.FromContext(ctx).Error("failed to read event", "err", qry.Error, "event_id", id)
 		return nil, qry.Error
 	}
 
 	return &event, nil
 }
 
-func (repo *Repository) UpdateEvent(event *models.Event) (*models.Event, error) {
-	qry := repo.client.Where("id = ?", event.ID).Updates(event)
+// ReadEventStatus reads only the status of the event, for long running tasks
+// that check it as they go.
+func (repo *Repository) ReadEventStatus(ctx context.Context, id int) (string, error) {
+	var event models.Event
+	qry := repo.client.WithContext(ctx).Select("id", "status").First(&event, id)
 	if errors.Is(qry.Error, gorm.ErrRecordNotFound) {
-		logger.Error(fmt.Errorf("no event found with ID %d", event.ID))
-		return nil, errutil.ErrRecordNotFound
+		return "", errutil.ErrRecordNotFound
 	}
 	if qry.Error != nil {
-		logger.Error(fmt.Errorf("error updating event: %w", qry.Error))
-		return nil, qry.Error
+		logutil.FromContext(ctx).Error("failed to read event status", "err", qry.Error, "event_id", id)
+		return "", qry.Error
+	}
+	return event.Status, nil
+}
+
+// UpdateEvent updates the event only while it still has event.Version, and
+// bumps the version. The tags are replaced unless event.Tags is nil.
+func (repo *Repository) UpdateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
+	version := event.Version
+	event.Version++
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategory(tx, event.CategoryID); err != nil {
+			return err
+		}
+		if err := checkUpdatedRoomBooking(tx, event, version); err != nil {
+			return err
+		}
+		qry := tx.Omit(clause.Associations).Where("id = ? AND version = ?", event.ID, version).Updates(event)
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrVersionConflict
+		}
+		if event.Tags == nil {
+			return nil
+		}
+		tags, err := resolveTags(tx, event.Tags)
+		if err != nil {
+			return err
+		}
+		event.Tags = tags
+		return replaceEventTags(tx, event.ID, tags)
+	})
+	if err != nil {
+		event.Version = version
+	}
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		logutil.FromContext(ctx).Warn("event version conflict", "event_id", event.ID, "version", version)
+		return nil, err
+	}
+	if errors.Is(err, errutil.ErrCategoryNotFound) || errutil.Exists(err, bookingErrors) {
+		return nil, err
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to update event", "err", err, "event_id", event.ID)
+		return nil, err
 	}
 	return event, nil
 }
 
-func (repo *Repository) DeleteEvent(id int) error {
-	qry := repo.client.Where("id = ?", id).Delete(&models.Event{})
+// UpdateEventStatus moves the event to the status while it still has
+// event.Version, and records the review of an approver with it.
+func (repo *Repository) UpdateEventStatus(ctx context.Context, event *models.Event, status string, review *models.EventReview) (*models.Event, error) {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		qry := tx.Model(&models.Event{}).
+			Where("id = ? AND version = ?", event.ID, event.Version).
+			Updates(map[string]interface{}{
+				"status":  status,
+				"version": gorm.Expr("version + 1"),
+			})
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrVersionConflict
+		}
+		if review == nil {
+			return nil
+		}
+		review.EventID = event.ID
+		return tx.Create(review).Error
+	})
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		logutil.FromContext(ctx).Warn("event version conflict", "event_id", event.ID, "version", event.Version)
+		return nil, err
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to update event status", "err", err, "event_id", event.ID, "status", status)
+		return nil, err
+	}
+	event.Status = status
+	event.Version++
+	return event, nil
+}
+
+// CancelEvent cancels the event with the reason while it still has
+// event.Version.
+func (repo *Repository) CancelEvent(ctx context.Context, event *models.Event, reason string) (*models.Event, error) {
+	now := time.Now()
+	qry := repo.client.WithContext(ctx).Model(&models.Event{}).
+		Where("id = ? AND version = ?", event.ID, event.Version).
+		Updates(map[string]interface{}{
+			"status":        consts.EventStatusCancelled,
+			"cancel_reason": reason,
+			"cancelled_at":  now,
+			"version":       gorm.Expr("version + 1"),
+		})
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to cancel event", "err", qry.Error, "event_id", event.ID)
+		return nil, qry.Error
+	}
 	if qry.RowsAffected == 0 {
-		logger.Error(fmt.Errorf("no event found with ID %d", id))
-		return errutil.ErrRecordNotFound
+		logutil.FromContext(ctx).Warn("event version conflict", "event_id", event.ID, "version", event.Version)
+		return nil, errutil.ErrVersionConflict
+	}
+	event.Status = consts.EventStatusCancelled
+	event.CancelReason = &reason
+	event.CancelledAt = &now
+	event.Version++
+	return event, nil
+}
+
+// ListEventReviews returns the reviews of the event, oldest first.
+func (repo *Repository) ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error) {
+	var reviews []models.EventReview
+	if err := repo.client.WithContext(ctx).Where("event_id = ?", eventID).Order("id").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ReplaceEvent writes every field of the event, nil and zero values included,
+// while it still has event.Version, and applies the attendee changes with it.
+// The tags of the event become event.Tags.
+func (repo *Repository) ReplaceEvent(ctx context.Context, event *models.Event, addedAttendees, removedAttendees []int) (*models.Event, error) {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategory(tx, event.CategoryID); err != nil {
+			return err
+		}
+		if err := checkRoomBooking(tx, event.ID, event.RoomID, event.StartTime, event.EndTime, event.Limit); err != nil {
+			return err
+		}
+		qry := tx.Model(&models.Event{}).
+			Where("id = ? AND version = ?", event.ID, event.Version).
+			Updates(map[string]interface{}{
+				"title":          event.Title,
+				"description":    event.Description,
+				"location":       event.Location,
+				"start_time":     event.StartTime,
+				"end_time":       event.EndTime,
+				"is_public":      event.IsPublic,
+				"attendee_limit": event.Limit,
+				"category_id":    event.CategoryID,
+				"room_id":        event.RoomID,
+				"timezone":       event.Timezone,
+				"version":        gorm.Expr("version + 1"),
+			})
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrVersionConflict
+		}
+
+		if len(removedAttendees) > 0 {
+			err := tx.Where("event_id = ? AND user_id IN ?", event.ID, removedAttendees).
+				Delete(&models.EventAttendee{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(addedAttendees) > 0 {
+			invitations := make([]models.EventAttendee, 0, len(addedAttendees))
+			for _, userID := range addedAttendees {
+				invitations = append(invitations, models.EventAttendee{
+					EventID:  event.ID,
+					UserID:   userID,
+					StatusID: consts.StatusInvited,
+				})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&invitations).Error; err != nil {
+				return err
+			}
+		}
+
+		tags, err := resolveTags(tx, event.Tags)
+		if err != nil {
+			return err
+		}
+		event.Tags = tags
+		return replaceEventTags(tx, event.ID, tags)
+	})
+	if errors.Is(err, errutil.ErrVersionConflict) {
+		logutil.FromContext(ctx).Warn("event version conflict", "event_id", event.ID, "version", event.Version)
+		return nil, err
+	}
+	if errors.Is(err, errutil.ErrCategoryNotFound) || errutil.Exists(err, bookingErrors) {
+		return nil, err
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to replace event", "err", err, "event_id", event.ID)
+		return nil, err
+	}
+
+	event.Version++
+	return event, nil
+}
+
+// checkUpdatedRoomBooking checks the room booking of the event as UpdateEvent
+// leaves it, the fields the update doesn't set keep their stored values. The
+// stored event may change meanwhile, the update then fails on its version.
+func checkUpdatedRoomBooking(tx *gorm.DB, event *models.Event, version int) error {
+	var current models.Event
+	err := tx.Where("id = ? AND version = ?", event.ID, version).First(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errutil.ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	roomID, start, end, limit := current.RoomID, current.StartTime, current.EndTime, current.Limit
+	if event.RoomID != nil {
+		roomID = event.RoomID
 	}
+	if event.StartTime != nil {
+		start = event.StartTime
+	}
+	if event.EndTime != nil {
+		end = event.EndTime
+	}
+	if event.Limit != nil {
+		limit = event.Limit
+	}
+	return checkRoomBooking(tx, event.ID, roomID, start, end, limit)
+}
+
+// DeleteEvent deletes the event when it has the version, 0 matches any version.
+func (repo *Repository) DeleteEvent(ctx context.Context, id, version int) error {
+	qry := repo.client.WithContext(ctx).Where("id = ?", id)
+	if version != 0 {
+		qry = qry.Where("version = ?", version)
+	}
+	qry = qry.Delete(&models.Event{})
 	if qry.Error != nil {
-		logger.Error(fmt.Errorf("error deleting event: %w", qry.Error))
+		logutil.FromContext(ctx).Error("failed to delete event", "err", qry.Error, "event_id", id)
 		return qry.Error
 	}
+	if qry.RowsAffected == 0 && version != 0 {
+		logutil.FromContext(ctx).Warn("event version conflict", "event_id", id, "version", version)
+		return errutil.ErrVersionConflict
+	}
+	if qry.RowsAffected == 0 {
+		logutil.FromContext(ctx).Warn("event not found", "event_id", id)
+		return errutil.ErrRecordNotFound
+	}
 	return nil
 }
-func (repo *Repository) ReadEventInvitation(event int, userID int) (*models.EventAttendee, error) {
+func (repo *Repository) ReadEventInvitation(ctx context.Context, event int, userID int) (*models.EventAttendee, error) {
 	var invitation models.EventAttendee
-	if err := repo.client.Where("event_id = ? AND user_id = ?", event, userID).First(&invitation).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Where("event_id = ? AND user_id = ?", event, userID).First(&invitation).Error; err != nil {
 		return nil, err
 	}
 	return &invitation, nil
 }
 
-func (repo *Repository) UpsertEventInvitation(invitation *models.EventAttendee) error {
-	qry := repo.client.Clauses(clause.OnConflict{
+// UpsertEventInvitation leaves the version of the event alone. It covers the
+// event's own fields, so an organizer's If-Match doesn't fail on every RSVP.
+func (repo *Repository) UpsertEventInvitation(ctx context.Context, invitation *models.EventAttendee) error {
+	err := repo.client.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{"status_id": invitation.StatusID}),
-	}).Create(invitation)
-
-	if qry.Error != nil {
-		logger.Error(fmt.Errorf("error upserting event invitation: %w", qry.Error))
-		return qry.Error
+	}).Create(invitation).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to upsert event invitation", "err", err)
+		return err
 	}
 	return nil
 }
-func (repo *Repository) GetEventAttendeesCount(eventID int) (int, error) {
+
+// activeUserIDs is a subquery of the IDs of the users not deleted, for the
+// event_attendees queries that don't go through the users model.
+func (repo *Repository) activeUserIDs() *gorm.DB {
+	return repo.client.Model(&models.User{}).Select("id")
+}
+
+func (repo *Repository) GetEventAttendeesCount(ctx context.Context, eventID int) (int, error) {
 	var count int64
-	if err := repo.client.Model(&models.EventAttendee{}).Where("event_id = ?", eventID).Count(&count).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.EventAttendee{}).Where("event_id = ? AND user_id IN (?)", eventID, repo.activeUserIDs()).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return int(count), nil
 }
 
-func (repo *Repository) GetAcceptedEventAttendees(eventID int) ([]models.EventAttendee, error) {
+func (repo *Repository) GetAcceptedEventAttendees(ctx context.Context, eventID int) ([]models.EventAttendee, error) {
 	var eventAttendees []models.EventAttendee
-	if err := repo.client.Model(&models.EventAttendee{}).Where("event_id = ? and status_id = ?", eventID, consts.StatusAccepted).Preload("User").Find(&eventAttendees).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.EventAttendee{}).Where("event_id = ? and status_id = ? and user_id IN (?)", eventID, consts.StatusAccepted, repo.activeUserIDs()).Preload("User").Find(&eventAttendees).Error; err != nil {
 		return nil, err
 	}
 	if len(eventAttendees) == 0 {
@@ -138,3 +548,62 @@ func (repo *Repository) GetAcceptedEventAttendees(eventID int) ([]models.EventAt
 	}
 	return eventAttendees, nil
 }
+
+// ListEventAttendees returns the invited users of the event together with the
+// state of the latest invitation email sent to each of them.
+func (repo *Repository) ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error) {
+	var attendees []types.EventAttendeeResp
+
+	latestDelivery := repo.client.WithContext(ctx).Model(&models.EmailDelivery{}).
+		Select("MAX(id)").
+		Where("event_id = event_attendees.event_id AND user_id = event_attendees.user_id AND task_type = ?", types.AsynqTaskTypeInvitationEmail.String())
+
+	err := repo.client.WithContext(ctx).Table("event_attendees").
+		Select("event_attendees.user_id, users.email, users.first_name, users.last_name, event_attendees.status_id, "+
+			"email_deliveries.status AS delivery_status, email_deliveries.updated_at AS delivery_updated_at").
+		Joins("JOIN users ON users.id = event_attendees.user_id").
+		Joins("LEFT JOIN email_deliveries ON email_deliveries.id = (?)", latestDelivery).
+		Where("event_attendees.event_id = ? AND users.deleted_at IS NULL", eventID).
+		Order("event_attendees.user_id").
+		Scan(&attendees).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list event attendees", "err", err, "event_id", eventID)
+		return nil, err
+	}
+
+	return attendees, nil
+}
+
+// ListEventUsersByStatus returns a page of the users whose invitation to
+// the event has one of the statuses, in ID order after afterUserID.
+func (repo *Repository) ListEventUsersByStatus(ctx context.Context, eventID int, statusIDs []int, afterUserID, limit int) ([]models.User, error) {
+	var users []models.User
+	err := repo.client.WithContext(ctx).Model(&models.User{}).
+		Joins("JOIN event_attendees ON event_attendees.user_id = users.id").
+		Where("event_attendees.event_id = ? AND event_attendees.status_id IN ? AND users.id > ?", eventID, statusIDs, afterUserID).
+		Order("users.id").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list event users", "err", err, "event_id", eventID)
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListEventInvitees returns up to limit invited users of the event with an ID
+// greater than afterUserID, ordered by ID, so callers can page through them.
+func (repo *Repository) ListEventInvitees(ctx context.Context, eventID, afterUserID, limit int) ([]models.User, error) {
+	var users []models.User
+	err := repo.client.WithContext(ctx).Model(&models.User{}).
+		Joins("JOIN event_attendees ON event_attendees.user_id = users.id").
+		Where("event_attendees.event_id = ? AND users.id > ?", eventID, afterUserID).
+		Order("users.id").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list event invitees", "err", err, "event_id", eventID)
+		return nil, err
+	}
+	return users, nil
+}
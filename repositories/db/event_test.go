@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+func TestFulltextQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		want   string
+	}{
+		{name: "Empty", search: "  ", want: ""},
+		{name: "Words", search: "go meetup", want: "+go* +meetup*"},
+		{name: "Operators", search: `-go +"meet*up" (x) ~y <z>`, want: "+go* +meet* +up* +x* +y* +z*"},
+		{name: "Unicode", search: "café Dhaka", want: "+café* +Dhaka*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fulltextQuery(tt.search); got != tt.want {
+				t.Errorf("fulltextQuery(%q) = %q, want %q", tt.search, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertEventInvitation(t *testing.T) {
+	// Test case 1: A new attendee leaves the version of the event alone
+	t.Run("SuccessfulWithoutVersionBump", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `event_attendees`")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpsertEventInvitation(context.Background(), &models.EventAttendee{EventID: 3, UserID: 7, StatusID: consts.StatusAccepted})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
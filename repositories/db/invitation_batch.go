@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateInvitationBatch creates the batch of the event or resets an existing one.
+func (repo *Repository) CreateInvitationBatch(ctx context.Context, batch *models.InvitationBatch) error {
+	qry := repo.client.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "total", "enqueued", "skipped", "failed", "last_user_id", "error", "started_at", "finished_at"}),
+	}).Create(batch)
+
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to create invitation batch", "err", qry.Error, "event_id", batch.EventID)
+		return qry.Error
+	}
+	return nil
+}
+
+func (repo *Repository) ReadInvitationBatch(ctx context.Context, eventID int) (*models.InvitationBatch, error) {
+	var batch models.InvitationBatch
+	err := repo.client.WithContext(ctx).Model(&models.InvitationBatch{}).Where("event_id = ?", eventID).First(&batch).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errutil.ErrRecordNotFound
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to read invitation batch", "err", err, "event_id", eventID)
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (repo *Repository) UpdateInvitationBatch(ctx context.Context, eventID int, updates map[string]interface{}) error {
+	qry := repo.client.WithContext(ctx).Model(&models.InvitationBatch{}).Where("event_id = ?", eventID).Updates(updates)
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to update invitation batch", "err", qry.Error, "event_id", eventID)
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errutil.ErrRecordNotFound
+	}
+	return nil
+}
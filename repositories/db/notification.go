@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+)
+
+func (repo *Repository) CreateNotifications(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := repo.client.WithContext(ctx).Create(&notifications).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to create notifications", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *Repository) ListNotifications(ctx context.Context, userID int, filter *types.NotificationFilter, limit, offset int) ([]*models.Notification, int, error) {
+	var notifications []*models.Notification
+	var count int64
+
+	query := repo.client.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ?", userID)
+	if filter != nil && filter.Unread != nil {
+		if *filter.Unread {
+			query = query.Where("read_at IS NULL")
+		} else {
+			query = query.Where("read_at IS NOT NULL")
+		}
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&notifications).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to list notifications", "err", err)
+		return nil, 0, err
+	}
+
+	return notifications, int(count), nil
+}
+
+func (repo *Repository) CountUnreadNotifications(ctx context.Context, userID int) (int, error) {
+	var count int64
+	if err := repo.client.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// MarkNotificationsRead marks the given notifications of the user as read.
+// An empty id list marks every unread notification of the user.
+func (repo *Repository) MarkNotificationsRead(ctx context.Context, userID int, ids []int) error {
+	query := repo.client.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN (?)", ids)
+	}
+
+	if err := query.Update("read_at", time.Now()).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to mark notifications as read", "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repo *Repository) ListCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	if err := repo.client.WithContext(ctx).Order("name").Find(&categories).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to list categories", "err", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (repo *Repository) ReadCategory(ctx context.Context, id int) (*models.Category, error) {
+	var category models.Category
+	qry := repo.client.WithContext(ctx).First(&category, id)
+	if errors.Is(qry.Error, gorm.ErrRecordNotFound) {
+		return nil, errutil.ErrRecordNotFound
+	}
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to read category", "err", qry.Error, "category_id", id)
+		return nil, qry.Error
+	}
+	return &category, nil
+}
+
+// CategoryCountByName counts the categories named name other than excludeID.
+func (repo *Repository) CategoryCountByName(ctx context.Context, name string, excludeID int) (int, error) {
+	var count int64
+	err := repo.client.WithContext(ctx).Model(&models.Category{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count categories", "err", err)
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (repo *Repository) CreateCategory(ctx context.Context, category *models.Category) error {
+	if err := repo.client.WithContext(ctx).Create(category).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to create category", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *Repository) UpdateCategory(ctx context.Context, category *models.Category) error {
+	qry := repo.client.WithContext(ctx).Model(category).Update("name", category.Name)
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to update category", "err", qry.Error, "category_id", category.ID)
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errutil.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteCategory deletes the category, its events are left without one. The
+// events change with it, so their versions are bumped.
+func (repo *Repository) DeleteCategory(ctx context.Context, id int) error {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Event{}).Where("category_id = ?", id).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		qry := tx.Delete(&models.Category{}, id)
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errutil.ErrRecordNotFound) {
+		logutil.FromContext(ctx).Error("failed to delete category", "err", err, "category_id", id)
+	}
+	return err
+}
+
+// ListTags returns up to limit tags starting with prefix, the most used first.
+// Only public, upcoming events are counted, like the public event listing.
+func (repo *Repository) ListTags(ctx context.Context, prefix string, limit int) ([]types.TagResp, error) {
+	var tags []types.TagResp
+	err := repo.client.WithContext(ctx).Table("tags").
+		Select("tags.id, tags.name, COUNT(events.id) AS event_count").
+		Joins("LEFT JOIN event_tags ON event_tags.tag_id = tags.id").
+		Joins("LEFT JOIN events ON events.id = event_tags.event_id AND events.is_public = ? AND events.end_time > ? AND events.deleted_at IS NULL", true, time.Now().UTC()).
+		Where("tags.name LIKE ?", escapeLike(prefix)+"%").
+		Group("tags.id, tags.name").
+		Order("event_count DESC, tags.name").
+		Limit(limit).
+		Scan(&tags).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list tags", "err", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// DeleteTag deletes the tag and takes it off its events, bumping their versions.
+func (repo *Repository) DeleteTag(ctx context.Context, id int) error {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tagged := tx.Model(&models.EventTag{}).Select("event_id").Where("tag_id = ?", id)
+		err := tx.Unscoped().Model(&models.Event{}).Where("id IN (?)", tagged).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		qry := tx.Delete(&models.Tag{}, id)
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errutil.ErrRecordNotFound) {
+		logutil.FromContext(ctx).Error("failed to delete tag", "err", err, "tag_id", id)
+	}
+	return err
+}
+
+// checkCategory fails with ErrCategoryNotFound unless the category exists, and
+// keeps it from being deleted until the transaction ends.
+func checkCategory(tx *gorm.DB, id *int) error {
+	if id == nil {
+		return nil
+	}
+	var count int64
+	err := tx.Model(&models.Category{}).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("id = ?", *id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errutil.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// resolveTags creates the tags that don't exist yet and returns all of them
+// with their IDs.
+func resolveTags(tx *gorm.DB, tags []models.Tag) ([]models.Tag, error) {
+	if len(tags) == 0 {
+		return tags, nil
+	}
+	names := make([]string, 0, len(tags))
+	rows := make([]models.Tag, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+		rows = append(rows, models.Tag{Name: tag.Name})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	var resolved []models.Tag
+	if err := tx.Where("name IN ?", names).Order("name").Find(&resolved).Error; err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// replaceEventTags makes the tags the only ones of the event.
+func replaceEventTags(tx *gorm.DB, eventID int, tags []models.Tag) error {
+	ids := make([]int, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	stale := tx.Where("event_id = ?", eventID)
+	if len(ids) > 0 {
+		stale = stale.Where("tag_id NOT IN ?", ids)
+	}
+	if err := stale.Delete(&models.EventTag{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	eventTags := make([]models.EventTag, 0, len(ids))
+	for _, id := range ids {
+		eventTags = append(eventTags, models.EventTag{EventID: eventID, TagID: id})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&eventTags).Error
+}
+
+// escapeLike escapes the wildcards of LIKE in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,22 @@
+package db
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{name: "Plain", prefix: "work", want: "work"},
+		{name: "Wildcards", prefix: "50%_off", want: `50\%\_off`},
+		{name: "Backslash", prefix: `a\b`, want: `a\\b`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeLike(tt.prefix); got != tt.want {
+				t.Errorf("escapeLike(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// purgeBatchSize bounds the rows locked by one purge transaction.
+const purgeBatchSize = 500
+
+func (repo *Repository) ListDeletedEvents(ctx context.Context, limit, offset int) ([]types.TrashedEvent, int, error) {
+	var events []types.TrashedEvent
+	var count int64
+
+	query := repo.client.WithContext(ctx).Unscoped().Model(&models.Event{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&count).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to count deleted events", "err", err)
+		return nil, 0, err
+	}
+	err := query.Select("id, title, created_by, deleted_at").
+		Order("deleted_at DESC").
+		Offset(offset).Limit(limit).
+		Scan(&events).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list deleted events", "err", err)
+		return nil, 0, err
+	}
+
+	return events, int(count), nil
+}
+
+// RestoreEvent brings a deleted event back together with its invitations,
+// which are kept until the event is purged.
+func (repo *Repository) RestoreEvent(ctx context.Context, id int) error {
+	qry := repo.client.WithContext(ctx).Unscoped().Model(&models.Event{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to restore event", "err", qry.Error, "event_id", id)
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errutil.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedEvents permanently deletes the events deleted before the cutoff
+// along with their invitations, notifications and email deliveries. Invitation
+// batches, reviews and tags go with the events through their foreign keys.
+func (repo *Repository) PurgeDeletedEvents(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	for {
+		var ids []int
+		err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&models.Event{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("deleted_at < ?", before).
+				Limit(purgeBatchSize).
+				Pluck("id", &ids).Error
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+
+			if err := tx.Where("event_id IN ?", ids).Delete(&models.EventAttendee{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("event_id IN ?", ids).Delete(&models.Notification{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("event_id IN ?", ids).Delete(&models.EmailDelivery{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Event{}).Error
+		})
+		if err != nil {
+			logutil.FromContext(ctx).Error("failed to purge deleted events", "err", err, "purged", purged)
+			return purged, err
+		}
+
+		purged += len(ids)
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (repo *Repository) ListDeletedUsers(ctx context.Context, limit, offset int) ([]types.TrashedUser, int, error) {
+	var users []types.TrashedUser
+	var count int64
+
+	query := repo.client.WithContext(ctx).Unscoped().Model(&models.User{}).Where("deleted_at IS NOT NULL")
+	if err := query.Count(&count).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to count deleted users", "err", err)
+		return nil, 0, err
+	}
+	err := query.Select("id, email, first_name, last_name, deleted_at").
+		Order("deleted_at DESC").
+		Offset(offset).Limit(limit).
+		Scan(&users).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list deleted users", "err", err)
+		return nil, 0, err
+	}
+
+	return users, int(count), nil
+}
+
+func (repo *Repository) RestoreUser(ctx context.Context, id int) error {
+	qry := repo.client.WithContext(ctx).Unscoped().Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to restore user", "err", qry.Error, "user_id", id)
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errutil.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedUsers permanently deletes the users deleted before the cutoff
+// along with their invitations, notifications and email deliveries. Their
+// reviews are kept without the reviewer. Users who still created an event,
+// deleted or not, are kept until the event is purged.
+func (repo *Repository) PurgeDeletedUsers(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	for {
+		var ids []int
+		err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&models.User{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("deleted_at < ?", before).
+				Where("NOT EXISTS (SELECT 1 FROM events WHERE events.created_by = users.id)").
+				Limit(purgeBatchSize).
+				Pluck("id", &ids).Error
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+
+			if err := tx.Where("user_id IN ?", ids).Delete(&models.EventAttendee{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id IN ?", ids).Delete(&models.Notification{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id IN ?", ids).Delete(&models.EmailDelivery{}).Error; err != nil {
+				return err
+			}
+			err = tx.Model(&models.EventReview{}).Where("reviewer_id IN ?", ids).
+				Update("reviewer_id", nil).Error
+			if err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ?", ids).Delete(&models.User{}).Error
+		})
+		if err != nil {
+			logutil.FromContext(ctx).Error("failed to purge deleted users", "err", err, "purged", purged)
+			return purged, err
+		}
+
+		purged += len(ids)
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockRepository(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sql mock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	client, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return NewRepository(client), mock
+}
+
+func TestPurgeDeletedEvents(t *testing.T) {
+	// Test case 1: The dependent rows are deleted before the events
+	t.Run("SuccessfulPurgeWithDependentRows", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		before := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `events` WHERE deleted_at < ? LIMIT ? FOR UPDATE")).
+			WithArgs(before, purgeBatchSize).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `event_attendees` WHERE event_id IN (?,?)")).
+			WithArgs(3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notifications` WHERE event_id IN (?,?)")).
+			WithArgs(3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_deliveries` WHERE event_id IN (?,?)")).
+			WithArgs(3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `events` WHERE id IN (?,?)")).
+			WithArgs(3, 7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeDeletedEvents(context.Background(), before)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if purged != 2 {
+			t.Errorf("Expected 2 purged events, got %d", purged)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// Test case 2: Nothing to purge
+	t.Run("NothingToPurge", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		before := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `events`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeDeletedEvents(context.Background(), before)
+
+		if err != nil || purged != 0 {
+			t.Errorf("Expected nothing purged, got %d, %v", purged, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	// Test case 1: The reviews of a purged user are kept without the reviewer
+	t.Run("SuccessfulPurgeWithDependentRows", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		before := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE deleted_at < ? AND NOT EXISTS (SELECT 1 FROM events WHERE events.created_by = users.id) LIMIT ? FOR UPDATE")).
+			WithArgs(before, purgeBatchSize).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `event_attendees` WHERE user_id IN (?)")).
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notifications` WHERE user_id IN (?)")).
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_deliveries` WHERE user_id IN (?)")).
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `event_reviews` SET `reviewer_id`=? WHERE reviewer_id IN (?)")).
+			WithArgs(nil, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE id IN (?)")).
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeDeletedUsers(context.Background(), before)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if purged != 1 {
+			t.Errorf("Expected 1 purged user, got %d", purged)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// Test case 2: A failing batch is rolled back and reported
+	t.Run("ErrorPurging", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		before := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `event_attendees`")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		purged, err := repo.PurgeDeletedUsers(context.Background(), before)
+
+		if err == nil || purged != 0 {
+			t.Errorf("Expected an error and nothing purged, got %d, %v", purged, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
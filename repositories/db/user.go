@@ -1,59 +1,110 @@
 package db
 
 import (
+	"context"
+	"slices"
+	"time"
+
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func (repo *Repository) CreateUser(user *models.User) (*models.User, error) {
-	err := repo.client.Create(&user).Error
+func (repo *Repository) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	err := repo.client.WithContext(ctx).Create(&user).Error
 	return user, err
 }
 
-func (repo *Repository) ReadUserById(id int) (*models.User, error) {
+func (repo *Repository) ReadUserById(ctx context.Context, id int) (*models.User, error) {
 	var user models.User
-	if err := repo.client.Model(&models.User{}).Where("id = ?", id).First(&user).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (repo *Repository) ReadPaginatedUsers(limit, offset int) ([]*types.UserInfo, int, error) {
+func (repo *Repository) ReadPaginatedUsers(ctx context.Context, limit, offset int) ([]*types.UserInfo, int, error) {
 	var users []*types.UserInfo
 	var total int64
 
-	if err := repo.client.Model(&models.User{}).Count(&total).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := repo.client.Model(&models.User{}).Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Offset(offset).Limit(limit).Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return users, int(total), nil
 }
 
-func (repo *Repository) ReadUserByEmail(email string) (*models.User, error) {
+func (repo *Repository) CountUsers(ctx context.Context) (int, error) {
+	var total int64
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+// ReadUsersAfter returns up to limit users with an ID past the cursor, or
+// before it when the cursor is backward, ordered by ID.
+func (repo *Repository) ReadUsersAfter(ctx context.Context, cursor *types.Cursor, limit int) ([]*types.UserInfo, error) {
+	var users []*types.UserInfo
+	query := repo.client.WithContext(ctx).Model(&models.User{})
+
+	backward := cursor.IsBackward()
+	if cursor != nil && backward {
+		query = query.Where("id < ?", cursor.ID)
+	} else if cursor != nil {
+		query = query.Where("id > ?", cursor.ID)
+	}
+	err := query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: backward}).
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if backward {
+		slices.Reverse(users)
+	}
+	return users, nil
+}
+
+func (repo *Repository) ReadUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := repo.client.Model(&models.User{}).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (repo *Repository) UserCountByEmail(email string) (int, error) {
+// UserCountByEmail counts deleted users too, their email stays taken until
+// they're purged so they can still be restored.
+func (repo *Repository) UserCountByEmail(ctx context.Context, email string) (int, error) {
+	var total int64
+
+	if err := repo.client.WithContext(ctx).Unscoped().Model(&models.User{}).Where("email = ?", email).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+func (repo *Repository) UserCountByPhone(ctx context.Context, phone string) (int, error) {
 	var total int64
 
-	if err := repo.client.Model(&models.User{}).Where("email = ?", email).Count(&total).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Where("phone = ? AND phone_verified_at IS NOT NULL", phone).Count(&total).Error; err != nil {
 		return 0, err
 	}
 	return int(total), nil
 }
 
-func (repo *Repository) ReadPermissionsByRole(roleID int) ([]*models.Permission, error) {
+func (repo *Repository) ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error) {
 	var permissions []*models.Permission
 
-	if err := repo.client.Model(&models.RolePermission{}).
+	if err := repo.client.WithContext(ctx).Model(&models.RolePermission{}).
 		Select("permissions.*").
 		Joins("JOIN permissions ON role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id = ?", roleID).Find(&permissions).Error; err != nil {
@@ -62,33 +113,93 @@ func (repo *Repository) ReadPermissionsByRole(roleID int) ([]*models.Permission,
 	return permissions, nil
 }
 
-func (repo *Repository) UpdateUser(user *models.User) error {
+// UpdateUser updates the user while it has user.Version, 0 matches any version.
+func (repo *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	updUserMap := map[string]interface{}{
 		"first_name": user.FirstName,
 		"last_name":  user.LastName,
+		"version":    gorm.Expr("version + 1"),
+	}
+	qry := repo.client.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID)
+	if user.Version != 0 {
+		qry = qry.Where("version = ?", user.Version)
+	}
+	qry = qry.Updates(&updUserMap)
+	if qry.Error != nil {
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 && user.Version != 0 {
+		return errutil.ErrVersionConflict
+	}
+	return nil
+}
+
+func (repo *Repository) UpdateUserPhone(ctx context.Context, id int, phone string, verifiedAt time.Time) error {
+	updUserMap := map[string]interface{}{
+		"phone":             phone,
+		"phone_verified_at": verifiedAt,
+		"version":           gorm.Expr("version + 1"),
+	}
+	return repo.client.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(&updUserMap).Error
+}
+
+func (repo *Repository) UpdateNotificationChannels(ctx context.Context, id int, smsEnabled, pushEnabled bool, pushToken *string) error {
+	updUserMap := map[string]interface{}{
+		"sms_enabled":  smsEnabled,
+		"push_enabled": pushEnabled,
+		"push_token":   pushToken,
+		"version":      gorm.Expr("version + 1"),
 	}
-	return repo.client.Model(&models.User{}).
-		Where("id = ?", user.ID).
+	return repo.client.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", id).
 		Updates(&updUserMap).Error
 }
 
-func (repo *Repository) DeleteUser(id int) error {
-	if err := repo.client.Delete(&models.User{}, id).Error; err != nil {
-		return err
+// UpdateUserTimezone sets the preferred time zone of the user, nil clears it.
+func (repo *Repository) UpdateUserTimezone(ctx context.Context, id int, timezone *string) error {
+	qry := repo.client.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"timezone": timezone,
+			"version":  gorm.Expr("version + 1"),
+		})
+	if qry.Error != nil {
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteUser deletes the user when it has the version, 0 matches any version.
+func (repo *Repository) DeleteUser(ctx context.Context, id, version int) error {
+	qry := repo.client.WithContext(ctx).Where("id = ?", id)
+	if version != 0 {
+		qry = qry.Where("version = ?", version)
+	}
+	qry = qry.Delete(&models.User{})
+	if qry.Error != nil {
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 && version != 0 {
+		return errutil.ErrVersionConflict
 	}
 	return nil
 }
-func (repo *Repository) ReadUsers(ids []int) ([]models.User, error) {
+func (repo *Repository) ReadUsers(ctx context.Context, ids []int) ([]models.User, error) {
 	var users []models.User
-	if err := repo.client.Model(&models.User{}).Where("id IN (?)", ids).Find(&users).Error; err != nil {
+	if err := repo.client.WithContext(ctx).Model(&models.User{}).Where("id IN (?)", ids).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
 }
 
-func (repo *Repository) ListAttendees(filter *types.AttendeeFilter) ([]types.AttendeeResp, error) {
+func (repo *Repository) ListAttendees(ctx context.Context, filter *types.AttendeeFilter) ([]types.AttendeeResp, error) {
 	var users []types.AttendeeResp
-	query := repo.client.Model(&models.User{})
+	query := repo.client.WithContext(ctx).Model(&models.User{})
 	if filter != nil {
 		if filter.RoleID != 0 {
 			query = query.Where("role_id =  ?", filter.RoleID)
@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repo *Repository) ListVenues(ctx context.Context) ([]models.Venue, error) {
+	var venues []models.Venue
+	err := repo.client.WithContext(ctx).Preload("Rooms", func(db *gorm.DB) *gorm.DB {
+		return db.Order("rooms.name")
+	}).Order("name").Find(&venues).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list venues", "err", err)
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (repo *Repository) ReadVenue(ctx context.Context, id int) (*models.Venue, error) {
+	var venue models.Venue
+	qry := repo.client.WithContext(ctx).First(&venue, id)
+	if errors.Is(qry.Error, gorm.ErrRecordNotFound) {
+		return nil, errutil.ErrRecordNotFound
+	}
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to read venue", "err", qry.Error, "venue_id", id)
+		return nil, qry.Error
+	}
+	return &venue, nil
+}
+
+// VenueCountByName counts the venues named name other than excludeID.
+func (repo *Repository) VenueCountByName(ctx context.Context, name string, excludeID int) (int, error) {
+	var count int64
+	err := repo.client.WithContext(ctx).Model(&models.Venue{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count venues", "err", err)
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (repo *Repository) CreateVenue(ctx context.Context, venue *models.Venue) error {
+	if err := repo.client.WithContext(ctx).Create(venue).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to create venue", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *Repository) UpdateVenue(ctx context.Context, venue *models.Venue) error {
+	qry := repo.client.WithContext(ctx).Model(venue).Updates(map[string]interface{}{
+		"name":     venue.Name,
+		"address":  venue.Address,
+		"timezone": venue.Timezone,
+	})
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to update venue", "err", qry.Error, "venue_id", venue.ID)
+		return qry.Error
+	}
+	return nil
+}
+
+// DeleteVenue deletes the venue with its rooms, the events booked into them
+// are left without a room and their versions bumped.
+func (repo *Repository) DeleteVenue(ctx context.Context, id int) error {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		rooms := tx.Model(&models.Room{}).Select("id").Where("venue_id = ?", id)
+		err := tx.Unscoped().Model(&models.Event{}).Where("room_id IN (?)", rooms).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		qry := tx.Delete(&models.Venue{}, id)
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errutil.ErrRecordNotFound) {
+		logutil.FromContext(ctx).Error("failed to delete venue", "err", err, "venue_id", id)
+	}
+	return err
+}
+
+func (repo *Repository) ReadRoom(ctx context.Context, id int) (*models.Room, error) {
+	var room models.Room
+	qry := repo.client.WithContext(ctx).Preload("Venue").First(&room, id)
+	if errors.Is(qry.Error, gorm.ErrRecordNotFound) {
+		return nil, errutil.ErrRoomNotFound
+	}
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to read room", "err", qry.Error, "room_id", id)
+		return nil, qry.Error
+	}
+	return &room, nil
+}
+
+// RoomCountByName counts the rooms of the venue named name other than excludeID.
+func (repo *Repository) RoomCountByName(ctx context.Context, venueID int, name string, excludeID int) (int, error) {
+	var count int64
+	err := repo.client.WithContext(ctx).Model(&models.Room{}).
+		Where("venue_id = ? AND name = ? AND id <> ?", venueID, name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count rooms", "err", err)
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (repo *Repository) CreateRoom(ctx context.Context, room *models.Room) error {
+	if err := repo.client.WithContext(ctx).Omit(clause.Associations).Create(room).Error; err != nil {
+		logutil.FromContext(ctx).Error("failed to create room", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *Repository) UpdateRoom(ctx context.Context, room *models.Room) error {
+	qry := repo.client.WithContext(ctx).Model(room).Updates(map[string]interface{}{
+		"name":     room.Name,
+		"capacity": room.Capacity,
+	})
+	if qry.Error != nil {
+		logutil.FromContext(ctx).Error("failed to update room", "err", qry.Error, "room_id", room.ID)
+		return qry.Error
+	}
+	return nil
+}
+
+// DeleteRoom deletes the room, its events are left without one and their
+// versions bumped.
+func (repo *Repository) DeleteRoom(ctx context.Context, id int) error {
+	err := repo.client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Event{}).Where("room_id = ?", id).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+		qry := tx.Delete(&models.Room{}, id)
+		if qry.Error != nil {
+			return qry.Error
+		}
+		if qry.RowsAffected == 0 {
+			return errutil.ErrRoomNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errutil.ErrRoomNotFound) {
+		logutil.FromContext(ctx).Error("failed to delete room", "err", err, "room_id", id)
+	}
+	return err
+}
+
+// ListRoomBookings returns the events in the room that overlap [from, to).
+func (repo *Repository) ListRoomBookings(ctx context.Context, roomID int, from, to time.Time) ([]types.RoomBooking, error) {
+	bookings, err := roomBookings(repo.client, roomID, 0, from, to)
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list room bookings", "err", err, "room_id", roomID)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func roomBookings(tx *gorm.DB, roomID, exceptEventID int, from, to time.Time) ([]types.RoomBooking, error) {
+	var bookings []types.RoomBooking
+	err := tx.Model(&models.Event{}).
+		Select("id AS event_id, title, start_time, end_time").
+		// a cancelled event gives its room back
+		Where("room_id = ? AND id <> ? AND status <> ? AND start_time < ? AND end_time > ?", roomID, exceptEventID, consts.EventStatusCancelled, to, from).
+		Order("start_time, id").
+		Scan(&bookings).Error
+	return bookings, err
+}
+
+// checkRoomBooking fails unless the room exists, holds the attendee limit and
+// is free between start and end apart from the event itself. The room stays
+// locked until the transaction ends, so bookings of a room are made one at a
+// time and the check holds when the event is written.
+func checkRoomBooking(tx *gorm.DB, eventID int, roomID *int, start, end *time.Time, limit *int) error {
+	if roomID == nil {
+		return nil
+	}
+	var room models.Room
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&room, *roomID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errutil.ErrRoomNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// a limit of 0 or less is no limit, which no room can hold
+	if limit == nil || *limit <= 0 || *limit > room.Capacity {
+		return errutil.ErrRoomCapacityExceeded
+	}
+	if start == nil || end == nil {
+		return nil
+	}
+
+	// a locking read sees the bookings committed while waiting for the room
+	bookings, err := roomBookings(tx.Clauses(clause.Locking{Strength: "SHARE"}), *roomID, eventID, *start, *end)
+	if err != nil {
+		return err
+	}
+	if len(bookings) > 0 {
+		return &types.RoomConflictError{Bookings: bookings}
+	}
+	return nil
+}
+
+// bookingErrors are the errors of checkRoomBooking that are the caller's fault.
+var bookingErrors = []error{errutil.ErrRoomNotFound, errutil.ErrRoomCapacityExceeded, errutil.ErrRoomDoubleBooked}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+func TestCheckRoomBookingCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	tests := []struct {
+		name    string
+		limit   *int
+		wantErr error
+	}{
+		{name: "WithinCapacity", limit: limit(40)},
+		{name: "AboveCapacity", limit: limit(41), wantErr: errutil.ErrRoomCapacityExceeded},
+		{name: "NoLimit", wantErr: errutil.ErrRoomCapacityExceeded},
+		{name: "ZeroLimit", limit: limit(0), wantErr: errutil.ErrRoomCapacityExceeded},
+		{name: "NegativeLimit", limit: limit(-1), wantErr: errutil.ErrRoomCapacityExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			roomID := 2
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE `rooms`.`id` = ?")).
+				WithArgs(roomID, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(roomID, 40))
+
+			// without a time range only the capacity is checked
+			err := checkRoomBooking(repo.client, 0, &roomID, nil, nil, tt.limit)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
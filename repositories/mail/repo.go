@@ -2,12 +2,20 @@ package mail
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/tracing"
 	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Repository struct {
@@ -22,26 +30,78 @@ func NewRepository(client *http.Client, config *config.EmailConfig) *Repository
 	}
 }
 
-func (repo *Repository) SendEmail(payload *types.EmailPayload) error {
+func (repo *Repository) SendEmail(ctx context.Context, payload *types.EmailPayload) (messageID string, err error) {
+	ctx, span := tracing.Start(ctx, "mail.SendEmail",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("email.message_id", payload.MessageID)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	reqByte, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal email payload: %w", err)
+		return "", fmt.Errorf("failed to marshal email payload: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, repo.config.Url, bytes.NewBuffer(reqByte))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, repo.config.Url, bytes.NewBuffer(reqByte))
 	if err != nil {
-		return fmt.Errorf("failed to create email request: %w", err)
+		return "", fmt.Errorf("failed to create email request: %w", err)
 	}
 
 	res, err := repo.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("error sending email to %s: %w", payload.MailTo, err)
+		return "", fmt.Errorf("error sending email to %s: %w", payload.MailTo, err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError {
+		err = fmt.Errorf("%w: status code %d for recipient %s", errutil.ErrEmailProviderThrottled, res.StatusCode, payload.MailTo)
+		// only an explicit back off skips the retry budget, a provider that
+		// doesn't say when to come back is retried like any other failure
+		if wait := retryAfter(res.Header.Get("Retry-After")); wait > 0 {
+			return "", &errutil.RetryAfterError{Err: err, RetryAfter: wait}
+		}
+		return "", err
+	}
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("email service returned status code %d for recipient %s", res.StatusCode, payload.MailTo)
+		return "", fmt.Errorf("email service returned status code %d for recipient %s", res.StatusCode, payload.MailTo)
+	}
+
+	return providerMessageID(res), nil
+}
+
+// providerMessageID reads the message ID from the X-Message-Id header or the
+// "message_id"/"id" field of a JSON response body.
+func providerMessageID(res *http.Response) string {
+	if id := res.Header.Get("X-Message-Id"); id != "" {
+		return id
+	}
+
+	var body struct {
+		MessageID string `json:"message_id"`
+		ID        string `json:"id"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 64*1024)).Decode(&body); err != nil {
+		return ""
 	}
+	if body.MessageID != "" {
+		return body.MessageID
+	}
+	return body.ID
+}
 
-	return nil
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP
+// date. It returns 0 when the header is missing or invalid.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
 }
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+func TestSendEmailProviderErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		retryAfter     string
+		wantRetryAfter time.Duration
+	}{
+		{name: "ThrottledWithRetryAfter", status: http.StatusTooManyRequests, retryAfter: "30", wantRetryAfter: 30 * time.Second},
+		{name: "ThrottledWithoutRetryAfter", status: http.StatusTooManyRequests},
+		{name: "UnavailableWithRetryAfter", status: http.StatusServiceUnavailable, retryAfter: "30", wantRetryAfter: 30 * time.Second},
+		{name: "InternalError", status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			repo := NewRepository(srv.Client(), &config.EmailConfig{Url: srv.URL})
+			_, err := repo.SendEmail(context.Background(), &types.EmailPayload{MailTo: "user@example.com"})
+
+			if !errors.Is(err, errutil.ErrEmailProviderThrottled) {
+				t.Fatalf("Expected error ErrEmailProviderThrottled, got %v", err)
+			}
+			// only a back off the provider asked for skips the retry budget
+			var retryAfterErr *errutil.RetryAfterError
+			isRetryAfter := errors.As(err, &retryAfterErr)
+			if isRetryAfter != (tt.wantRetryAfter > 0) {
+				t.Fatalf("Expected RetryAfterError %v, got %v", tt.wantRetryAfter > 0, err)
+			}
+			if isRetryAfter && retryAfterErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("Expected retry after %s, got %s", tt.wantRetryAfter, retryAfterErr.RetryAfter)
+			}
+		})
+	}
+}
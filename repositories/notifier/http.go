@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	reqByte, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notifier payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqByte))
+	if err != nil {
+		return fmt.Errorf("failed to create notifier request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling notifier provider %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("notifier provider %s returned status code %d", url, res.StatusCode)
+	}
+
+	return nil
+}
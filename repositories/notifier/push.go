@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"net/http"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+type PushChannel struct {
+	client *http.Client
+	url    string
+}
+
+func NewPushChannel(client *http.Client, url string) *PushChannel {
+	return &PushChannel{
+		client: client,
+		url:    url,
+	}
+}
+
+func (ch *PushChannel) Name() string {
+	return consts.NotifierChannelPush
+}
+
+func (ch *PushChannel) Enabled(user *models.User) bool {
+	return user.PushEnabled && user.PushToken != nil && *user.PushToken != ""
+}
+
+func (ch *PushChannel) Send(user *models.User, msg *types.ChannelMessage) error {
+	if user.PushToken == nil || *user.PushToken == "" {
+		return errutil.ErrNoPushTokenIsSet
+	}
+	return postJSON(ch.client, ch.url, &types.PushPayload{
+		Token: *user.PushToken,
+		Title: msg.Title,
+		Body:  msg.Body,
+		Data:  msg.Data,
+	})
+}
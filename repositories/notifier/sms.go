@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"net/http"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+type SmsChannel struct {
+	client *http.Client
+	url    string
+}
+
+func NewSmsChannel(client *http.Client, url string) *SmsChannel {
+	return &SmsChannel{
+		client: client,
+		url:    url,
+	}
+}
+
+func (ch *SmsChannel) Name() string {
+	return consts.NotifierChannelSms
+}
+
+func (ch *SmsChannel) Enabled(user *models.User) bool {
+	return user.SmsEnabled && user.IsPhoneVerified()
+}
+
+func (ch *SmsChannel) Send(user *models.User, msg *types.ChannelMessage) error {
+	if user.Phone == nil {
+		return errutil.ErrNoPhoneNumberIsSet
+	}
+	return postJSON(ch.client, ch.url, &types.SmsPayload{
+		To:      *user.Phone,
+		Message: smsText(msg),
+	})
+}
+
+func smsText(msg *types.ChannelMessage) string {
+	if msg.Title == "" {
+		return msg.Body
+	}
+	return msg.Title + "\n" + msg.Body
+}
@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+// StubRecord is a single line written by the StubChannel.
+type StubRecord struct {
+	Channel string               `json:"channel"`
+	UserID  int                  `json:"user_id"`
+	Phone   *string              `json:"phone,omitempty"`
+	Token   *string              `json:"token,omitempty"`
+	Message types.ChannelMessage `json:"message"`
+	SentAt  time.Time            `json:"sent_at"`
+}
+
+// StubChannel wraps a real channel's opt-in rules but appends the messages to a
+// local file as JSON lines instead of calling the provider.
+type StubChannel struct {
+	channel optInRules
+	path    string
+	mu      *sync.Mutex
+}
+
+type optInRules interface {
+	Name() string
+	Enabled(user *models.User) bool
+}
+
+// stubFileLocks serializes writes of channels sharing the same stub file.
+var stubFileLocks sync.Map
+
+func NewStubChannel(channel optInRules, path string) *StubChannel {
+	mu, _ := stubFileLocks.LoadOrStore(path, &sync.Mutex{})
+	return &StubChannel{
+		channel: channel,
+		path:    path,
+		mu:      mu.(*sync.Mutex),
+	}
+}
+
+func (ch *StubChannel) Name() string {
+	return ch.channel.Name()
+}
+
+func (ch *StubChannel) Enabled(user *models.User) bool {
+	return ch.channel.Enabled(user)
+}
+
+func (ch *StubChannel) Send(user *models.User, msg *types.ChannelMessage) error {
+	line, err := json.Marshal(&StubRecord{
+		Channel: ch.Name(),
+		UserID:  user.ID,
+		Phone:   user.Phone,
+		Token:   user.PushToken,
+		Message: *msg,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if dir := filepath.Dir(ch.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(ch.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// ReadStubRecords returns every record written to the stub file so far.
+func ReadStubRecords(path string) ([]StubRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []StubRecord
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var record StubRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -9,20 +9,38 @@ import (
 )
 
 type Routes struct {
-	echo           *echo.Echo
-	eventCtrl      *controllers.EventController
-	userCtrl       *controllers.UserController
-	authCtrl       *controllers.AuthController
-	authMiddleware *m.AuthMiddleware
+	echo             *echo.Echo
+	eventCtrl        *controllers.EventController
+	userCtrl         *controllers.UserController
+	authCtrl         *controllers.AuthController
+	notificationCtrl *controllers.NotificationController
+	webhookCtrl      *controllers.WebhookController
+	taskAdminCtrl    *controllers.TaskAdminController
+	trashCtrl        *controllers.TrashController
+	taxonomyCtrl     *controllers.TaxonomyController
+	venueCtrl        *controllers.VenueController
+	healthCtrl       *controllers.HealthController
+	authMiddleware   *m.AuthMiddleware
+	rateLimit        *m.RateLimitMiddleware
+	idempotency      *m.IdempotencyMiddleware
 }
 
-func New(e *echo.Echo, eventCtrl *controllers.EventController, userCtrl *controllers.UserController, authCtrl *controllers.AuthController, authMiddleware *m.AuthMiddleware) *Routes {
+func New(e *echo.Echo, eventCtrl *controllers.EventController, userCtrl *controllers.UserController, authCtrl *controllers.AuthController, notificationCtrl *controllers.NotificationController, webhookCtrl *controllers.WebhookController, taskAdminCtrl *controllers.TaskAdminController, trashCtrl *controllers.TrashController, taxonomyCtrl *controllers.TaxonomyController, venueCtrl *controllers.VenueController, healthCtrl *controllers.HealthController, authMiddleware *m.AuthMiddleware, rateLimit *m.RateLimitMiddleware, idempotency *m.IdempotencyMiddleware) *Routes {
 	return &Routes{
-		echo:           e,
-		eventCtrl:      eventCtrl,
-		userCtrl:       userCtrl,
-		authCtrl:       authCtrl,
-		authMiddleware: authMiddleware,
+		echo:             e,
+		eventCtrl:        eventCtrl,
+		userCtrl:         userCtrl,
+		authCtrl:         authCtrl,
+		notificationCtrl: notificationCtrl,
+		webhookCtrl:      webhookCtrl,
+		taskAdminCtrl:    taskAdminCtrl,
+		trashCtrl:        trashCtrl,
+		taxonomyCtrl:     taxonomyCtrl,
+		venueCtrl:        venueCtrl,
+		healthCtrl:       healthCtrl,
+		authMiddleware:   authMiddleware,
+		rateLimit:        rateLimit,
+		idempotency:      idempotency,
 	}
 }
 
@@ -30,30 +48,98 @@ func (r *Routes) Init() {
 	e := r.echo
 	m.Init(e)
 	// APM routes
-	e.GET("/metrics", echoprometheus.NewHandler())
+	Metrics(e)
+	Health(e, r.healthCtrl)
+
+	auth := r.authMiddleware.Authenticate
+	limit := r.rateLimit.Limit
+	limitUser := r.rateLimit.LimitUser
+	idempotent := r.idempotency.Handle
 
 	g := e.Group("/v1")
 
-	g.POST("/events", r.eventCtrl.CreateEvent, r.authMiddleware.Authenticate(consts.PermissionEventCreate))
-	g.GET("/events", r.eventCtrl.ListEvents, r.authMiddleware.Authenticate(consts.PermissionEventList))
-	g.GET("/events/public", r.eventCtrl.ListPublicEvents)
-	g.GET("/events/:id", r.eventCtrl.ReadEventByID, r.authMiddleware.Authenticate(consts.PermissionEventFetch))
-	g.PUT("/events/:id", r.eventCtrl.UpdateEvent, r.authMiddleware.Authenticate(consts.PermissionEventUpdate))
-	g.DELETE("/events/:id", r.eventCtrl.DeleteEvent, r.authMiddleware.Authenticate(consts.PermissionEventDelete))
-	g.POST("/events/:id/rsvp", r.eventCtrl.Rsvp, r.authMiddleware.Authenticate(""))
+	g.POST("/events", r.eventCtrl.CreateEvent, limit("client"), auth(consts.PermissionEventCreate), limitUser("events"), idempotent)
+	g.GET("/events", r.eventCtrl.ListEvents, limit("client"), auth(consts.PermissionEventList), limitUser("events"))
+	g.GET("/events/public", r.eventCtrl.ListPublicEvents, limit("public"))
+	g.GET("/events/:id", r.eventCtrl.ReadEventByID, limit("client"), auth(consts.PermissionEventFetch), limitUser("events"))
+	g.PUT("/events/:id", r.eventCtrl.UpdateEvent, limit("client"), auth(consts.PermissionEventUpdate), limitUser("events"))
+	g.PATCH("/events/:id", r.eventCtrl.PatchEvent, limit("client"), auth(consts.PermissionEventUpdate), limitUser("events"))
+	g.DELETE("/events/:id", r.eventCtrl.DeleteEvent, limit("client"), auth(consts.PermissionEventDelete), limitUser("events"))
+	g.POST("/events/:id/rsvp", r.eventCtrl.Rsvp, limit("client"), auth(""), limitUser("events"), idempotent)
+	g.GET("/events/:id/attendees", r.eventCtrl.ListEventAttendees, limit("client"), auth(consts.PermissionEventFetch), limitUser("events"))
+	g.GET("/events/:id/invitations", r.eventCtrl.InvitationProgress, limit("client"), auth(consts.PermissionEventFetch), limitUser("events"))
+	g.POST("/events/:id/publish", r.eventCtrl.PublishEvent, limit("client"), auth(consts.PermissionEventUpdate), limitUser("events"))
+	g.POST("/events/:id/complete", r.eventCtrl.CompleteEvent, limit("client"), auth(consts.PermissionEventUpdate), limitUser("events"))
+	g.POST("/events/:id/cancel", r.eventCtrl.CancelEvent, limit("client"), auth(consts.PermissionEventUpdate), limitUser("events"))
+	g.POST("/events/:id/approve", r.eventCtrl.ApproveEvent, limit("client"), auth(consts.PermissionEventApprove), limitUser("events"))
+	g.POST("/events/:id/reject", r.eventCtrl.RejectEvent, limit("client"), auth(consts.PermissionEventApprove), limitUser("events"))
+	g.GET("/events/:id/reviews", r.eventCtrl.ListEventReviews, limit("client"), auth(consts.PermissionEventFetch), limitUser("events"))
+
+	g.GET("/categories", r.taxonomyCtrl.ListCategories, limit("public"))
+	g.POST("/categories", r.taxonomyCtrl.CreateCategory, limit("client"), auth(consts.PermissionTaxonomyManage), limitUser("admin"))
+	g.PUT("/categories/:id", r.taxonomyCtrl.UpdateCategory, limit("client"), auth(consts.PermissionTaxonomyManage), limitUser("admin"))
+	g.DELETE("/categories/:id", r.taxonomyCtrl.DeleteCategory, limit("client"), auth(consts.PermissionTaxonomyManage), limitUser("admin"))
+	g.GET("/tags", r.taxonomyCtrl.ListTags, limit("public"))
+	g.DELETE("/tags/:id", r.taxonomyCtrl.DeleteTag, limit("client"), auth(consts.PermissionTaxonomyManage), limitUser("admin"))
+
+	g.GET("/venues", r.venueCtrl.ListVenues, limit("public"))
+	g.POST("/venues", r.venueCtrl.CreateVenue, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.PUT("/venues/:id", r.venueCtrl.UpdateVenue, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.DELETE("/venues/:id", r.venueCtrl.DeleteVenue, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.POST("/rooms", r.venueCtrl.CreateRoom, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.PUT("/rooms/:id", r.venueCtrl.UpdateRoom, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.DELETE("/rooms/:id", r.venueCtrl.DeleteRoom, limit("client"), auth(consts.PermissionVenueManage), limitUser("admin"))
+	g.GET("/rooms/:id/availability", r.venueCtrl.RoomAvailability, limit("client"), auth(consts.PermissionEventCreate), limitUser("events"))
 
 	users := g.Group("/users")
-	users.POST("/signup", r.userCtrl.Signup)
-	users.GET("/profile", r.userCtrl.Profile, r.authMiddleware.Authenticate(""))
-	users.POST("", r.userCtrl.CreateUser, r.authMiddleware.Authenticate(consts.PermissionUserCreate))
-	users.GET("", r.userCtrl.ListUsers, r.authMiddleware.Authenticate(consts.PermissionUserList))
-	users.GET("/:id", r.userCtrl.ReadUser, r.authMiddleware.Authenticate(consts.PermissionUserFetch))
-	users.PUT("/:id", r.userCtrl.UpdateUser, r.authMiddleware.Authenticate(consts.PermissionUserUpdate))
-	users.DELETE("/:id", r.userCtrl.DeleteUser, r.authMiddleware.Authenticate(consts.PermissionUserDelete))
-	users.GET("/attendees", r.userCtrl.ListAttendees, r.authMiddleware.Authenticate(consts.PermissionListAttendee))
-
-	auth := g.Group("/auth")
-	auth.POST("/login", r.authCtrl.Login)
-	auth.POST("/logout", r.authCtrl.Logout, r.authMiddleware.Authenticate(""))
+	users.POST("/signup", r.userCtrl.Signup, limit("auth"))
+	users.GET("/profile", r.userCtrl.Profile, limit("client"), auth(""), limitUser("users"))
+	users.PUT("/profile/phone", r.userCtrl.UpdatePhone, limit("client"), auth(""), limitUser("users"))
+	users.POST("/profile/phone/verify", r.userCtrl.VerifyPhone, limit("client"), auth(""), limitUser("users"))
+	users.PUT("/profile/notification-channels", r.userCtrl.UpdateNotificationChannels, limit("client"), auth(""), limitUser("users"))
+	users.PUT("/profile/timezone", r.userCtrl.UpdateTimezone, limit("client"), auth(""), limitUser("users"))
+	users.POST("", r.userCtrl.CreateUser, limit("client"), auth(consts.PermissionUserCreate), limitUser("users"), idempotent)
+	users.GET("", r.userCtrl.ListUsers, limit("client"), auth(consts.PermissionUserList), limitUser("users"))
+	users.GET("/:id", r.userCtrl.ReadUser, limit("client"), auth(consts.PermissionUserFetch), limitUser("users"))
+	users.PUT("/:id", r.userCtrl.UpdateUser, limit("client"), auth(consts.PermissionUserUpdate), limitUser("users"))
+	users.DELETE("/:id", r.userCtrl.DeleteUser, limit("client"), auth(consts.PermissionUserDelete), limitUser("users"))
+	users.GET("/attendees", r.userCtrl.ListAttendees, limit("client"), auth(consts.PermissionListAttendee), limitUser("users"))
+
+	authGroup := g.Group("/auth")
+	authGroup.POST("/login", r.authCtrl.Login, limit("auth"))
+	authGroup.POST("/logout", r.authCtrl.Logout, limit("client"), auth(""), limitUser("auth"))
+
+	webhooks := g.Group("/webhooks")
+	webhooks.POST("/email", r.webhookCtrl.EmailDeliveryEvent)
+
+	notifications := g.Group("/notifications", limit("client"), auth(""), limitUser("notifications"))
+	notifications.GET("", r.notificationCtrl.ListNotifications)
+	notifications.GET("/stream", r.notificationCtrl.StreamNotifications)
+	notifications.PUT("/read", r.notificationCtrl.MarkAllNotificationsRead)
+	notifications.PUT("/:id/read", r.notificationCtrl.MarkNotificationRead)
+
+	queues := g.Group("/admin/queues", limit("client"), auth(consts.PermissionTaskManage), limitUser("admin"))
+	queues.GET("", r.taskAdminCtrl.ListQueues)
+	queues.GET("/:queue/tasks", r.taskAdminCtrl.ListTasks)
+	queues.POST("/:queue/tasks/:action", r.taskAdminCtrl.ApplyTaskAction, idempotent)
+	queues.POST("/:queue/pause", r.taskAdminCtrl.PauseQueue)
+	queues.POST("/:queue/unpause", r.taskAdminCtrl.UnpauseQueue)
+
+	trash := g.Group("/admin/trash", limit("client"), auth(consts.PermissionTrashManage), limitUser("admin"))
+	trash.GET("/events", r.trashCtrl.ListEvents)
+	trash.POST("/events/:id/restore", r.trashCtrl.RestoreEvent)
+	trash.GET("/users", r.trashCtrl.ListUsers)
+	trash.POST("/users/:id/restore", r.trashCtrl.RestoreUser)
+
+}
+
+// Metrics exposes the Prometheus metrics of the process.
+func Metrics(e *echo.Echo) {
+	e.GET("/metrics", echoprometheus.NewHandler())
+}
 
+// Health registers the liveness and readiness probes.
+func Health(e *echo.Echo, healthCtrl *controllers.HealthController) {
+	e.GET("/healthz", healthCtrl.Healthz)
+	e.GET("/readyz", healthCtrl.Readyz)
 }
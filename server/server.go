@@ -2,46 +2,39 @@ package server
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"net/http"
-	"os"
-	"os/signal"
-	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/vivasoft-ltd/go-ems/config"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
 )
 
 type Server struct {
 	echo *echo.Echo
+	port string
 }
 
-func (s *Server) Start() {
-	e := s.echo
-	// Start server in a goroutine
-	go func() {
-		if err := e.Start(":" + config.App().Port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal(err)
-		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, os.Kill)
-	<-quit
-
-	logger.Info("Shutting down server...")
+// Start serves HTTP until Shutdown is called.
+func (s *Server) Start() error {
+	if err := s.echo.Start(":" + s.port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+// Shutdown stops accepting connections and waits for the in-flight requests
+// until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	slog.Info("shutting down server...")
 
-	if err := e.Shutdown(ctx); err != nil {
-		e.Logger.Fatal("Server shutdown with error: %v", err)
+	if err := s.echo.Shutdown(ctx); err != nil {
+		return err
 	}
 
-	logger.Info("Server exited gracefully")
+	slog.Info("server exited gracefully")
+	return nil
 }
 
-func New(echo *echo.Echo) *Server {
-	return &Server{echo: echo}
+func New(echo *echo.Echo, port string) *Server {
+	return &Server{echo: echo, port: port}
 }
@@ -1,10 +1,12 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/consts"
@@ -12,14 +14,19 @@ import (
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
 )
 
 type AsynqService struct {
-	config    *config.AsynqConfig
-	asynqRepo domain.AsynqRepository
-	userRepo  domain.UserRepository
-	eventRepo domain.EventRepository
+	config          *config.AsynqConfig
+	asynqRepo       domain.AsynqRepository
+	userRepo        domain.UserRepository
+	eventRepo       domain.EventRepository
+	notificationSvc domain.NotificationService
+	notifierSvc     domain.NotifierService
+	deliveryRepo    domain.EmailDeliveryRepository
+	batchRepo       domain.InvitationBatchRepository
 }
 
 func NewAsynqService(
@@ -27,164 +34,669 @@ func NewAsynqService(
 	asynqRepo domain.AsynqRepository,
 	userRepo domain.UserRepository,
 	eventRepo domain.EventRepository,
+	notificationSvc domain.NotificationService,
+	notifierSvc domain.NotifierService,
+	deliveryRepo domain.EmailDeliveryRepository,
+	batchRepo domain.InvitationBatchRepository,
 ) *AsynqService {
 	return &AsynqService{
-		config:    config,
-		asynqRepo: asynqRepo,
-		userRepo:  userRepo,
-		eventRepo: eventRepo,
+		config:          config,
+		asynqRepo:       asynqRepo,
+		userRepo:        userRepo,
+		eventRepo:       eventRepo,
+		notificationSvc: notificationSvc,
+		notifierSvc:     notifierSvc,
+		deliveryRepo:    deliveryRepo,
+		batchRepo:       batchRepo,
 	}
 }
 
-func (svc *AsynqService) CreateEmailInvitationTasks(userIds []int, event *models.Event) error {
-	users, err := svc.userRepo.ReadUsers(userIds)
-	if errors.Is(err, errutil.ErrUserNotFound) {
-		logger.Error(fmt.Sprintf("SKIPPING: No accepted event attendees found for event: %s", event.Title))
+// CreateInvitationBatchTask records the invitation batch of the event and
+// enqueues the task that fans the invitation emails out in the background.
+func (svc *AsynqService) CreateInvitationBatchTask(ctx context.Context, event *models.Event) error {
+	total, err := svc.eventRepo.GetEventAttendeesCount(ctx, event.ID)
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count invitees", "err", err, "event_id", event.ID)
+		return err
+	}
+	if total == 0 {
+		logutil.FromContext(ctx).Info("skipping invitations, no invitees found", "event_id", event.ID)
 		return nil
 	}
 
+	batch := &models.InvitationBatch{
+		EventID: event.ID,
+		Status:  consts.InvitationBatchStatusPending,
+		Total:   total,
+	}
+	if err := svc.batchRepo.CreateInvitationBatch(ctx, batch); err != nil {
+		return err
+	}
+
+	task, err := svc.asynqRepo.CreateTask(ctx, types.AsynqTaskTypeInvitationBatch, types.InvitationBatchPayload{EventID: event.ID})
 	if err != nil {
-		logger.Error(fmt.Sprintf("err: [%v] occurred while reading users for creating email invitation tasks of event id: %v", err, event.ID))
+		logutil.FromContext(ctx).Error("failed to create invitation batch task", "err", err, "event_id", event.ID)
 		return err
 	}
 
-	for _, user := range users {
-		task, err := svc.createEmailInvitationTask(user, event)
+	taskID := fmt.Sprintf("%s_event:%d", types.AsynqTaskTypeInvitationBatch, event.ID)
+	customOpts := &types.AsynqOption{
+		Queue:  svc.config.TaskQueue(types.AsynqTaskTypeInvitationBatch.String()),
+		TaskID: taskID,
+		Retry:  svc.config.InvitationBatchTaskRetryCount,
+	}
+	if _, err := svc.enqueueTask(ctx, task, customOpts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ProcessInvitationBatch pages through the invitees of the event and enqueues an
+// invitation email for each of them. The progress is saved after every page so a
+// retried batch resumes where it stopped, and the deterministic child task IDs
+// keep a repeated page from emailing anyone twice.
+func (svc *AsynqService) ProcessInvitationBatch(ctx context.Context, eventID int) error {
+	batch, err := svc.batchRepo.ReadInvitationBatch(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if batch.Status == consts.InvitationBatchStatusCompleted {
+		return nil
+	}
+
+	event, err := svc.eventRepo.ReadEventByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.Status == consts.EventStatusCancelled {
+		logutil.FromContext(ctx).Info("skipping invitations of cancelled event", "event_id", eventID)
+		return svc.batchRepo.UpdateInvitationBatch(ctx, eventID, map[string]interface{}{
+			"status":      consts.InvitationBatchStatusCompleted,
+			"finished_at": time.Now(),
+		})
+	}
+	// invitees are paged below, keep them out of every email payload
+	event.Attendees = nil
+
+	updates := map[string]interface{}{
+		"status": consts.InvitationBatchStatusRunning,
+		"error":  nil,
+	}
+	if batch.StartedAt == nil {
+		updates["started_at"] = time.Now()
+	}
+	if err := svc.batchRepo.UpdateInvitationBatch(ctx, eventID, updates); err != nil {
+		return err
+	}
+
+	pageSize := svc.config.InvitationBatchSize
+	if pageSize <= 0 {
+		pageSize = consts.DefaultInvitationBatchSize
+	}
+
+	cursor := batch.LastUserID
+	for {
+		users, err := svc.eventRepo.ListEventInvitees(ctx, eventID, cursor, pageSize)
+		if err != nil {
+			return svc.recordInvitationBatchError(ctx, eventID, err)
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		enqueued, skipped, failed := svc.enqueueInvitationPage(ctx, users, event)
+		cursor = users[len(users)-1].ID
+
+		err = svc.batchRepo.UpdateInvitationBatch(ctx, eventID, map[string]interface{}{
+			"last_user_id": cursor,
+			"enqueued":     gorm.Expr("enqueued + ?", enqueued),
+			"skipped":      gorm.Expr("skipped + ?", skipped),
+			"failed":       gorm.Expr("failed + ?", failed),
+		})
 		if err != nil {
-			logger.Error(fmt.Sprintf("err: [%v] occurred while creating email invitation task for user: %v", err, user.Email))
+			logutil.FromContext(ctx).Error("failed to save invitation batch progress", "err", err, "event_id", eventID)
 			return err
 		}
 
+		// the event may be cancelled while the batch runs
+		status, err := svc.eventRepo.ReadEventStatus(ctx, eventID)
+		if err != nil {
+			return svc.recordInvitationBatchError(ctx, eventID, err)
+		}
+		if status == consts.EventStatusCancelled {
+			logutil.FromContext(ctx).Info("stopping invitations of cancelled event", "event_id", eventID)
+			break
+		}
+	}
+
+	err = svc.batchRepo.UpdateInvitationBatch(ctx, eventID, map[string]interface{}{
+		"status":      consts.InvitationBatchStatusCompleted,
+		"finished_at": time.Now(),
+	})
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to complete invitation batch", "err", err, "event_id", eventID)
+	}
+	return err
+}
+
+// recordInvitationBatchError saves the error on the batch and returns it, joined
+// with the error of saving it when that fails too.
+func (svc *AsynqService) recordInvitationBatchError(ctx context.Context, eventID int, err error) error {
+	if updateErr := svc.batchRepo.UpdateInvitationBatch(ctx, eventID, map[string]interface{}{"error": err.Error()}); updateErr != nil {
+		logutil.FromContext(ctx).Error("failed to record invitation batch error", "err", updateErr, "event_id", eventID, "batch_err", err)
+		return errors.Join(err, updateErr)
+	}
+	return err
+}
+
+// InviteUsers enqueues the invitation emails of users added to an existing
+// event, which have no batch of their own.
+func (svc *AsynqService) InviteUsers(ctx context.Context, event *models.Event, users []models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	// keep the invitees out of every email payload
+	invitedEvent := *event
+	invitedEvent.Attendees = nil
+	if _, _, failed := svc.enqueueInvitationPage(ctx, users, &invitedEvent); failed > 0 {
+		return fmt.Errorf("failed to enqueue %d of %d invitations", failed, len(users))
+	}
+	return nil
+}
+
+// enqueueInvitationPage enqueues the invitation emails of one page of invitees.
+// A failure is counted against the invitee and doesn't stop the rest of the page.
+func (svc *AsynqService) enqueueInvitationPage(ctx context.Context, users []models.User, event *models.Event) (enqueued, skipped, failed int) {
+	log := logutil.FromContext(ctx)
+	notifications := make([]*models.Notification, 0, len(users))
+	defer func() { svc.notify(ctx, notifications) }()
+
+	for _, user := range users {
+		task, messageID, err := svc.createEmailInvitationTask(ctx, user, event)
+		if err != nil {
+			log.Error("failed to create email invitation task", "err", err, "event_id", event.ID, "user_id", user.ID)
+			failed++
+			continue
+		}
+
 		taskID := fmt.Sprintf("%s_user:%d_event:%d", types.AsynqTaskTypeInvitationEmail, user.ID, event.ID)
 		customOpts := &types.AsynqOption{
-			Queue:        svc.config.Queue,
+			Queue:        svc.config.TaskQueue(types.AsynqTaskTypeInvitationEmail.String()),
 			TaskID:       taskID,
 			DelaySeconds: svc.config.EmailInvitationTaskDelay,
 			Retry:        svc.config.EmailInvitationTaskRetryCount,
 		}
-		_, err = svc.enqueueTask(task, customOpts)
+		_, err = svc.asynqRepo.EnqueueTask(ctx, task, customOpts)
+		if errutil.Exists(err, []error{asynq.ErrTaskIDConflict, asynq.ErrDuplicateTask}) {
+			// already enqueued by an earlier run
+			svc.discardEmailDelivery(ctx, messageID)
+			skipped++
+			continue
+		}
 		if err != nil {
-			return err
+			log.Error("failed to enqueue task", "err", err, "task_id", taskID)
+			svc.failEmailDelivery(ctx, messageID, err)
+			failed++
+			continue
 		}
-		logger.Info(fmt.Sprintf("enqueued email invitation task for user [%s] successfully", user.Email))
+
+		enqueued++
+		notifications = append(notifications, invitationNotification(user, event))
+	}
+
+	log.Info("enqueued email invitation tasks", "event_id", event.ID, "enqueued", enqueued, "skipped", skipped, "failed", failed)
+	return
+}
+
+// CreatePurgeTrashTask enqueues the purge of what was deleted before the cutoff.
+// The task ID is derived from the cutoff, so every worker scheduling the same
+// purge enqueues it once.
+func (svc *AsynqService) CreatePurgeTrashTask(ctx context.Context, before time.Time) error {
+	log := logutil.FromContext(ctx)
+	task, err := svc.asynqRepo.CreateTask(ctx, types.AsynqTaskTypePurgeTrash, types.PurgeTrashPayload{Before: before})
+	if err != nil {
+		log.Error("failed to create purge trash task", "err", err)
+		return err
 	}
 
+	customOpts := &types.AsynqOption{
+		Queue:  svc.config.TaskQueue(types.AsynqTaskTypePurgeTrash.String()),
+		TaskID: fmt.Sprintf("%s_before:%d", types.AsynqTaskTypePurgeTrash, before.Unix()),
+		Retry:  svc.config.RetryCount,
+	}
+	_, err = svc.asynqRepo.EnqueueTask(ctx, task, customOpts)
+	if errutil.Exists(err, []error{asynq.ErrTaskIDConflict, asynq.ErrDuplicateTask}) {
+		return nil
+	}
+	if err != nil {
+		log.Error("failed to enqueue task", "err", err, "task_id", customOpts.TaskID)
+		return err
+	}
 	return nil
 }
 
-func (svc *AsynqService) CreateEventReminderTask(event *models.Event) error {
+// ReadInvitationProgress reports the fan-out progress of the event's invitations
+// together with the delivery state of the invitation emails.
+func (svc *AsynqService) ReadInvitationProgress(ctx context.Context, eventID int) (*types.InvitationProgressResp, error) {
+	batch, err := svc.batchRepo.ReadInvitationBatch(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := svc.deliveryRepo.CountEmailDeliveriesByStatus(ctx, eventID, types.AsynqTaskTypeInvitationEmail.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.InvitationProgressResp{
+		InvitationBatch: *batch,
+		Deliveries:      deliveries,
+	}, nil
+}
+
+func (svc *AsynqService) CreateEventReminderTask(ctx context.Context, event *models.Event) error {
 	eventStartTime := event.StartTime.UTC()
 	reminderTime := eventStartTime.Add(-consts.EventReminderInterval)
 	now := time.Now().UTC()
+	log := logutil.FromContext(ctx).With("event_id", event.ID)
 
 	if reminderTime.Before(now) {
-		logger.Info("Reminder time is in the past, skipping event reminder email for event: ", event.Title)
+		log.Info("skipping event reminder, reminder time is in the past")
 		return errutil.ErrEventReminderEmailNotEnqueued
 	}
 
-	logger.Info("Enqueuing event reminder email for event: ", event.Title)
-	logger.Info(fmt.Sprintf("Reminder trigger time: %v, event start time: %v", reminderTime.Format(time.RFC3339), eventStartTime.Format(time.RFC3339)))
-
-	timeLeftToSendReminderEmail := time.Duration(reminderTime.Sub(now).Seconds())
+	log.Info("enqueuing event reminder", "reminder_time", reminderTime.Format(time.RFC3339), "start_time", eventStartTime.Format(time.RFC3339))
 
+	// the reminder is due at an instant, not after a wall clock delay, so a DST
+	// change before the event doesn't move it
 	taskID := fmt.Sprintf("%s_event:%d", types.AsynqTaskTypeEventReminder, event.ID)
 	customOpts := &types.AsynqOption{
-		Queue:        svc.config.Queue,
-		TaskID:       taskID,
-		DelaySeconds: timeLeftToSendReminderEmail,
-		Retry:        svc.config.EventReminderTaskRetryCount,
+		Queue:     svc.config.TaskQueue(types.AsynqTaskTypeEventReminder.String()),
+		TaskID:    taskID,
+		ProcessAt: reminderTime,
+		Retry:     svc.config.EventReminderTaskRetryCount,
 	}
 
-	task, err := svc.asynqRepo.CreateTask(types.AsynqTaskTypeEventReminder, event)
+	task, err := svc.asynqRepo.CreateTask(ctx, types.AsynqTaskTypeEventReminder, event)
 	if err != nil {
-		logger.Error(fmt.Sprintf("error: [%v] occurred while creating event reminder email task for event id: %d", err, event.ID))
+		log.Error("failed to create event reminder task", "err", err)
 		return err
 	}
 
-	_, err = svc.enqueueTask(task, customOpts)
+	_, err = svc.enqueueTask(ctx, task, customOpts)
 	if err != nil {
-		logger.Error(fmt.Sprintf("error: [%v] occurred while enqueuing event reminder email task for event id: %d", err, event.ID))
+		log.Error("failed to enqueue event reminder task", "err", err)
 		return err
 	}
 	return nil
 }
 
-func (svc *AsynqService) CreateEventReminderEmailTasks(event *models.Event) error {
-	eventAttendees, err := svc.eventRepo.GetAcceptedEventAttendees(event.ID)
+// CreateEventReminderEmailTasks sends the reminders of the event scheduled by
+// CreateEventReminderTask. The event is read again, so the reminders show the
+// event as it is now rather than when they were scheduled.
+func (svc *AsynqService) CreateEventReminderEmailTasks(ctx context.Context, scheduled *models.Event) error {
+	log := logutil.FromContext(ctx).With("event_id", scheduled.ID)
+
+	// the reminder may have been due before the cancellation dequeued it
+	event, err := svc.eventRepo.ReadEventByID(ctx, scheduled.ID)
+	if errors.Is(err, errutil.ErrRecordNotFound) || (err == nil && event.Status == consts.EventStatusCancelled) {
+		log.Info("skipping event reminder emails, the event is cancelled or deleted")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	eventAttendees, err := svc.eventRepo.GetAcceptedEventAttendees(ctx, event.ID)
 	if errors.Is(err, errutil.ErrUserNotFound) {
-		logger.Error(fmt.Sprintf("SKIPPING: No accepted event attendees found for event: %s", event.Title))
+		log.Info("skipping event reminder emails, no accepted attendees found")
 		return nil
 	}
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to get accepted event attendees for event: %s , err: %v", event.Title, err))
+		log.Error("failed to get accepted event attendees", "err", err)
 		return err
 	}
 
+	notifications := make([]*models.Notification, 0, len(eventAttendees))
+	defer func() { svc.notify(ctx, notifications) }()
+
 	for _, attendee := range eventAttendees {
-		task, err := svc.createEventReminderEmailTask(attendee.User, event)
+		task, messageID, err := svc.createEventReminderEmailTask(ctx, attendee.User, event)
 		if err != nil {
-			logger.Error(fmt.Sprintf("err: [%v] occurred while creating event reminder email task for user: %v", err, attendee.User.Email))
+			log.Error("failed to create event reminder email task", "err", err, "user_id", attendee.User.ID)
 			return err
 		}
 		taskID := fmt.Sprintf("%s_user:%d_event:%d", types.AsynqTaskTypeEventReminderEmail, attendee.User.ID, event.ID)
 		customOpts := &types.AsynqOption{
-			Queue:        svc.config.Queue,
+			Queue:        svc.config.TaskQueue(types.AsynqTaskTypeEventReminderEmail.String()),
 			TaskID:       taskID,
 			DelaySeconds: svc.config.EventReminderEmailTaskDelay,
 			Retry:        svc.config.EventReminderEmailTaskRetryCount,
 		}
-		_, err = svc.enqueueTask(task, customOpts)
+		enqueuedID, err := svc.enqueueTask(ctx, task, customOpts)
 		if err != nil {
+			svc.failEmailDelivery(ctx, messageID, err)
 			return err
 		}
-		logger.Info(fmt.Sprintf("enqueued event reminder email task for user [%s] successfully", attendee.User.Email))
+		if enqueuedID == "" {
+			svc.discardEmailDelivery(ctx, messageID)
+			continue
+		}
+		log.Info("enqueued event reminder email task", "user_id", attendee.User.ID)
+		notifications = append(notifications, reminderNotification(attendee.User, event))
+
+		svc.createChannelReminderTasks(ctx, attendee.User, event)
+	}
+	return nil
+}
+
+// CreateEventCancellationTask enqueues the task that tells everyone of the
+// cancellation of the event in the background.
+func (svc *AsynqService) CreateEventCancellationTask(ctx context.Context, event *models.Event) error {
+	task, err := svc.asynqRepo.CreateTask(ctx, types.AsynqTaskTypeEventCancellation, types.EventCancellationPayload{EventID: event.ID})
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to create event cancellation task", "err", err, "event_id", event.ID)
+		return err
+	}
+
+	customOpts := &types.AsynqOption{
+		Queue:  svc.config.TaskQueue(types.AsynqTaskTypeEventCancellation.String()),
+		TaskID: fmt.Sprintf("%s_event:%d", types.AsynqTaskTypeEventCancellation, event.ID),
+		Retry:  svc.config.EventCancellationTaskRetryCount,
+	}
+	if _, err := svc.enqueueTask(ctx, task, customOpts); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (svc *AsynqService) createEmailInvitationTask(user models.User, event *models.Event) (*asynq.Task, error) {
+// CreateEventCancellationTasks dequeues the pending reminders of a cancelled
+// event and enqueues the cancellation emails of everyone invited or accepted.
+func (svc *AsynqService) CreateEventCancellationTasks(ctx context.Context, eventID int) error {
+	log := logutil.FromContext(ctx).With("event_id", eventID)
+
+	event, err := svc.eventRepo.ReadEventByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	// the users are paged below, keep them out of every email payload
+	event.Attendees = nil
+
+	svc.dequeueTask(ctx, types.AsynqTaskTypeEventReminder, fmt.Sprintf("%s_event:%d", types.AsynqTaskTypeEventReminder, event.ID))
+
+	pageSize := svc.config.InvitationBatchSize
+	if pageSize <= 0 {
+		pageSize = consts.DefaultInvitationBatchSize
+	}
+	statuses := []int{consts.StatusInvited, consts.StatusAccepted}
+
+	cursor := 0
+	for {
+		users, err := svc.eventRepo.ListEventUsersByStatus(ctx, event.ID, statuses, cursor, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			break
+		}
+		cursor = users[len(users)-1].ID
+
+		notifications := make([]*models.Notification, 0, len(users))
+		for _, user := range users {
+			// the reminder tasks fanned out already
+			svc.dequeueTask(ctx, types.AsynqTaskTypeEventReminderEmail, fmt.Sprintf("%s_user:%d_event:%d", types.AsynqTaskTypeEventReminderEmail, user.ID, event.ID))
+			for _, channel := range []string{consts.NotifierChannelSms, consts.NotifierChannelPush} {
+				svc.dequeueTask(ctx, types.AsynqTaskTypeChannelNotification, fmt.Sprintf("%s_%s_user:%d_event:%d", types.AsynqTaskTypeChannelNotification, channel, user.ID, event.ID))
+			}
+
+			task, messageID, err := svc.createCancellationEmailTask(ctx, user, event)
+			if err != nil {
+				log.Error("failed to create cancellation email task", "err", err, "user_id", user.ID)
+				continue
+			}
+			customOpts := &types.AsynqOption{
+				Queue:  svc.config.TaskQueue(types.AsynqTaskTypeCancellationEmail.String()),
+				TaskID: fmt.Sprintf("%s_user:%d_event:%d", types.AsynqTaskTypeCancellationEmail, user.ID, event.ID),
+				Retry:  svc.config.CancellationEmailTaskRetryCount,
+			}
+			enqueuedID, err := svc.enqueueTask(ctx, task, customOpts)
+			if err != nil {
+				svc.failEmailDelivery(ctx, messageID, err)
+				continue
+			}
+			if enqueuedID == "" {
+				svc.discardEmailDelivery(ctx, messageID)
+				continue
+			}
+			notifications = append(notifications, cancellationNotification(user, event))
+		}
+		svc.notify(ctx, notifications)
+	}
+
+	log.Info("enqueued event cancellation tasks")
+	return nil
+}
+
+// dequeueTask removes a pending task, a task that doesn't exist is fine.
+func (svc *AsynqService) dequeueTask(ctx context.Context, taskType types.AsynqTaskType, taskID string) {
+	err := svc.asynqRepo.DequeueTask(ctx, svc.config.TaskQueue(taskType.String()), taskID)
+	if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+		logutil.FromContext(ctx).Error("failed to dequeue task", "err", err, "task_id", taskID)
+	}
+}
+
+// createChannelReminderTasks enqueues the reminder on every SMS/push channel the
+// user has enabled. Failures are logged only, the email reminder is already queued.
+func (svc *AsynqService) createChannelReminderTasks(ctx context.Context, user models.User, event *models.Event) {
+	if svc.notifierSvc == nil {
+		return
+	}
+
+	log := logutil.FromContext(ctx).With("event_id", event.ID, "user_id", user.ID)
+	notification := reminderNotification(user, event)
+	for _, channel := range svc.notifierSvc.EnabledChannels(&user) {
+		payload := types.ChannelNotificationPayload{
+			UserID:  user.ID,
+			Channel: channel,
+			Message: types.ChannelMessage{
+				Title: notification.Title,
+				Body:  notification.Body,
+				Data: map[string]interface{}{
+					"event_id": event.ID,
+				},
+			},
+		}
+
+		task, err := svc.asynqRepo.CreateTask(ctx, types.AsynqTaskTypeChannelNotification, payload)
+		if err != nil {
+			log.Error("failed to create channel reminder task", "err", err, "channel", channel)
+			continue
+		}
+
+		taskID := fmt.Sprintf("%s_%s_user:%d_event:%d", types.AsynqTaskTypeChannelNotification, channel, user.ID, event.ID)
+		customOpts := &types.AsynqOption{
+			Queue:  svc.config.TaskQueue(types.AsynqTaskTypeChannelNotification.String()),
+			TaskID: taskID,
+			Retry:  svc.config.ChannelNotificationTaskRetryCount,
+		}
+		if _, err := svc.enqueueTask(ctx, task, customOpts); err != nil {
+			continue
+		}
+		log.Info("enqueued channel reminder task", "channel", channel)
+	}
+}
+
+func (svc *AsynqService) createEmailInvitationTask(ctx context.Context, user models.User, event *models.Event) (*asynq.Task, string, error) {
+	loc := userLocation(user, event)
 	emailPayload := types.EmailPayload{
 		MailTo:  user.Email,
 		Subject: "Invitation to Event: " + event.Title,
 		Body: map[string]interface{}{
-			"event":     event,
+			"event":     types.LocalizeEvent(event, loc),
+			"timezone":  loc.String(),
 			"rsvp_link": fmt.Sprintf("http://127.0.0.1:8080/v1/events/%d/rsvp", event.ID),
 		},
 	}
 
-	return svc.asynqRepo.CreateTask(types.AsynqTaskTypeInvitationEmail, emailPayload)
+	return svc.createEmailTask(ctx, types.AsynqTaskTypeInvitationEmail, user, event, emailPayload)
 }
 
-func (svc *AsynqService) createEventReminderEmailTask(user models.User, event *models.Event) (*asynq.Task, error) {
+func (svc *AsynqService) createEventReminderEmailTask(ctx context.Context, user models.User, event *models.Event) (*asynq.Task, string, error) {
+	loc := userLocation(user, event)
 	emailPayload := types.EmailPayload{
 		MailTo:  user.Email,
 		Subject: "Event Reminder: " + event.Title,
 		Body: map[string]interface{}{
 			"event_title": event.Title,
-			"start_time":  event.StartTime,
+			"start_time":  types.LocalizeEvent(event, loc).StartTime,
+			"timezone":    loc.String(),
 			"join_link":   "https://www.go-ems.com/join?_C=dQw4w9WgXcQ",
 		},
 	}
-	return svc.asynqRepo.CreateTask(types.AsynqTaskTypeEventReminderEmail, emailPayload)
+	return svc.createEmailTask(ctx, types.AsynqTaskTypeEventReminderEmail, user, event, emailPayload)
 }
 
-func (svc *AsynqService) enqueueTask(task *asynq.Task, customOpts *types.AsynqOption) (taskID string, err error) {
-	err = svc.asynqRepo.DequeueTask(customOpts.TaskID) // Ensure no duplicate tasks
-	if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
-		logger.Error(fmt.Sprintf("error: [%v] occurred while dequeuing task with ID: %s", err, customOpts.TaskID))
+func (svc *AsynqService) createCancellationEmailTask(ctx context.Context, user models.User, event *models.Event) (*asynq.Task, string, error) {
+	loc := userLocation(user, event)
+	body := map[string]interface{}{
+		"event_title": event.Title,
+		"start_time":  types.LocalizeEvent(event, loc).StartTime,
+		"timezone":    loc.String(),
+	}
+	if event.CancelReason != nil {
+		body["reason"] = *event.CancelReason
+	}
+	emailPayload := types.EmailPayload{
+		MailTo:  user.Email,
+		Subject: "Event Cancelled: " + event.Title,
+		Body:    body,
+	}
+	return svc.createEmailTask(ctx, types.AsynqTaskTypeCancellationEmail, user, event, emailPayload)
+}
+
+// createEmailTask records a queued delivery for the email and returns the task
+// carrying its message ID, so the worker can report the outcome.
+func (svc *AsynqService) createEmailTask(ctx context.Context, taskType types.AsynqTaskType, user models.User, event *models.Event, emailPayload types.EmailPayload) (*asynq.Task, string, error) {
+	emailPayload.MessageID = uuid.New().String()
+
+	delivery := &models.EmailDelivery{
+		MessageID: emailPayload.MessageID,
+		TaskType:  taskType.String(),
+		EventID:   &event.ID,
+		UserID:    &user.ID,
+		Email:     emailPayload.MailTo,
+		Subject:   emailPayload.Subject,
+		Status:    consts.EmailDeliveryStatusQueued,
+	}
+	if err := svc.deliveryRepo.CreateEmailDelivery(ctx, delivery); err != nil {
+		return nil, "", err
+	}
+
+	task, err := svc.asynqRepo.CreateTask(ctx, taskType, emailPayload)
+	if err != nil {
+		svc.failEmailDelivery(ctx, emailPayload.MessageID, err)
+		return nil, "", err
+	}
+	return task, emailPayload.MessageID, nil
+}
+
+func (svc *AsynqService) failEmailDelivery(ctx context.Context, messageID string, cause error) {
+	err := svc.deliveryRepo.UpdateEmailDelivery(ctx, messageID, map[string]interface{}{
+		"status": consts.EmailDeliveryStatusFailed,
+		"error":  cause.Error(),
+	})
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to mark email delivery as failed", "err", err, "message_id", messageID)
 	}
+}
 
-	taskID, err = svc.asynqRepo.EnqueueTask(task, customOpts)
-	if errors.Is(err, asynq.ErrDuplicateTask) {
-		logger.Warn(fmt.Sprintf("skipped: duplicate task for taskID: [%s]", customOpts.TaskID))
-		err = nil // No error for duplicate tasks, just skip
+// discardEmailDelivery drops the delivery record of an email whose task is
+// already enqueued, the existing task keeps its own record.
+func (svc *AsynqService) discardEmailDelivery(ctx context.Context, messageID string) {
+	if err := svc.deliveryRepo.DeleteEmailDelivery(ctx, messageID); err != nil {
+		svc.failEmailDelivery(ctx, messageID, err)
+	}
+}
+
+// notify delivers the in-app counterpart of the enqueued emails. Failures are
+// only logged since the emails are already on their way.
+func (svc *AsynqService) notify(ctx context.Context, notifications []*models.Notification) {
+	if svc.notificationSvc == nil || len(notifications) == 0 {
 		return
 	}
+	if err := svc.notificationSvc.Notify(ctx, notifications); err != nil {
+		logutil.FromContext(ctx).Error("failed to create in-app notifications", "err", err, "count", len(notifications))
+	}
+}
+
+func invitationNotification(user models.User, event *models.Event) *models.Notification {
+	return &models.Notification{
+		UserID:  user.ID,
+		EventID: &event.ID,
+		Type:    consts.NotificationTypeEventInvitation,
+		Title:   "Invitation to Event: " + event.Title,
+		Body:    fmt.Sprintf("You have been invited to %s. RSVP to let the organizer know if you can make it.", event.Title),
+	}
+}
+
+func reminderNotification(user models.User, event *models.Event) *models.Notification {
+	body := fmt.Sprintf("%s is starting soon.", event.Title)
+	if event.StartTime != nil {
+		body = fmt.Sprintf("%s starts at %s.", event.Title, event.StartTime.In(userLocation(user, event)).Format(time.RFC1123))
+	}
+	return &models.Notification{
+		UserID:  user.ID,
+		EventID: &event.ID,
+		Type:    consts.NotificationTypeEventReminder,
+		Title:   "Event Reminder: " + event.Title,
+		Body:    body,
+	}
+}
+
+func cancellationNotification(user models.User, event *models.Event) *models.Notification {
+	body := fmt.Sprintf("%s has been cancelled.", event.Title)
+	if event.CancelReason != nil {
+		body = fmt.Sprintf("%s has been cancelled: %s", event.Title, *event.CancelReason)
+	}
+	return &models.Notification{
+		UserID:  user.ID,
+		EventID: &event.ID,
+		Type:    consts.NotificationTypeEventCancelled,
+		Title:   "Event Cancelled: " + event.Title,
+		Body:    body,
+	}
+}
+
+// userLocation returns the preferred time zone of the user, the time zone of
+// the event when the user has none.
+func userLocation(user models.User, event *models.Event) *time.Location {
+	if user.Timezone != nil {
+		return types.Location(*user.Timezone, event.Timezone)
+	}
+	return types.Location(event.Timezone)
+}
+
+// enqueueTask enqueues the task in place of a pending one with its ID. The ID is
+// empty when a task with the ID is still running, the task is then skipped.
+func (svc *AsynqService) enqueueTask(ctx context.Context, task *asynq.Task, customOpts *types.AsynqOption) (taskID string, err error) {
+	log := logutil.FromContext(ctx).With("task_id", customOpts.TaskID, "queue", customOpts.Queue)
+
+	err = svc.asynqRepo.DequeueTask(ctx, customOpts.Queue, customOpts.TaskID) // Ensure no duplicate tasks
+	if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+		log.Error("failed to dequeue task", "err", err)
+	}
+
+	// an active task can't be dequeued and keeps its ID until it's done
+	taskID, err = svc.asynqRepo.EnqueueTask(ctx, task, customOpts)
+	if errutil.Exists(err, []error{asynq.ErrTaskIDConflict, asynq.ErrDuplicateTask}) {
+		log.Warn("skipped duplicate task")
+		return "", nil // No error for duplicate tasks, just skip
+	}
 	if err != nil {
-		logger.Error(fmt.Sprintf("error: [%v] occurred while enqueuing task with ID: %s", err, customOpts.TaskID))
+		log.Error("failed to enqueue task", "err", err)
 		return
 	}
 
-	logger.Info(fmt.Sprintf("enqueued task [%s] successfully", taskID))
+	log.Info("enqueued task")
 	return taskID, nil
 }
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"go.uber.org/mock/gomock"
+)
+
+type asynqServiceMocks struct {
+	asynqRepo    *mocks.MockAsynqRepository
+	eventRepo    *mocks.MockEventRepository
+	deliveryRepo *mocks.MockEmailDeliveryRepository
+	batchRepo    *mocks.MockInvitationBatchRepository
+}
+
+func newTestAsynqService(ctrl *gomock.Controller) (*AsynqService, asynqServiceMocks) {
+	m := asynqServiceMocks{
+		asynqRepo:    mocks.NewMockAsynqRepository(ctrl),
+		eventRepo:    mocks.NewMockEventRepository(ctrl),
+		deliveryRepo: mocks.NewMockEmailDeliveryRepository(ctrl),
+		batchRepo:    mocks.NewMockInvitationBatchRepository(ctrl),
+	}
+	svc := NewAsynqService(&config.AsynqConfig{Queue: "default"}, m.asynqRepo, mocks.NewMockUserRepository(ctrl), m.eventRepo, nil, nil, m.deliveryRepo, m.batchRepo)
+	return svc, m
+}
+
+// expectEmailTask expects an email of the type to be recorded and its task created.
+func (m asynqServiceMocks) expectEmailTask(taskType types.AsynqTaskType, check func(payload interface{})) {
+	m.deliveryRepo.EXPECT().CreateEmailDelivery(gomock.Any(), gomock.Any()).Return(nil)
+	m.asynqRepo.EXPECT().
+		CreateTask(gomock.Any(), gomock.Eq(taskType), gomock.Any()).
+		DoAndReturn(func(_ context.Context, taskType types.AsynqTaskType, payload interface{}) (*asynq.Task, error) {
+			if check != nil {
+				check(payload)
+			}
+			return asynq.NewTask(taskType.String(), nil), nil
+		})
+}
+
+// Test cases for AsynqService.CreateEventReminderEmailTasks
+func TestCreateEventReminderEmailTasks(t *testing.T) {
+	// Test case 1: A reminder email still running keeps its delivery record
+	t.Run("SkipRunningTask", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		event := createTestEvent(1)
+		user := models.User{ID: 5, Email: "user@example.com"}
+
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(event, nil)
+		m.eventRepo.EXPECT().
+			GetAcceptedEventAttendees(gomock.Any(), gomock.Eq(1)).
+			Return([]models.EventAttendee{{EventID: 1, UserID: 5, User: user}}, nil)
+		m.expectEmailTask(types.AsynqTaskTypeEventReminderEmail, nil)
+		m.asynqRepo.EXPECT().DequeueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(asynq.ErrTaskNotFound)
+		m.asynqRepo.EXPECT().EnqueueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return("", asynq.ErrTaskIDConflict)
+		// the new record is dropped, not marked failed
+		m.deliveryRepo.EXPECT().DeleteEmailDelivery(gomock.Any(), gomock.Any()).Return(nil)
+
+		if err := svc.CreateEventReminderEmailTasks(context.Background(), event); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 2: A cancelled event sends no reminders
+	t.Run("SkipCancelledEvent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		event := createTestEvent(1)
+		cancelled := createTestEvent(1)
+		cancelled.Status = consts.EventStatusCancelled
+
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(cancelled, nil)
+
+		if err := svc.CreateEventReminderEmailTasks(context.Background(), event); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 3: The reminder shows the event as edited after it was scheduled
+	t.Run("SuccessfulWithCurrentEvent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		scheduled := createTestEvent(1)
+		current := createTestEvent(1)
+		current.Title = "Renamed Event"
+		startTime := current.StartTime.Add(2 * time.Hour)
+		current.StartTime = &startTime
+		user := models.User{ID: 5, Email: "user@example.com"}
+
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(current, nil)
+		m.eventRepo.EXPECT().
+			GetAcceptedEventAttendees(gomock.Any(), gomock.Eq(1)).
+			Return([]models.EventAttendee{{EventID: 1, UserID: 5, User: user}}, nil)
+		m.expectEmailTask(types.AsynqTaskTypeEventReminderEmail, func(payload interface{}) {
+			email := payload.(types.EmailPayload)
+			body := email.Body.(map[string]interface{})
+			if email.Subject != "Event Reminder: Renamed Event" || body["event_title"] != current.Title {
+				t.Errorf("Expected the current title, got '%s'", email.Subject)
+			}
+			if got := body["start_time"].(*time.Time); !got.Equal(startTime) {
+				t.Errorf("Expected start time %s, got %s", startTime, got)
+			}
+		})
+		m.asynqRepo.EXPECT().DequeueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(asynq.ErrTaskNotFound)
+		m.asynqRepo.EXPECT().EnqueueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return("task-5", nil)
+
+		if err := svc.CreateEventReminderEmailTasks(context.Background(), scheduled); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+// Test cases for AsynqService.CreateEventCancellationTasks
+func TestCreateEventCancellationTasks(t *testing.T) {
+	// Test case 1: Everyone invited or accepted is emailed, a running task is skipped
+	t.Run("SuccessfulFanOut", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		reason := "Speaker is ill"
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusCancelled
+		event.CancelReason = &reason
+		users := []models.User{{ID: 5, Email: "a@example.com"}, {ID: 8, Email: "b@example.com"}}
+
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(event, nil)
+		statuses := []int{consts.StatusInvited, consts.StatusAccepted}
+		m.eventRepo.EXPECT().
+			ListEventUsersByStatus(gomock.Any(), gomock.Eq(1), gomock.Eq(statuses), gomock.Eq(0), gomock.Any()).
+			Return(users, nil)
+		m.eventRepo.EXPECT().
+			ListEventUsersByStatus(gomock.Any(), gomock.Eq(1), gomock.Eq(statuses), gomock.Eq(8), gomock.Any()).
+			Return(nil, nil)
+		// the pending reminders and the task IDs about to be reused
+		m.asynqRepo.EXPECT().DequeueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(asynq.ErrTaskNotFound).AnyTimes()
+		m.expectEmailTask(types.AsynqTaskTypeCancellationEmail, func(payload interface{}) {
+			email := payload.(types.EmailPayload)
+			if email.Body.(map[string]interface{})["reason"] != reason {
+				t.Errorf("Expected reason '%s' in %v", reason, email.Body)
+			}
+		})
+		m.expectEmailTask(types.AsynqTaskTypeCancellationEmail, nil)
+		gomock.InOrder(
+			m.asynqRepo.EXPECT().EnqueueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return("task-5", nil),
+			m.asynqRepo.EXPECT().EnqueueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return("", asynq.ErrTaskIDConflict),
+		)
+		m.deliveryRepo.EXPECT().DeleteEmailDelivery(gomock.Any(), gomock.Any()).Return(nil)
+
+		if err := svc.CreateEventCancellationTasks(context.Background(), 1); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 2: A deleted event has nobody left to tell
+	t.Run("ErrorEventNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(nil, errutil.ErrRecordNotFound)
+
+		if err := svc.CreateEventCancellationTasks(context.Background(), 1); !errors.Is(err, errutil.ErrRecordNotFound) {
+			t.Errorf("Expected error %v, got %v", errutil.ErrRecordNotFound, err)
+		}
+	})
+}
+
+// Test cases for AsynqService.ProcessInvitationBatch
+func TestProcessInvitationBatch(t *testing.T) {
+	errPage := errors.New("page failed")
+	expectRunningBatch := func(m asynqServiceMocks) {
+		m.batchRepo.EXPECT().ReadInvitationBatch(gomock.Any(), gomock.Eq(1)).Return(&models.InvitationBatch{EventID: 1, Status: consts.InvitationBatchStatusRunning}, nil)
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(createTestEvent(1), nil)
+		m.batchRepo.EXPECT().UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Any()).Return(nil)
+		m.eventRepo.EXPECT().ListEventInvitees(gomock.Any(), gomock.Eq(1), gomock.Eq(0), gomock.Any()).Return(nil, errPage)
+	}
+
+	// Test case 1: A failed page is recorded on the batch
+	t.Run("ErrorListingInvitees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		expectRunningBatch(m)
+		m.batchRepo.EXPECT().
+			UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Eq(map[string]interface{}{"error": errPage.Error()})).
+			Return(nil)
+
+		if err := svc.ProcessInvitationBatch(context.Background(), 1); !errors.Is(err, errPage) {
+			t.Errorf("Expected error %v, got %v", errPage, err)
+		}
+	})
+
+	// Test case 2: Failing to record the error is reported too
+	t.Run("ErrorRecordingError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		expectRunningBatch(m)
+		errUpdate := errors.New("update failed")
+		m.batchRepo.EXPECT().UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Any()).Return(errUpdate)
+
+		err := svc.ProcessInvitationBatch(context.Background(), 1)
+		if !errors.Is(err, errPage) || !errors.Is(err, errUpdate) {
+			t.Errorf("Expected errors %v and %v, got %v", errPage, errUpdate, err)
+		}
+	})
+
+	// Test case 3: An event cancelled mid-batch stops inviting after the page
+	t.Run("StopWhenEventCancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, m := newTestAsynqService(ctrl)
+		m.batchRepo.EXPECT().ReadInvitationBatch(gomock.Any(), gomock.Eq(1)).Return(&models.InvitationBatch{EventID: 1, Status: consts.InvitationBatchStatusPending}, nil)
+		m.eventRepo.EXPECT().ReadEventByID(gomock.Any(), gomock.Eq(1)).Return(createTestEvent(1), nil)
+		m.eventRepo.EXPECT().
+			ListEventInvitees(gomock.Any(), gomock.Eq(1), gomock.Eq(0), gomock.Any()).
+			Return([]models.User{{ID: 5, Email: "user@example.com"}}, nil)
+		m.expectEmailTask(types.AsynqTaskTypeInvitationEmail, nil)
+		m.asynqRepo.EXPECT().EnqueueTask(gomock.Any(), gomock.Any(), gomock.Any()).Return("task-5", nil)
+		m.eventRepo.EXPECT().ReadEventStatus(gomock.Any(), gomock.Eq(1)).Return(consts.EventStatusCancelled, nil)
+		gomock.InOrder(
+			m.batchRepo.EXPECT().UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Any()).Return(nil),
+			m.batchRepo.EXPECT().UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Any()).Return(nil),
+			m.batchRepo.EXPECT().
+				UpdateInvitationBatch(gomock.Any(), gomock.Eq(1), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int, updates map[string]interface{}) error {
+					if updates["status"] != consts.InvitationBatchStatusCompleted {
+						t.Errorf("Expected the batch to be completed, got %v", updates)
+					}
+					return nil
+				}),
+		)
+
+		if err := svc.ProcessInvitationBatch(context.Background(), 1); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
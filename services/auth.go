@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/metrics"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -18,13 +22,17 @@ func NewAuthServiceImpl(userSvc domain.UserService, tokenSvc domain.TokenService
 	return &AuthServiceImpl{userSvc: userSvc, tokenSvc: tokenSvc}
 }
 
-func (svc *AuthServiceImpl) Login(req *types.LoginReq) (*types.LoginResp, error) {
-	user, err := svc.userSvc.ReadUserByEmail(req.Email)
+func (svc *AuthServiceImpl) Login(ctx context.Context, req *types.LoginReq) (*types.LoginResp, error) {
+	user, err := svc.userSvc.ReadUserByEmail(ctx, req.Email)
+	if errors.Is(err, errutil.ErrUserNotFound) {
+		metrics.Login(err)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		metrics.Login(errutil.ErrInvalidLoginCredentials)
 		return nil, errutil.ErrInvalidLoginCredentials
 	}
 
@@ -33,7 +41,7 @@ func (svc *AuthServiceImpl) Login(req *types.LoginReq) (*types.LoginResp, error)
 		return nil, err
 	}
 
-	if err := svc.tokenSvc.StoreTokenUUID(token); err != nil {
+	if err := svc.tokenSvc.StoreTokenUUID(ctx, token); err != nil {
 		return nil, err
 	}
 
@@ -46,12 +54,15 @@ func (svc *AuthServiceImpl) Login(req *types.LoginReq) (*types.LoginResp, error)
 		Role:      consts.RoleMap[user.RoleID],
 	}
 
+	log := logutil.FromContext(ctx)
 	go func() {
-		if err := svc.userSvc.StoreInCache(userInfo); err != nil {
-			logger.Error(err)
+		if err := svc.userSvc.StoreInCache(ctx, userInfo); err != nil {
+			log.Error("failed to cache user", "err", err, "user_id", userInfo.ID)
 		}
 	}()
 
+	metrics.Login(nil)
+
 	resp := &types.LoginResp{
 		AccessToken:  token.AccessToken,
 		RefreshToken: token.RefreshToken,
@@ -61,13 +72,13 @@ func (svc *AuthServiceImpl) Login(req *types.LoginReq) (*types.LoginResp, error)
 	return resp, nil
 }
 
-func (svc *AuthServiceImpl) VerifyAccessToken(accessToken string) (*types.UserInfo, *types.Token, error) {
+func (svc *AuthServiceImpl) VerifyAccessToken(ctx context.Context, accessToken string) (*types.UserInfo, *types.Token, error) {
 	token, err := svc.tokenSvc.ParseAccessToken(accessToken)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	userID, err := svc.tokenSvc.ReadUserIDFromAccessTokenUUID(token.AccessUuid)
+	userID, err := svc.tokenSvc.ReadUserIDFromAccessTokenUUID(ctx, token.AccessUuid)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,7 +87,7 @@ func (svc *AuthServiceImpl) VerifyAccessToken(accessToken string) (*types.UserIn
 		return nil, nil, errutil.ErrInvalidAccessToken
 	}
 
-	user, err := svc.userSvc.ReadUser(userID, true)
+	user, err := svc.userSvc.ReadUser(ctx, userID, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -84,6 +95,6 @@ func (svc *AuthServiceImpl) VerifyAccessToken(accessToken string) (*types.UserIn
 	return user, token, nil
 }
 
-func (svc *AuthServiceImpl) Logout(accessTokenUuid, refreshTokenUuid string) error {
-	return svc.tokenSvc.DeleteTokenUUID(&types.Token{AccessUuid: accessTokenUuid, RefreshUuid: refreshTokenUuid})
+func (svc *AuthServiceImpl) Logout(ctx context.Context, accessTokenUuid, refreshTokenUuid string) error {
+	return svc.tokenSvc.DeleteTokenUUID(ctx, &types.Token{AccessUuid: accessTokenUuid, RefreshUuid: refreshTokenUuid})
 }
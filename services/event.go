@@ -1,40 +1,58 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"slices"
+
+	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/metrics"
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
 )
 
 type EventServiceImpl struct {
+	config    *config.EventConfig
 	eventRepo domain.EventRepository
 	userRepo  domain.UserRepository
 }
 
-func NewEventServiceImpl(eventRepo domain.EventRepository, userRepo domain.UserRepository) *EventServiceImpl {
+func NewEventServiceImpl(config *config.EventConfig, eventRepo domain.EventRepository, userRepo domain.UserRepository) *EventServiceImpl {
 	return &EventServiceImpl{
+		config:    config,
 		eventRepo: eventRepo,
 		userRepo:  userRepo,
 	}
 }
 
-func (svc *EventServiceImpl) CreateEvent(eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error) {
+// eventTransitions lists the statuses an event can move to from each status,
+// cancelled and completed events stay as they are.
+var eventTransitions = map[string][]string{
+	consts.EventStatusDraft:           {consts.EventStatusPendingApproval, consts.EventStatusPublished, consts.EventStatusCancelled},
+	consts.EventStatusPendingApproval: {consts.EventStatusPublished, consts.EventStatusDraft, consts.EventStatusCancelled},
+	consts.EventStatusPublished:       {consts.EventStatusCancelled, consts.EventStatusCompleted},
+}
+
+func (svc *EventServiceImpl) CreateEvent(ctx context.Context, eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error) {
 	event := eventReq.ToEvent()
 	if !eventReq.IsPublic && len(eventReq.Attendees) > 0 {
-		users, err := svc.userRepo.ReadUsers(eventReq.Attendees)
+		users, err := svc.userRepo.ReadUsers(ctx, eventReq.Attendees)
 		if err != nil {
 			return nil, err
 		}
 		event.Attendees = users
 	}
 
-	createdEvent, err := svc.eventRepo.CreateEvent(event)
+	createdEvent, err := svc.eventRepo.CreateEvent(ctx, event)
 	if err != nil {
 		return nil, err
 	}
+	metrics.EventCreated()
 
 	return &types.CreateEventResponse{
 		Message: "Event created",
@@ -42,10 +60,10 @@ func (svc *EventServiceImpl) CreateEvent(eventReq *types.CreateEventRequest) (*t
 	}, nil
 }
 
-func (svc *EventServiceImpl) ListEvents(req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error) {
+func (svc *EventServiceImpl) ListEvents(ctx context.Context, req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error) {
 	offset := (req.Page - 1) * req.Limit
-	filter := svc.getEventListFilter(user)
-	events, count, err := svc.eventRepo.ListEvents(filter, req.Limit, offset)
+	filter := svc.getEventListFilter(req.Filter(), user)
+	events, count, err := svc.eventRepo.ListEvents(ctx, filter, req.Limit, offset)
 	if errors.Is(err, errutil.ErrRecordNotFound) {
 		return &types.PaginatedEventResponse{}, nil
 	}
@@ -60,13 +78,56 @@ func (svc *EventServiceImpl) ListEvents(req types.ListEventRequest, user *types.
 	}
 	return response, nil
 }
-func (svc *EventServiceImpl) getEventListFilter(user *types.CurrentUser) *types.EventFilter {
-	filter := &types.EventFilter{}
+
+// ListEventsByCursor lists the events next to the cursor, from the first one
+// without a cursor. One event more than the limit is read to tell if the
+// listing goes on.
+func (svc *EventServiceImpl) ListEventsByCursor(ctx context.Context, req types.ListEventRequest, cursor *types.Cursor, user *types.CurrentUser) (*types.CursorEventResponse, error) {
+	filter := svc.getEventListFilter(req.Filter(), user)
+	events, err := svc.eventRepo.ListEventsAfter(ctx, filter, cursor, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	more := len(events) > req.Limit
+	if more && cursor.IsBackward() {
+		events = events[1:]
+	} else if more {
+		events = events[:req.Limit]
+	}
+
+	resp := &types.CursorEventResponse{
+		CursorPage: types.CursorPage{Limit: req.Limit},
+		Events:     events,
+	}
+	if len(events) > 0 {
+		first, last := events[0], events[len(events)-1]
+		resp.CursorPage = types.NewCursorPage(req.Limit, cursor, more,
+			types.Cursor{StartTime: first.StartTime, ID: first.ID},
+			types.Cursor{StartTime: last.StartTime, ID: last.ID},
+		)
+	}
+	if req.IncludeTotal {
+		total, err := svc.eventRepo.CountEvents(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		resp.Total = &total
+	}
+	return resp, nil
+}
+
+// getEventListFilter scopes the search criteria of the request to the events
+// the user may see.
+func (svc *EventServiceImpl) getEventListFilter(filter *types.EventFilter, user *types.CurrentUser) *types.EventFilter {
 	if user == nil {
 		t := true
 		filter.IsPublic = &t
+		// nobody to have RSVPed
+		filter.RsvpStatus = 0
 		return filter
 	}
+	filter.RsvpUserID = user.ID
 
 	if user.HasPermission(consts.PermissionFetchAllEvent) {
 		return filter
@@ -81,25 +142,36 @@ func (svc *EventServiceImpl) getEventListFilter(user *types.CurrentUser) *types.
 	return filter
 }
 
-func (svc *EventServiceImpl) ReadEventByID(id int) (*models.Event, error) {
-	event, err := svc.eventRepo.ReadEventByID(id)
+func (svc *EventServiceImpl) ReadEventByID(ctx context.Context, id int) (*models.Event, error) {
+	event, err := svc.eventRepo.ReadEventByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	return event, nil
 }
 
-func (svc *EventServiceImpl) UpdateEvent(eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error) {
-	existingEvent, err := svc.eventRepo.ReadEventByID(eventReq.ID)
+func (svc *EventServiceImpl) UpdateEvent(ctx context.Context, eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error) {
+	existingEvent, err := svc.eventRepo.ReadEventByID(ctx, eventReq.ID)
 	if err != nil {
 		return nil, err
 	}
 	if existingEvent == nil {
 		return nil, errutil.ErrRecordNotFound
 	}
+	if eventReq.Version != 0 && eventReq.Version != existingEvent.Version {
+		return nil, errutil.ErrVersionConflict
+	}
 
+	// local times are read in the time zone the event keeps
+	if eventReq.Timezone == "" {
+		eventReq.Timezone = existingEvent.Timezone
+	}
 	event := eventReq.ToEvent()
-	updatedEvent, err := svc.eventRepo.UpdateEvent(event)
+	// without If-Match, still don't overwrite a change made since the read
+	event.Version = existingEvent.Version
+	// the status only changes through its transitions
+	event.Status = existingEvent.Status
+	updatedEvent, err := svc.eventRepo.UpdateEvent(ctx, event)
 	if err != nil {
 		return nil, err
 	}
@@ -109,8 +181,98 @@ func (svc *EventServiceImpl) UpdateEvent(eventReq *types.UpdateEventRequest) (*t
 	}, nil
 }
 
-func (svc *EventServiceImpl) DeleteEvent(id int) (*types.DeleteEventResponse, error) {
-	err := svc.eventRepo.DeleteEvent(id)
+// PatchEvent applies the merge patch to the event as it would be created and
+// validates the result like a new event. Attendee changes of a private event
+// become new invitations and removed invitations.
+func (svc *EventServiceImpl) PatchEvent(ctx context.Context, req *types.PatchEventRequest) (*types.PatchEventResponse, error) {
+	existingEvent, err := svc.eventRepo.ReadEventByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existingEvent == nil {
+		return nil, errutil.ErrRecordNotFound
+	}
+	if req.Version != 0 && req.Version != existingEvent.Version {
+		return nil, errutil.ErrVersionConflict
+	}
+
+	doc, err := json.Marshal(types.NewCreateEventRequest(existingEvent))
+	if err != nil {
+		return nil, err
+	}
+	patched, err := methodutil.MergePatch(doc, req.Patch)
+	if err != nil {
+		return nil, err
+	}
+	var eventReq types.CreateEventRequest
+	if err := json.Unmarshal(patched, &eventReq); err != nil {
+		return nil, errutil.ErrInvalidMergePatch
+	}
+	// the owner isn't editable
+	eventReq.CreatedBy = existingEvent.CreatedBy
+	if err := eventReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	event := eventReq.ToEvent()
+	event.ID = existingEvent.ID
+	event.Version = existingEvent.Version
+	event.Status = existingEvent.Status
+
+	// the attendees of a public event are its RSVPs, not invitations to diff
+	var added, removed []int
+	var invitees []models.User
+	if !event.IsPublic {
+		added, removed = diffAttendees(existingEvent.Attendees, eventReq.Attendees)
+		if len(added) > 0 {
+			invitees, err = svc.userRepo.ReadUsers(ctx, added)
+			if err != nil {
+				return nil, err
+			}
+			added = added[:0]
+			for _, user := range invitees {
+				added = append(added, user.ID)
+			}
+		}
+	}
+
+	patchedEvent, err := svc.eventRepo.ReplaceEvent(ctx, event, added, removed)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PatchEventResponse{
+		UpdateEventResponse: types.UpdateEventResponse{
+			Message: "Event updated",
+			Event:   patchedEvent,
+		},
+		Invitees: invitees,
+	}, nil
+}
+
+// diffAttendees returns the user IDs that are in ids but not among the
+// attendees, and the other way round.
+func diffAttendees(attendees []models.User, ids []int) (added, removed []int) {
+	current := make(map[int]bool, len(attendees))
+	for _, attendee := range attendees {
+		current[attendee.ID] = true
+	}
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !wanted[id] && !current[id] {
+			added = append(added, id)
+		}
+		wanted[id] = true
+	}
+	for _, attendee := range attendees {
+		if !wanted[attendee.ID] {
+			removed = append(removed, attendee.ID)
+		}
+	}
+	return added, removed
+}
+
+func (svc *EventServiceImpl) DeleteEvent(ctx context.Context, id, version int) (*types.DeleteEventResponse, error) {
+	err := svc.eventRepo.DeleteEvent(ctx, id, version)
 	if err != nil {
 		return nil, err
 	}
@@ -118,28 +280,36 @@ func (svc *EventServiceImpl) DeleteEvent(id int) (*types.DeleteEventResponse, er
 		Message: "Event deleted",
 	}, nil
 }
-func (svc *EventServiceImpl) RsvpEvent(request types.RsvpEventRequest) error {
-	event, err := svc.eventRepo.ReadEventByID(request.EventID)
+func (svc *EventServiceImpl) RsvpEvent(ctx context.Context, request types.RsvpEventRequest) error {
+	event, err := svc.eventRepo.ReadEventByID(ctx, request.EventID)
 	if err != nil {
 		return err
 	}
+	if event.Status == consts.EventStatusCancelled {
+		return errutil.ErrEventCancelled
+	}
+	if event.Status != consts.EventStatusPublished {
+		return errutil.ErrEventNotPublished
+	}
 	if !event.IsPublic {
-		invitation, err := svc.eventRepo.ReadEventInvitation(event.ID, request.UserID)
+		invitation, err := svc.eventRepo.ReadEventInvitation(ctx, event.ID, request.UserID)
 		if invitation == nil || err != nil {
 			return errutil.ErrRecordNotFound
 		}
 		invitation.StatusID = request.StatusID
-		err = svc.eventRepo.UpsertEventInvitation(invitation)
+		err = svc.eventRepo.UpsertEventInvitation(ctx, invitation)
 		if err != nil {
 			return err
 		}
+		metrics.Rsvp(request.StatusID)
 		return nil
 	}
-	count, err := svc.eventRepo.GetEventAttendeesCount(request.EventID)
+	count, err := svc.eventRepo.GetEventAttendeesCount(ctx, request.EventID)
 	if err != nil {
 		return err
 	}
 	if *event.Limit > 0 && count >= *event.Limit {
+		metrics.RsvpCapacityRejected()
 		return errutil.ErrEventCapacityExceeded
 	}
 
@@ -148,10 +318,124 @@ func (svc *EventServiceImpl) RsvpEvent(request types.RsvpEventRequest) error {
 		UserID:   request.UserID,
 		StatusID: request.StatusID,
 	}
-	err = svc.eventRepo.UpsertEventInvitation(newInvitation)
+	err = svc.eventRepo.UpsertEventInvitation(ctx, newInvitation)
 	if err != nil {
 		return err
 	}
+	metrics.Rsvp(request.StatusID)
 
 	return nil
 }
+
+func (svc *EventServiceImpl) ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error) {
+	if _, err := svc.eventRepo.ReadEventByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return svc.eventRepo.ListEventAttendees(ctx, eventID)
+}
+
+// PublishEvent publishes the event, or submits it for approval when events need
+// approval and the user can't approve them.
+func (svc *EventServiceImpl) PublishEvent(ctx context.Context, req *types.EventStatusRequest, user *types.CurrentUser) (*types.UpdateEventResponse, error) {
+	status, message := consts.EventStatusPublished, "Event published"
+	if svc.config.RequireApproval && !user.HasPermission(consts.PermissionEventApprove) {
+		status, message = consts.EventStatusPendingApproval, "Event submitted for approval"
+	}
+	event, err := svc.transitionEvent(ctx, req.ID, req.Version, status, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UpdateEventResponse{
+		Message: message,
+		Event:   event,
+	}, nil
+}
+
+// ReviewEvent publishes an event waiting for approval, or sends it back to
+// draft, and records the decision with the comment of the reviewer.
+func (svc *EventServiceImpl) ReviewEvent(ctx context.Context, req *types.EventReviewRequest) (*types.UpdateEventResponse, error) {
+	status, decision, message := consts.EventStatusDraft, consts.EventReviewRejected, "Event rejected"
+	if req.Approve {
+		status, decision, message = consts.EventStatusPublished, consts.EventReviewApproved, "Event approved"
+	}
+	review := &models.EventReview{
+		ReviewerID: &req.ReviewerID,
+		Decision:   decision,
+	}
+	if req.Comment != "" {
+		review.Comment = &req.Comment
+	}
+	event, err := svc.transitionEvent(ctx, req.ID, req.Version, status, review)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UpdateEventResponse{
+		Message: message,
+		Event:   event,
+	}, nil
+}
+
+func (svc *EventServiceImpl) CompleteEvent(ctx context.Context, req *types.EventStatusRequest) (*types.UpdateEventResponse, error) {
+	event, err := svc.transitionEvent(ctx, req.ID, req.Version, consts.EventStatusCompleted, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UpdateEventResponse{
+		Message: "Event completed",
+		Event:   event,
+	}, nil
+}
+
+// CancelEvent cancels the event with the reason, it stays visible as cancelled.
+func (svc *EventServiceImpl) CancelEvent(ctx context.Context, req *types.CancelEventRequest) (*types.CancelEventResponse, error) {
+	event, err := svc.readEventForTransition(ctx, req.ID, req.Version, consts.EventStatusCancelled, false)
+	if err != nil {
+		return nil, err
+	}
+	wasPublished := event.Status == consts.EventStatusPublished
+	event, err = svc.eventRepo.CancelEvent(ctx, event, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &types.CancelEventResponse{
+		UpdateEventResponse: types.UpdateEventResponse{
+			Message: "Event cancelled",
+			Event:   event,
+		},
+		WasPublished: wasPublished,
+	}, nil
+}
+
+func (svc *EventServiceImpl) ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error) {
+	if _, err := svc.eventRepo.ReadEventByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return svc.eventRepo.ListEventReviews(ctx, eventID)
+}
+
+// transitionEvent moves the event to the status when its current status allows
+// it. A review only applies to an event waiting for approval.
+func (svc *EventServiceImpl) transitionEvent(ctx context.Context, id, version int, status string, review *models.EventReview) (*models.Event, error) {
+	event, err := svc.readEventForTransition(ctx, id, version, status, review != nil)
+	if err != nil {
+		return nil, err
+	}
+	return svc.eventRepo.UpdateEventStatus(ctx, event, status, review)
+}
+
+// readEventForTransition reads the event and checks that it has the version and
+// may move to the status.
+func (svc *EventServiceImpl) readEventForTransition(ctx context.Context, id, version int, status string, review bool) (*models.Event, error) {
+	event, err := svc.eventRepo.ReadEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && version != event.Version {
+		return nil, errutil.ErrVersionConflict
+	}
+	if !slices.Contains(eventTransitions[event.Status], status) ||
+		(review && event.Status != consts.EventStatusPendingApproval) {
+		return nil, &types.EventTransitionError{From: event.Status, To: status}
+	}
+	return event, nil
+}
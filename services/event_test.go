@@ -1,11 +1,14 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 	"time"
 
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/services/mocks"
@@ -32,6 +35,7 @@ func createTestEvent(id int) *models.Event {
 		IsPublic:    true,
 		Limit:       &limit,
 		CreatedBy:   1,
+		Status:      consts.EventStatusPublished,
 	}
 }
 
@@ -68,11 +72,11 @@ func TestCreateEvent(t *testing.T) {
 		expectedEvent.ID = 1
 
 		mockEventRepo.EXPECT().
-			CreateEvent(gomock.Any()).
+			CreateEvent(gomock.Any(), gomock.Any()).
 			Return(expectedEvent, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.CreateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CreateEvent(context.Background(), request)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -126,15 +130,15 @@ func TestCreateEvent(t *testing.T) {
 		expectedEvent.Attendees = users
 
 		mockUserRepo.EXPECT().
-			ReadUsers(gomock.Eq([]int{2, 3})).
+			ReadUsers(gomock.Any(), gomock.Eq([]int{2, 3})).
 			Return(users, nil)
 
 		mockEventRepo.EXPECT().
-			CreateEvent(gomock.Any()).
+			CreateEvent(gomock.Any(), gomock.Any()).
 			Return(expectedEvent, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.CreateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CreateEvent(context.Background(), request)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -179,11 +183,11 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockUserRepo.EXPECT().
-			ReadUsers(gomock.Eq([]int{2, 3})).
+			ReadUsers(gomock.Any(), gomock.Eq([]int{2, 3})).
 			Return(nil, errors.New("error reading users"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.CreateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CreateEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -224,11 +228,11 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			CreateEvent(gomock.Any()).
+			CreateEvent(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("error creating event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.CreateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CreateEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -240,6 +244,66 @@ func TestCreateEvent(t *testing.T) {
 			t.Errorf("Expected error message 'error creating event', got '%s'", err.Error())
 		}
 	})
+
+	// Test case 5: Local times are stored in UTC with the offset of their day
+	t.Run("SuccessfulLocalTimeAcrossDST", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution for this subtest
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		// New York moves to daylight saving time on 2025-03-09
+		startTime := "2025-03-08T09:00:00"
+		endTime := "2025-03-10T09:00:00"
+
+		request := &types.CreateEventRequest{
+			Title:     "Test Event",
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			Timezone:  "America/New_York",
+			CreatedBy: 1,
+			IsPublic:  true,
+		}
+		if err := request.Validate(); err != nil {
+			t.Fatalf("Expected no validation error, got %v", err)
+		}
+
+		wantStart := time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC)
+		wantEnd := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+		mockEventRepo.EXPECT().
+			CreateEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.Event) (*models.Event, error) {
+				if !event.StartTime.Equal(wantStart) || event.StartTime.Location() != time.UTC {
+					t.Errorf("Expected start time %v, got %v", wantStart, event.StartTime)
+				}
+				if !event.EndTime.Equal(wantEnd) || event.EndTime.Location() != time.UTC {
+					t.Errorf("Expected end time %v, got %v", wantEnd, event.EndTime)
+				}
+				if event.Timezone != "America/New_York" {
+					t.Errorf("Expected timezone 'America/New_York', got '%s'", event.Timezone)
+				}
+				event.ID = 1
+				return event, nil
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CreateEvent(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		local := types.LocalizeEvent(response.Event, types.Location(response.Event.Timezone))
+		if got := local.StartTime.Format(time.RFC3339); got != "2025-03-08T09:00:00-05:00" {
+			t.Errorf("Expected local start time 2025-03-08T09:00:00-05:00, got %s", got)
+		}
+		if got := local.EndTime.Format(time.RFC3339); got != "2025-03-10T09:00:00-04:00" {
+			t.Errorf("Expected local end time 2025-03-10T09:00:00-04:00, got %s", got)
+		}
+	})
 }
 
 // Test cases for EventServiceImpl.ListEvents
@@ -271,11 +335,11 @@ func TestListEvents(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ListEvents(gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 			Return(events, 2, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.ListEvents(request, user)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEvents(context.Background(), request, user)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -317,8 +381,8 @@ func TestListEvents(t *testing.T) {
 
 		// Use a matcher to verify IsPublic is set to true
 		mockEventRepo.EXPECT().
-			ListEvents(gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
-			DoAndReturn(func(filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error) {
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			DoAndReturn(func(_ context.Context, filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error) {
 				// Verify that IsPublic is set to true for public access
 				if filter.IsPublic == nil || *filter.IsPublic != true {
 					t.Error("Expected IsPublic to be true for public access")
@@ -326,8 +390,8 @@ func TestListEvents(t *testing.T) {
 				return events, 2, nil
 			})
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.ListEvents(request, nil)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEvents(context.Background(), request, nil)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -371,11 +435,11 @@ func TestListEvents(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ListEvents(gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 			Return(nil, 0, errutil.ErrRecordNotFound)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.ListEvents(request, user)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEvents(context.Background(), request, user)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -413,11 +477,11 @@ func TestListEvents(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ListEvents(gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 			Return(nil, 0, errors.New("error listing events"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.ListEvents(request, user)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEvents(context.Background(), request, user)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -429,6 +493,131 @@ func TestListEvents(t *testing.T) {
 			t.Errorf("Expected error message 'error listing events', got '%s'", err.Error())
 		}
 	})
+
+	// Test case 5: Search criteria keep the permission scope
+	t.Run("SearchKeepsScope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		creator := 2
+		request := types.ListEventRequest{
+			Page:       1,
+			Limit:      10,
+			From:       "2025-06-01T00:00:00Z",
+			Search:     " kickoff ",
+			CreatedBy:  &creator,
+			RsvpStatus: consts.StatusAccepted,
+			Sort:       "start_time",
+			Order:      consts.SortDesc,
+		}
+
+		user := &types.CurrentUser{
+			ID:          3,
+			Email:       "manager@example.com",
+			RoleID:      2,
+			Role:        "Manager",
+			Permissions: []string{consts.PermissionFetchOwnEvent},
+		}
+
+		mockEventRepo.EXPECT().
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			DoAndReturn(func(_ context.Context, filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error) {
+				if filter.CreatedBy == nil || *filter.CreatedBy != 3 {
+					t.Error("Expected the events to stay scoped to the manager's own")
+				}
+				if filter.Creator == nil || *filter.Creator != 2 {
+					t.Error("Expected the creator criteria to be kept")
+				}
+				if filter.RsvpUserID != 3 || filter.RsvpStatus != consts.StatusAccepted {
+					t.Errorf("Expected RSVPs of user 3, got user %d status %d", filter.RsvpUserID, filter.RsvpStatus)
+				}
+				if filter.From == nil || filter.Search != "kickoff" {
+					t.Errorf("Expected from and search criteria, got %v and %q", filter.From, filter.Search)
+				}
+				return nil, 0, errutil.ErrRecordNotFound
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		if _, err := service.ListEvents(context.Background(), request, user); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+// Test cases for EventServiceImpl.ListEventsByCursor
+func TestListEventsByCursor(t *testing.T) {
+	// Test case 1: First page with more events after it
+	t.Run("FirstPage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		events := []*models.Event{createTestEvent(1), createTestEvent(2), createTestEvent(3)}
+		mockEventRepo.EXPECT().
+			ListEventsAfter(gomock.Any(), gomock.Any(), gomock.Nil(), gomock.Eq(3)).
+			Return(events, nil)
+		mockEventRepo.EXPECT().
+			CountEvents(gomock.Any(), gomock.Any()).
+			Return(7, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEventsByCursor(context.Background(), types.ListEventRequest{Limit: 2, IncludeTotal: true}, nil, nil)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(response.Events) != 2 || response.Events[1].ID != 2 {
+			t.Errorf("Expected events 1 and 2, got %v", response.Events)
+		}
+		if response.NextCursor == nil || response.NextCursor.ID != 2 || response.NextCursor.Backward {
+			t.Errorf("Expected next cursor after event 2, got %+v", response.NextCursor)
+		}
+		if response.PrevCursor != nil {
+			t.Errorf("Expected no previous cursor on the first page, got %+v", response.PrevCursor)
+		}
+		if response.Total == nil || *response.Total != 7 {
+			t.Errorf("Expected total 7, got %v", response.Total)
+		}
+	})
+
+	// Test case 2: Backward page keeps the events closest to the cursor
+	t.Run("BackwardPage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		cursor := &types.Cursor{ID: 4, Backward: true}
+		events := []*models.Event{createTestEvent(1), createTestEvent(2), createTestEvent(3)}
+		mockEventRepo.EXPECT().
+			ListEventsAfter(gomock.Any(), gomock.Any(), gomock.Eq(cursor), gomock.Eq(3)).
+			Return(events, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.ListEventsByCursor(context.Background(), types.ListEventRequest{Limit: 2}, cursor, nil)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(response.Events) != 2 || response.Events[0].ID != 2 {
+			t.Errorf("Expected events 2 and 3, got %v", response.Events)
+		}
+		if response.PrevCursor == nil || response.PrevCursor.ID != 2 || !response.PrevCursor.Backward {
+			t.Errorf("Expected previous cursor before event 2, got %+v", response.PrevCursor)
+		}
+		if response.NextCursor == nil || response.NextCursor.ID != 3 {
+			t.Errorf("Expected next cursor after event 3, got %+v", response.NextCursor)
+		}
+		if response.Total != nil {
+			t.Errorf("Expected no total, got %v", *response.Total)
+		}
+	})
 }
 
 // Test cases for EventServiceImpl.ReadEventByID
@@ -444,11 +633,11 @@ func TestReadEventByID(t *testing.T) {
 		expectedEvent := createTestEvent(1)
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(expectedEvent, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		event, err := service.ReadEventByID(1)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		event, err := service.ReadEventByID(context.Background(), 1)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -470,11 +659,11 @@ func TestReadEventByID(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(nil, errors.New("error reading event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		event, err := service.ReadEventByID(1)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		event, err := service.ReadEventByID(context.Background(), 1)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -520,15 +709,15 @@ func TestUpdateEvent(t *testing.T) {
 		updatedEvent.ID = 1
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(existingEvent, nil)
 
 		mockEventRepo.EXPECT().
-			UpdateEvent(gomock.Any()).
+			UpdateEvent(gomock.Any(), gomock.Any()).
 			Return(updatedEvent, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.UpdateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.UpdateEvent(context.Background(), request)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -570,11 +759,11 @@ func TestUpdateEvent(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(nil, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.UpdateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.UpdateEvent(context.Background(), request)
 
 		if err != errutil.ErrRecordNotFound {
 			t.Errorf("Expected error ErrRecordNotFound, got %v", err)
@@ -610,11 +799,11 @@ func TestUpdateEvent(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(nil, errors.New("error reading event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.UpdateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.UpdateEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -655,15 +844,15 @@ func TestUpdateEvent(t *testing.T) {
 		existingEvent := createTestEvent(1)
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(existingEvent, nil)
 
 		mockEventRepo.EXPECT().
-			UpdateEvent(gomock.Any()).
+			UpdateEvent(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("error updating event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.UpdateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.UpdateEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -675,9 +864,189 @@ func TestUpdateEvent(t *testing.T) {
 			t.Errorf("Expected error message 'error updating event', got '%s'", err.Error())
 		}
 	})
+
+	// Test case 5: Error when the event changed since the client read it
+	t.Run("ErrorVersionConflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		request := &types.UpdateEventRequest{
+			ID:      1,
+			Version: 1,
+			CreateEventRequest: types.CreateEventRequest{
+				Title:     "Updated Event",
+				CreatedBy: 1,
+			},
+		}
+
+		existingEvent := createTestEvent(1)
+		existingEvent.Version = 2
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(existingEvent, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.UpdateEvent(context.Background(), request)
+
+		if !errors.Is(err, errutil.ErrVersionConflict) {
+			t.Errorf("Expected error ErrVersionConflict, got %v", err)
+		}
+		if response != nil {
+			t.Errorf("Expected nil response, got %v", response)
+		}
+	})
 }
 
 // Test cases for EventServiceImpl.DeleteEvent
+// Test cases for EventServiceImpl.PatchEvent
+func TestPatchEvent(t *testing.T) {
+	// Test case 1: null clears a field and attendee changes are diffed
+	t.Run("SuccessfulPatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		existingEvent := createTestEvent(1)
+		existingEvent.IsPublic = false
+		existingEvent.Version = 2
+		existingEvent.Attendees = []models.User{{ID: 2}, {ID: 3}}
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(existingEvent, nil)
+		mockUserRepo.EXPECT().
+			ReadUsers(gomock.Any(), gomock.Eq([]int{4})).
+			Return([]models.User{{ID: 4}}, nil)
+		mockEventRepo.EXPECT().
+			ReplaceEvent(gomock.Any(), gomock.Any(), gomock.Eq([]int{4}), gomock.Eq([]int{2})).
+			DoAndReturn(func(_ context.Context, event *models.Event, added, removed []int) (*models.Event, error) {
+				if event.Location != nil {
+					t.Errorf("Expected location to be cleared, got %v", *event.Location)
+				}
+				if event.Title != "Patched Event" {
+					t.Errorf("Expected title 'Patched Event', got '%s'", event.Title)
+				}
+				if event.Version != 2 {
+					t.Errorf("Expected version 2, got %d", event.Version)
+				}
+				event.Version++
+				return event, nil
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.PatchEvent(context.Background(), &types.PatchEventRequest{
+			ID:      1,
+			Version: 2,
+			Patch:   []byte(`{"title":"Patched Event","location":null,"attendees":[3,4]}`),
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if response.Event.Version != 3 {
+			t.Errorf("Expected version 3, got %d", response.Event.Version)
+		}
+		if len(response.Invitees) != 1 || response.Invitees[0].ID != 4 {
+			t.Errorf("Expected invitee 4, got %v", response.Invitees)
+		}
+	})
+
+	// Test case 2: tags are normalized and null clears the category
+	t.Run("SuccessfulTagPatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		category := 1
+		existingEvent := createTestEvent(1)
+		existingEvent.CategoryID = &category
+		existingEvent.Tags = []models.Tag{{ID: 1, Name: "social"}}
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(existingEvent, nil)
+		mockEventRepo.EXPECT().
+			ReplaceEvent(gomock.Any(), gomock.Any(), gomock.Nil(), gomock.Nil()).
+			DoAndReturn(func(_ context.Context, event *models.Event, added, removed []int) (*models.Event, error) {
+				if event.CategoryID != nil {
+					t.Errorf("Expected category to be cleared, got %v", *event.CategoryID)
+				}
+				want := []models.Tag{{Name: "workshop"}, {Name: "go"}}
+				if !reflect.DeepEqual(event.Tags, want) {
+					t.Errorf("Expected tags %v, got %v", want, event.Tags)
+				}
+				return event, nil
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		_, err := service.PatchEvent(context.Background(), &types.PatchEventRequest{
+			ID:    1,
+			Patch: []byte(`{"category_id":null,"tags":[" Workshop","go","GO",""]}`),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 3: the patched event fails validation
+	t.Run("ErrorValidation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(createTestEvent(1), nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		_, err := service.PatchEvent(context.Background(), &types.PatchEventRequest{
+			ID:    1,
+			Patch: []byte(`{"title":null}`),
+		})
+
+		var validationErr v.Errors
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+
+	// Test case 4: the event changed since the client read it
+	t.Run("ErrorVersionConflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		existingEvent := createTestEvent(1)
+		existingEvent.Version = 3
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(existingEvent, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		_, err := service.PatchEvent(context.Background(), &types.PatchEventRequest{
+			ID:      1,
+			Version: 2,
+			Patch:   []byte(`{"title":"Patched Event"}`),
+		})
+
+		if !errors.Is(err, errutil.ErrVersionConflict) {
+			t.Errorf("Expected version conflict, got %v", err)
+		}
+	})
+}
+
 func TestDeleteEvent(t *testing.T) {
 	// Test case 1: Successful deletion of an event
 	t.Run("SuccessfulDeletion", func(t *testing.T) {
@@ -688,11 +1057,11 @@ func TestDeleteEvent(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 
 		mockEventRepo.EXPECT().
-			DeleteEvent(gomock.Eq(1)).
+			DeleteEvent(gomock.Any(), gomock.Eq(1), gomock.Eq(0)).
 			Return(nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.DeleteEvent(1)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.DeleteEvent(context.Background(), 1, 0)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -714,11 +1083,11 @@ func TestDeleteEvent(t *testing.T) {
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 
 		mockEventRepo.EXPECT().
-			DeleteEvent(gomock.Eq(1)).
+			DeleteEvent(gomock.Any(), gomock.Eq(1), gomock.Eq(0)).
 			Return(errors.New("error deleting event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		response, err := service.DeleteEvent(1)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.DeleteEvent(context.Background(), 1, 0)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -758,25 +1127,25 @@ func TestRsvpEvent(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(event, nil)
 
 		mockEventRepo.EXPECT().
-			ReadEventInvitation(gomock.Eq(1), gomock.Eq(2)).
+			ReadEventInvitation(gomock.Any(), gomock.Eq(1), gomock.Eq(2)).
 			Return(invitation, nil)
 
 		// Verify the invitation is updated correctly
 		mockEventRepo.EXPECT().
-			UpsertEventInvitation(gomock.Any()).
-			DoAndReturn(func(inv *models.EventAttendee) error {
+			UpsertEventInvitation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, inv *models.EventAttendee) error {
 				if inv.EventID != 1 || inv.UserID != 2 || inv.StatusID != 2 {
 					t.Errorf("Expected invitation with EventID=1, UserID=2, StatusID=2, got %v", inv)
 				}
 				return nil
 			})
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -801,25 +1170,25 @@ func TestRsvpEvent(t *testing.T) {
 		event.IsPublic = true
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(event, nil)
 
 		mockEventRepo.EXPECT().
-			GetEventAttendeesCount(gomock.Eq(1)).
+			GetEventAttendeesCount(gomock.Any(), gomock.Eq(1)).
 			Return(5, nil)
 
 		// Verify the invitation is created correctly
 		mockEventRepo.EXPECT().
-			UpsertEventInvitation(gomock.Any()).
-			DoAndReturn(func(inv *models.EventAttendee) error {
+			UpsertEventInvitation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, inv *models.EventAttendee) error {
 				if inv.EventID != 1 || inv.UserID != 2 || inv.StatusID != 2 {
 					t.Errorf("Expected invitation with EventID=1, UserID=2, StatusID=2, got %v", inv)
 				}
 				return nil
 			})
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
@@ -846,15 +1215,15 @@ func TestRsvpEvent(t *testing.T) {
 		event.Limit = &limit
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(event, nil)
 
 		mockEventRepo.EXPECT().
-			GetEventAttendeesCount(gomock.Eq(1)).
+			GetEventAttendeesCount(gomock.Any(), gomock.Eq(1)).
 			Return(5, nil) // Already at capacity
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err != errutil.ErrEventCapacityExceeded {
 			t.Errorf("Expected error ErrEventCapacityExceeded, got %v", err)
@@ -876,11 +1245,11 @@ func TestRsvpEvent(t *testing.T) {
 		}
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(nil, errors.New("error reading event"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -908,15 +1277,15 @@ func TestRsvpEvent(t *testing.T) {
 		event.IsPublic = false
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(event, nil)
 
 		mockEventRepo.EXPECT().
-			ReadEventInvitation(gomock.Eq(1), gomock.Eq(2)).
+			ReadEventInvitation(gomock.Any(), gomock.Eq(1), gomock.Eq(2)).
 			Return(nil, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err != errutil.ErrRecordNotFound {
 			t.Errorf("Expected error ErrRecordNotFound, got %v", err)
@@ -941,19 +1310,19 @@ func TestRsvpEvent(t *testing.T) {
 		event.IsPublic = true
 
 		mockEventRepo.EXPECT().
-			ReadEventByID(gomock.Eq(1)).
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
 			Return(event, nil)
 
 		mockEventRepo.EXPECT().
-			GetEventAttendeesCount(gomock.Eq(1)).
+			GetEventAttendeesCount(gomock.Any(), gomock.Eq(1)).
 			Return(5, nil)
 
 		mockEventRepo.EXPECT().
-			UpsertEventInvitation(gomock.Any()).
+			UpsertEventInvitation(gomock.Any(), gomock.Any()).
 			Return(errors.New("error upserting invitation"))
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		err := service.RsvpEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
 
 		if err == nil {
 			t.Error("Expected error, got nil")
@@ -962,6 +1331,277 @@ func TestRsvpEvent(t *testing.T) {
 			t.Errorf("Expected error message 'error upserting invitation', got '%s'", err.Error())
 		}
 	})
+
+	// Test case 7: Error when the event isn't published
+	t.Run("ErrorEventNotPublished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		request := types.RsvpEventRequest{
+			EventID:  1,
+			UserID:   2,
+			StatusID: 2,
+		}
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusDraft
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
+
+		if !errors.Is(err, errutil.ErrEventNotPublished) {
+			t.Errorf("Expected error ErrEventNotPublished, got %v", err)
+		}
+	})
+
+	// Test case 8: A cancelled event takes no more RSVPs
+	t.Run("ErrorEventCancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		request := types.RsvpEventRequest{
+			EventID:  1,
+			UserID:   2,
+			StatusID: 2,
+		}
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusCancelled
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		err := service.RsvpEvent(context.Background(), request)
+
+		if !errors.Is(err, errutil.ErrEventCancelled) {
+			t.Errorf("Expected error ErrEventCancelled, got %v", err)
+		}
+	})
+}
+
+// Test cases for EventServiceImpl.PublishEvent
+func TestPublishEvent(t *testing.T) {
+	tests := []struct {
+		name            string
+		requireApproval bool
+		permissions     []string
+		from            string
+		wantStatus      string
+		wantErr         error
+	}{
+		{name: "PublishDraft", from: consts.EventStatusDraft, wantStatus: consts.EventStatusPublished},
+		{name: "SubmitForApproval", requireApproval: true, from: consts.EventStatusDraft, wantStatus: consts.EventStatusPendingApproval},
+		{
+			name:            "ApproverPublishesDirectly",
+			requireApproval: true,
+			permissions:     []string{consts.PermissionEventApprove},
+			from:            consts.EventStatusDraft,
+			wantStatus:      consts.EventStatusPublished,
+		},
+		{name: "ErrorAlreadyPublished", from: consts.EventStatusPublished, wantErr: errutil.ErrEventTransitionNotAllowed},
+		{name: "ErrorCancelled", from: consts.EventStatusCancelled, wantErr: errutil.ErrEventTransitionNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEventRepo := mocks.NewMockEventRepository(ctrl)
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+			event := createTestEvent(1)
+			event.Status = tt.from
+			event.Version = 2
+
+			mockEventRepo.EXPECT().
+				ReadEventByID(gomock.Any(), gomock.Eq(1)).
+				Return(event, nil)
+			if tt.wantErr == nil {
+				mockEventRepo.EXPECT().
+					UpdateEventStatus(gomock.Any(), gomock.Eq(event), gomock.Eq(tt.wantStatus), gomock.Nil()).
+					DoAndReturn(func(_ context.Context, event *models.Event, status string, _ *models.EventReview) (*models.Event, error) {
+						event.Status = status
+						return event, nil
+					})
+			}
+
+			service := NewEventServiceImpl(&config.EventConfig{RequireApproval: tt.requireApproval}, mockEventRepo, mockUserRepo)
+			user := &types.CurrentUser{ID: 3, Permissions: tt.permissions}
+			response, err := service.PublishEvent(context.Background(), &types.EventStatusRequest{ID: 1, Version: 2}, user)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && response.Event.Status != tt.wantStatus {
+				t.Errorf("Expected status '%s', got '%s'", tt.wantStatus, response.Event.Status)
+			}
+		})
+	}
+}
+
+// Test cases for EventServiceImpl.ReviewEvent
+func TestReviewEvent(t *testing.T) {
+	// Test case 1: Approving publishes the event and records the review
+	t.Run("SuccessfulApproval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusPendingApproval
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+		mockEventRepo.EXPECT().
+			UpdateEventStatus(gomock.Any(), gomock.Eq(event), gomock.Eq(consts.EventStatusPublished), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.Event, status string, review *models.EventReview) (*models.Event, error) {
+				if review.ReviewerID == nil || *review.ReviewerID != 4 || review.Decision != consts.EventReviewApproved || review.Comment == nil || *review.Comment != "Looks good" {
+					t.Errorf("Unexpected review %+v", review)
+				}
+				event.Status = status
+				return event, nil
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{RequireApproval: true}, mockEventRepo, mockUserRepo)
+		response, err := service.ReviewEvent(context.Background(), &types.EventReviewRequest{ID: 1, Comment: "Looks good", ReviewerID: 4, Approve: true})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if response.Event.Status != consts.EventStatusPublished {
+			t.Errorf("Expected status '%s', got '%s'", consts.EventStatusPublished, response.Event.Status)
+		}
+	})
+
+	// Test case 2: Only events waiting for approval are reviewed
+	t.Run("ErrorRejectingDraft", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusDraft
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{RequireApproval: true}, mockEventRepo, mockUserRepo)
+		_, err := service.ReviewEvent(context.Background(), &types.EventReviewRequest{ID: 1, Comment: "Needs a venue", ReviewerID: 4})
+
+		var transitionErr *types.EventTransitionError
+		if !errors.As(err, &transitionErr) || transitionErr.From != consts.EventStatusDraft {
+			t.Errorf("Expected EventTransitionError from draft, got %v", err)
+		}
+	})
+}
+
+// Test cases for EventServiceImpl.CancelEvent
+func TestCancelEvent(t *testing.T) {
+	// Test case 1: Cancelling a published event keeps it with the reason
+	t.Run("SuccessfulCancellation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		event := createTestEvent(1)
+		event.Version = 2
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+		mockEventRepo.EXPECT().
+			CancelEvent(gomock.Any(), gomock.Eq(event), gomock.Eq("Speaker is ill")).
+			DoAndReturn(func(_ context.Context, event *models.Event, reason string) (*models.Event, error) {
+				event.Status = consts.EventStatusCancelled
+				event.CancelReason = &reason
+				return event, nil
+			})
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CancelEvent(context.Background(), &types.CancelEventRequest{ID: 1, Reason: "Speaker is ill", Version: 2})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !response.WasPublished {
+			t.Error("Expected the event to have been published")
+		}
+		if response.Event.Status != consts.EventStatusCancelled {
+			t.Errorf("Expected status '%s', got '%s'", consts.EventStatusCancelled, response.Event.Status)
+		}
+	})
+
+	// Test case 2: A draft is cancelled without anyone to tell
+	t.Run("SuccessfulDraftCancellation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusDraft
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+		mockEventRepo.EXPECT().
+			CancelEvent(gomock.Any(), gomock.Eq(event), gomock.Eq("Not needed")).
+			Return(event, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		response, err := service.CancelEvent(context.Background(), &types.CancelEventRequest{ID: 1, Reason: "Not needed"})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if response.WasPublished {
+			t.Error("Expected the draft not to have been published")
+		}
+	})
+
+	// Test case 3: An event is cancelled only once
+	t.Run("ErrorAlreadyCancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEventRepo := mocks.NewMockEventRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+
+		event := createTestEvent(1)
+		event.Status = consts.EventStatusCancelled
+
+		mockEventRepo.EXPECT().
+			ReadEventByID(gomock.Any(), gomock.Eq(1)).
+			Return(event, nil)
+
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		_, err := service.CancelEvent(context.Background(), &types.CancelEventRequest{ID: 1, Reason: "Again"})
+
+		if !errors.Is(err, errutil.ErrEventTransitionNotAllowed) {
+			t.Errorf("Expected error ErrEventTransitionNotAllowed, got %v", err)
+		}
+	})
 }
 
 // Benchmark for EventServiceImpl.CreateEvent
@@ -992,11 +1632,11 @@ func BenchmarkCreateEvent(b *testing.B) {
 		expectedEvent.ID = i + 1
 
 		mockEventRepo.EXPECT().
-			CreateEvent(gomock.Any()).
+			CreateEvent(gomock.Any(), gomock.Any()).
 			Return(expectedEvent, nil)
 
-		service := NewEventServiceImpl(mockEventRepo, mockUserRepo)
-		_, err := service.CreateEvent(request)
+		service := NewEventServiceImpl(&config.EventConfig{}, mockEventRepo, mockUserRepo)
+		_, err := service.CreateEvent(context.Background(), request)
 		if err != nil {
 			b.Errorf("Unexpected error: %v", err)
 		}
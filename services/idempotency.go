@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"github.com/vivasoft-ltd/go-ems/tracing"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// A request that outlives its reservation may find the key taken over by a
+// retry, so the record is only replaced or deleted by the owner of the token.
+var (
+	completeIdempotencyScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and cjson.decode(current).token == ARGV[1] then
+	return redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+end
+return false`)
+
+	releaseIdempotencyScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and cjson.decode(current).token == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+type IdempotencyStore struct {
+	client *redis.Client
+}
+
+func NewIdempotencyStore(client *redis.Client) *IdempotencyStore {
+	return &IdempotencyStore{client: client}
+}
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, key, bodyHash string, timeout time.Duration) (string, error) {
+	token := uuid.New().String()
+	value, err := json.Marshal(&types.IdempotencyRecord{BodyHash: bodyHash, Token: token})
+	if err != nil {
+		return "", err
+	}
+	// the timeout frees the key if the process dies before completing it
+	reserved, err := tracing.Redis(ctx, s.client).SetNX(key, value, timeout).Result()
+	if err != nil || !reserved {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *IdempotencyStore) Read(ctx context.Context, key string) (*types.IdempotencyRecord, error) {
+	value, err := tracing.Redis(ctx, s.client).Get(key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record types.IdempotencyRecord
+	if err := json.Unmarshal(value, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, key, token string, record *types.IdempotencyRecord, ttl time.Duration) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	err = completeIdempotencyScript.Run(tracing.Redis(ctx, s.client), []string{key}, token, value, ttl.Milliseconds()).Err()
+	if errors.Is(err, redis.Nil) {
+		return errutil.ErrIdempotencyKeyLost
+	}
+	return err
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, key, token string) error {
+	return releaseIdempotencyScript.Run(tracing.Redis(ctx, s.client), []string{key}, token).Err()
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+func newTestIdempotencyStore(t *testing.T) (*IdempotencyStore, *miniredis.Miniredis) {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewIdempotencyStore(client), srv
+}
+
+// Test cases for IdempotencyStore
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	// Test case 1: The owner completes its reservation
+	t.Run("SuccessfulComplete", func(t *testing.T) {
+		store, _ := newTestIdempotencyStore(t)
+
+		token, err := store.Reserve(ctx, "key", "hash", time.Minute)
+		if err != nil || token == "" {
+			t.Fatalf("Expected the key to be reserved, got '%s', %v", token, err)
+		}
+		if other, err := store.Reserve(ctx, "key", "hash", time.Minute); err != nil || other != "" {
+			t.Fatalf("Expected the key to be taken, got '%s', %v", other, err)
+		}
+
+		record := &types.IdempotencyRecord{BodyHash: "hash", Status: 201, Header: map[string]string{"ETag": `"1"`}}
+		if err := store.Complete(ctx, "key", token, record, time.Minute); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		stored, err := store.Read(ctx, "key")
+		if err != nil || !stored.Completed() || stored.Header["ETag"] != `"1"` {
+			t.Errorf("Expected the completed record, got %+v, %v", stored, err)
+		}
+	})
+
+	// Test case 2: A request that outlived its reservation leaves the new one alone
+	t.Run("StaleTokenKeepsNewReservation", func(t *testing.T) {
+		store, srv := newTestIdempotencyStore(t)
+
+		stale, _ := store.Reserve(ctx, "key", "hash", time.Second)
+		srv.FastForward(2 * time.Second)
+		token, _ := store.Reserve(ctx, "key", "hash", time.Minute)
+		if token == "" || token == stale {
+			t.Fatalf("Expected a new reservation, got '%s'", token)
+		}
+
+		if err := store.Release(ctx, "key", stale); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		err := store.Complete(ctx, "key", stale, &types.IdempotencyRecord{BodyHash: "hash", Status: 201}, time.Minute)
+		if !errors.Is(err, errutil.ErrIdempotencyKeyLost) {
+			t.Errorf("Expected error %v, got %v", errutil.ErrIdempotencyKeyLost, err)
+		}
+		if stored, _ := store.Read(ctx, "key"); stored == nil || stored.Token != token {
+			t.Errorf("Expected the new reservation to be kept, got %+v", stored)
+		}
+
+		if err := store.Release(ctx, "key", token); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if srv.Exists("key") {
+			t.Error("Expected the owner to release the key")
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,36 +11,130 @@ import (
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"github.com/vivasoft-ltd/go-ems/worker"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"gorm.io/gorm"
 )
 
 type Mail struct {
-	userRepo   domain.UserRepository
-	eventRepo  domain.EventRepository
-	mailRepo   domain.MailRepository
-	workerPool *worker.Pool
+	userRepo     domain.UserRepository
+	eventRepo    domain.EventRepository
+	mailRepo     domain.MailRepository
+	deliveryRepo domain.EmailDeliveryRepository
+	workerPool   *worker.Pool
 }
 
-func NewMailService(userRepo domain.UserRepository, eventRepo domain.EventRepository, mailRepo domain.MailRepository) *Mail {
+func NewMailService(userRepo domain.UserRepository, eventRepo domain.EventRepository, mailRepo domain.MailRepository, deliveryRepo domain.EmailDeliveryRepository, workerPool *worker.Pool) *Mail {
 	return &Mail{
-		userRepo:  userRepo,
-		eventRepo: eventRepo,
-		mailRepo:  mailRepo,
+		userRepo:     userRepo,
+		eventRepo:    eventRepo,
+		mailRepo:     mailRepo,
+		deliveryRepo: deliveryRepo,
+		workerPool:   workerPool,
 	}
 }
 
-func (m *Mail) SendEmail(reqData types.EmailPayload) error {
-	err := m.mailRepo.SendEmail(&reqData)
+// SendEmail sends the email unless the address is suppressed and records the
+// outcome on the payload's delivery record.
+func (m *Mail) SendEmail(ctx context.Context, reqData types.EmailPayload) error {
+	log := logutil.FromContext(ctx).With("message_id", reqData.MessageID)
+
+	suppressed, err := m.deliveryRepo.IsEmailSuppressed(ctx, reqData.MailTo)
 	if err != nil {
-		logger.Error(fmt.Sprintf("err: []%v occurred while sending email to: %s", err, reqData.MailTo))
+		log.Error("failed to check email suppression", "err", err)
+		return err
+	}
+	if suppressed {
+		m.updateDelivery(ctx, reqData.MessageID, map[string]interface{}{
+			"status": consts.EmailDeliveryStatusFailed,
+			"error":  errutil.ErrEmailSuppressed.Error(),
+		})
+		return errutil.ErrEmailSuppressed
+	}
+
+	providerMessageID, err := m.mailRepo.SendEmail(ctx, &reqData)
+	var retryAfterErr *errutil.RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		// the provider asked us to back off, the task is retried without counting as a failed attempt
+		log.Warn("email provider throttled sending, retrying later", "retry_after", retryAfterErr.RetryAfter)
+		m.updateDelivery(ctx, reqData.MessageID, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+	if err != nil {
+		log.Error("failed to send email", "err", err)
+		m.updateDelivery(ctx, reqData.MessageID, map[string]interface{}{
+			"status":   consts.EmailDeliveryStatusFailed,
+			"error":    err.Error(),
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"status":   consts.EmailDeliveryStatusSent,
+		"error":    nil,
+		"attempts": gorm.Expr("attempts + 1"),
+		"sent_at":  time.Now(),
+	}
+	if providerMessageID != "" {
+		updates["provider_message_id"] = providerMessageID
+	}
+	m.updateDelivery(ctx, reqData.MessageID, updates)
+
+	return nil
+}
+
+// HandleDeliveryEvent applies a provider bounce or complaint: the delivery is
+// flagged and the address is suppressed from future sends.
+func (m *Mail) HandleDeliveryEvent(ctx context.Context, req *types.EmailDeliveryEventReq) error {
+	status := consts.EmailDeliveryStatusBounced
+	if req.Type == consts.EmailDeliveryEventComplaint {
+		status = consts.EmailDeliveryStatusComplained
+	}
+
+	delivery, err := m.deliveryRepo.ReadEmailDelivery(ctx, req.MessageID, req.ProviderMessageID)
+	if err != nil && !errors.Is(err, errutil.ErrRecordNotFound) {
 		return err
 	}
+	if delivery != nil {
+		updates := map[string]interface{}{"status": status}
+		if req.Reason != "" {
+			updates["error"] = req.Reason
+		}
+		if err := m.deliveryRepo.UpdateEmailDelivery(ctx, delivery.MessageID, updates); err != nil {
+			return err
+		}
+	}
+
+	var details *string
+	if req.Reason != "" {
+		details = &req.Reason
+	}
+	if err := m.deliveryRepo.SuppressEmail(ctx, &models.EmailSuppression{
+		Email:   req.Email,
+		Reason:  req.Type,
+		Details: details,
+	}); err != nil {
+		return err
+	}
+
+	logutil.FromContext(ctx).Info("suppressed future emails", "email", req.Email, "reason", req.Type)
 	return nil
 }
 
-// func (m *Mail) SendInvitationEmail(userIds []int, event *models.Event) error {
-// 	users, err := m.userRepo.ReadUsers(userIds)
+func (m *Mail) updateDelivery(ctx context.Context, messageID string, updates map[string]interface{}) {
+	if messageID == "" {
+		return
+	}
+	if err := m.deliveryRepo.UpdateEmailDelivery(ctx, messageID, updates); err != nil {
+		logutil.FromContext(ctx).Error("failed to update email delivery", "err", err, "message_id", messageID)
+	}
+}
+
+// func (m *Mail) SendInvitationEmail(ctx context.Context, userIds []int, event *models.Event) error {
+// 	users, err := m.userRepo.ReadUsers(ctx, userIds)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -59,30 +155,36 @@ func (m *Mail) SendEmail(reqData types.EmailPayload) error {
 // 	return nil
 // }
 
-func (m *Mail) SendInvitationEmail(userIds []int, event *models.Event) error {
-	users, err := m.userRepo.ReadUsers(userIds)
+func (m *Mail) SendInvitationEmail(ctx context.Context, userIds []int, event *models.Event) error {
+	users, err := m.userRepo.ReadUsers(ctx, userIds)
 	if err != nil {
 		return err
 	}
 
 	for _, user := range users {
+		loc := userLocation(user, event)
 		emailPayload := types.EmailPayload{
 			MailTo:  user.Email,
 			Subject: "Invitation to Event: " + event.Title,
 			Body: map[string]interface{}{
-				"event":     event,
+				"event":     types.LocalizeEvent(event, loc),
+				"timezone":  loc.String(),
 				"rsvp_link": fmt.Sprintf("http://127.0.0.1:8080/v1/events/%d/rsvp", event.ID),
 			},
 		}
 
 		// Add the email sending task to the worker pool
-		task := worker.NewTask(func() error {
-			return m.SendEmail(emailPayload)
+		task := worker.NewTask(func(ctx context.Context) error {
+			return m.SendEmail(ctx, emailPayload)
 		}, func(err error) {
-			logger.Error("Failed to send email: ", err, " to user: ", user.Email)
+			logutil.FromContext(ctx).Error("failed to send invitation email", "err", err, "event_id", event.ID, "user_id", user.ID)
 		}, 3)
 
-		m.workerPool.AddTask(task)
+		// blocks while the pool is busy, fails once it is shutting down
+		if err := m.workerPool.AddTask(task); err != nil {
+			logutil.FromContext(ctx).Error("failed to queue invitation email", "err", err, "event_id", event.ID, "user_id", user.ID)
+			return err
+		}
 	}
 
 	// wp.StopAfterTaskCompleted(len(users))
@@ -90,25 +192,25 @@ func (m *Mail) SendInvitationEmail(userIds []int, event *models.Event) error {
 	return nil
 }
 
-func (m *Mail) EnqueueEventReminderEmailNotification(event *models.Event) error {
+func (m *Mail) EnqueueEventReminderEmailNotification(ctx context.Context, event *models.Event) error {
 	eventStartTime := event.StartTime
 	reminderTime := eventStartTime.Add(-consts.EventReminderInterval)
 	now := time.Now()
+	log := logutil.FromContext(ctx).With("event_id", event.ID)
 
 	if reminderTime.Before(now) {
-		logger.Info("Reminder time is in the past, skipping event reminder email for event: ", event.Title)
+		log.Info("skipping event reminder email, reminder time is in the past")
 		return errutil.ErrEventReminderEmailNotEnqueued
 	}
 
-	logger.Info("Enqueing event reminder email for event: ", event.Title)
-	logger.Info(fmt.Sprintf("Reminder trigger time: %v, event start time: %v", reminderTime.Format(time.RFC3339), eventStartTime.Format(time.RFC3339)))
+	log.Info("scheduling event reminder email", "reminder_time", reminderTime.Format(time.RFC3339), "start_time", eventStartTime.Format(time.RFC3339))
 
 	timeLeftToSendReminderEmail := reminderTime.Sub(now)
 
 	// Initialize the scheduler
 	scheduler := worker.NewScheduler(timeLeftToSendReminderEmail)
 	// pass callback/callable function to the scheduler
-	scheduler.Start(func() { m.sendEventReminderEmail(event) })
+	scheduler.Start(func() { m.sendEventReminderEmail(ctx, event) })
 
 	// Let the scheduler run for some time
 	time.Sleep(time.Duration(timeLeftToSendReminderEmail))
@@ -117,38 +219,45 @@ func (m *Mail) EnqueueEventReminderEmailNotification(event *models.Event) error
 	return nil
 }
 
-func (m *Mail) sendEventReminderEmail(event *models.Event) {
-	eventAttendees, err := m.eventRepo.GetAcceptedEventAttendees(event.ID)
+func (m *Mail) sendEventReminderEmail(ctx context.Context, event *models.Event) {
+	log := logutil.FromContext(ctx).With("event_id", event.ID)
+
+	eventAttendees, err := m.eventRepo.GetAcceptedEventAttendees(ctx, event.ID)
 	if err != nil {
 		if err == errutil.ErrUserNotFound {
-			logger.Error(fmt.Sprintf("SKIPPING: No accepted event attendees found for event: %s", event.Title))
+			log.Info("skipping event reminder emails, no accepted attendees found")
 			return
 		}
 
-		logger.Error(fmt.Sprintf("Failed to get accepted event attendees for event: %s , err: %v", event.Title, err))
+		log.Error("failed to get accepted event attendees", "err", err)
 		return
 	}
 
-	fmt.Printf("Sending event reminder email to %d Attendees who have accepted the invitation", len(eventAttendees))
+	log.Info("sending event reminder emails", "attendees", len(eventAttendees))
 
 	for _, eventAttendee := range eventAttendees {
 		user := eventAttendee.User
+		loc := userLocation(user, event)
 		emailPayload := types.EmailPayload{
 			MailTo:  user.Email,
 			Subject: "Event Reminder: " + event.Title,
 			Body: map[string]interface{}{
 				"event_title": event.Title,
-				"start_time":  event.StartTime,
+				"start_time":  types.LocalizeEvent(event, loc).StartTime,
+				"timezone":    loc.String(),
 				"join_link":   "https://www.go-ems.com/join?_C=dQw4w9WgXcQ",
 			},
 		}
 
-		task := worker.NewTask(func() error {
-			return m.SendEmail(emailPayload)
+		task := worker.NewTask(func(ctx context.Context) error {
+			return m.SendEmail(ctx, emailPayload)
 		}, func(err error) {
-			logger.Error("Failed to send reminder email: ", err, " to user: ", user.Email)
+			log.Error("failed to send event reminder email", "err", err, "user_id", user.ID)
 		}, 0)
 
-		m.workerPool.AddTask(task)
+		if err := m.workerPool.AddTask(task); err != nil {
+			log.Error("failed to queue event reminder email", "err", err, "user_id", user.ID)
+			return
+		}
 	}
 }
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/worker"
+	"go.uber.org/mock/gomock"
+)
+
+// Test cases for Mail.SendInvitationEmail
+func TestSendInvitationEmail(t *testing.T) {
+	// Test case 1: Mails that can't be queued are reported, not dropped
+	t.Run("ErrorPoolClosed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		pool := worker.NewPool(1, 1, worker.WithName(t.Name()))
+		pool.Start()
+		if err := pool.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+
+		mockUserRepo.EXPECT().
+			ReadUsers(gomock.Any(), gomock.Eq([]int{5})).
+			Return([]models.User{{ID: 5, Email: "user@example.com"}}, nil)
+
+		service := NewMailService(mockUserRepo, nil, nil, nil, pool)
+		err := service.SendInvitationEmail(context.Background(), []int{5}, createTestEvent(1))
+
+		if !errors.Is(err, worker.ErrPoolClosed) {
+			t.Errorf("Expected error %v, got %v", worker.ErrPoolClosed, err)
+		}
+	})
+}
+
+// Test cases for Mail.SendEmail
+func TestSendEmail(t *testing.T) {
+	payload := types.EmailPayload{MessageID: "msg-1", MailTo: "user@example.com", Subject: "Hello"}
+
+	// Test case 1: A suppressed address is skipped and its delivery failed
+	t.Run("SkipSuppressedAddress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMailRepo := mocks.NewMockMailRepository(ctrl)
+		mockDeliveryRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+		service := NewMailService(nil, nil, mockMailRepo, mockDeliveryRepo, nil)
+
+		mockDeliveryRepo.EXPECT().IsEmailSuppressed(gomock.Any(), gomock.Eq(payload.MailTo)).Return(true, nil)
+		mockDeliveryRepo.EXPECT().
+			UpdateEmailDelivery(gomock.Any(), gomock.Eq(payload.MessageID), gomock.Eq(map[string]interface{}{
+				"status": consts.EmailDeliveryStatusFailed,
+				"error":  errutil.ErrEmailSuppressed.Error(),
+			})).
+			Return(nil)
+		mockMailRepo.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Times(0)
+
+		if err := service.SendEmail(context.Background(), payload); !errors.Is(err, errutil.ErrEmailSuppressed) {
+			t.Errorf("Expected error %v, got %v", errutil.ErrEmailSuppressed, err)
+		}
+	})
+
+	// Test case 2: A sent email records the provider's message ID
+	t.Run("Successful", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMailRepo := mocks.NewMockMailRepository(ctrl)
+		mockDeliveryRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+		service := NewMailService(nil, nil, mockMailRepo, mockDeliveryRepo, nil)
+
+		mockDeliveryRepo.EXPECT().IsEmailSuppressed(gomock.Any(), gomock.Eq(payload.MailTo)).Return(false, nil)
+		mockMailRepo.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return("provider-1", nil)
+		mockDeliveryRepo.EXPECT().
+			UpdateEmailDelivery(gomock.Any(), gomock.Eq(payload.MessageID), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updates map[string]interface{}) error {
+				if updates["status"] != consts.EmailDeliveryStatusSent || updates["provider_message_id"] != "provider-1" {
+					t.Errorf("Expected a sent delivery with the provider message ID, got %v", updates)
+				}
+				return nil
+			})
+
+		if err := service.SendEmail(context.Background(), payload); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 3: Failing to check the suppression list doesn't send
+	t.Run("ErrorCheckingSuppression", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMailRepo := mocks.NewMockMailRepository(ctrl)
+		mockDeliveryRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+		service := NewMailService(nil, nil, mockMailRepo, mockDeliveryRepo, nil)
+		errDB := errors.New("db down")
+
+		mockDeliveryRepo.EXPECT().IsEmailSuppressed(gomock.Any(), gomock.Eq(payload.MailTo)).Return(false, errDB)
+		mockMailRepo.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Times(0)
+
+		if err := service.SendEmail(context.Background(), payload); !errors.Is(err, errDB) {
+			t.Errorf("Expected error %v, got %v", errDB, err)
+		}
+	})
+}
+
+// Test cases for Mail.HandleDeliveryEvent
+func TestHandleDeliveryEvent(t *testing.T) {
+	errDB := errors.New("db down")
+	tests := []struct {
+		name       string
+		req        types.EmailDeliveryEventReq
+		delivery   *models.EmailDelivery
+		readErr    error
+		wantStatus string // status the delivery is flagged with, empty when it isn't
+		wantErr    error
+	}{
+		{
+			name:       "BounceFlagsDeliveryAndSuppresses",
+			req:        types.EmailDeliveryEventReq{Type: consts.EmailDeliveryEventBounce, Email: "user@example.com", MessageID: "msg-1", Reason: "mailbox full"},
+			delivery:   &models.EmailDelivery{MessageID: "msg-1"},
+			wantStatus: consts.EmailDeliveryStatusBounced,
+		},
+		{
+			name:       "ComplaintFlagsDeliveryAndSuppresses",
+			req:        types.EmailDeliveryEventReq{Type: consts.EmailDeliveryEventComplaint, Email: "user@example.com", ProviderMessageID: "provider-1"},
+			delivery:   &models.EmailDelivery{MessageID: "msg-1"},
+			wantStatus: consts.EmailDeliveryStatusComplained,
+		},
+		{
+			name:    "UnknownDeliveryStillSuppresses",
+			req:     types.EmailDeliveryEventReq{Type: consts.EmailDeliveryEventBounce, Email: "user@example.com", MessageID: "msg-unknown"},
+			readErr: errutil.ErrRecordNotFound,
+		},
+		{
+			name:    "ErrorReadingDelivery",
+			req:     types.EmailDeliveryEventReq{Type: consts.EmailDeliveryEventBounce, Email: "user@example.com", MessageID: "msg-1"},
+			readErr: errDB,
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDeliveryRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+			service := NewMailService(nil, nil, nil, mockDeliveryRepo, nil)
+
+			mockDeliveryRepo.EXPECT().
+				ReadEmailDelivery(gomock.Any(), gomock.Eq(tt.req.MessageID), gomock.Eq(tt.req.ProviderMessageID)).
+				Return(tt.delivery, tt.readErr)
+			if tt.wantStatus != "" {
+				updates := map[string]interface{}{"status": tt.wantStatus}
+				if tt.req.Reason != "" {
+					updates["error"] = tt.req.Reason
+				}
+				mockDeliveryRepo.EXPECT().UpdateEmailDelivery(gomock.Any(), gomock.Eq(tt.delivery.MessageID), gomock.Eq(updates)).Return(nil)
+			}
+			if tt.wantErr == nil {
+				mockDeliveryRepo.EXPECT().
+					SuppressEmail(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, suppression *models.EmailSuppression) error {
+						if suppression.Email != tt.req.Email || suppression.Reason != tt.req.Type {
+							t.Errorf("Expected %s to be suppressed for %s, got %+v", tt.req.Email, tt.req.Type, suppression)
+						}
+						return nil
+					})
+			}
+
+			if err := service.HandleDeliveryEvent(context.Background(), &tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/asynq.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/asynq.go -destination=services/mocks/mock_asynq_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	asynq "github.com/hibiken/asynq"
+	models "github.com/vivasoft-ltd/go-ems/models"
+	types "github.com/vivasoft-ltd/go-ems/types"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAsynqRepository is a mock of AsynqRepository interface.
+type MockAsynqRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAsynqRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAsynqRepositoryMockRecorder is the mock recorder for MockAsynqRepository.
+type MockAsynqRepositoryMockRecorder struct {
+	mock *MockAsynqRepository
+}
+
+// NewMockAsynqRepository creates a new mock instance.
+func NewMockAsynqRepository(ctrl *gomock.Controller) *MockAsynqRepository {
+	mock := &MockAsynqRepository{ctrl: ctrl}
+	mock.recorder = &MockAsynqRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAsynqRepository) EXPECT() *MockAsynqRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTask mocks base method.
+func (m *MockAsynqRepository) CreateTask(ctx context.Context, event types.AsynqTaskType, payload any) (*asynq.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, event, payload)
+	ret0, _ := ret[0].(*asynq.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockAsynqRepositoryMockRecorder) CreateTask(ctx, event, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockAsynqRepository)(nil).CreateTask), ctx, event, payload)
+}
+
+// DequeueTask mocks base method.
+func (m *MockAsynqRepository) DequeueTask(ctx context.Context, queue, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DequeueTask", ctx, queue, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DequeueTask indicates an expected call of DequeueTask.
+func (mr *MockAsynqRepositoryMockRecorder) DequeueTask(ctx, queue, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DequeueTask", reflect.TypeOf((*MockAsynqRepository)(nil).DequeueTask), ctx, queue, taskID)
+}
+
+// EnqueueTask mocks base method.
+func (m *MockAsynqRepository) EnqueueTask(ctx context.Context, task *asynq.Task, customOpts *types.AsynqOption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueTask", ctx, task, customOpts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueTask indicates an expected call of EnqueueTask.
+func (mr *MockAsynqRepositoryMockRecorder) EnqueueTask(ctx, task, customOpts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueTask", reflect.TypeOf((*MockAsynqRepository)(nil).EnqueueTask), ctx, task, customOpts)
+}
+
+// MockAsynqInspectorRepository is a mock of AsynqInspectorRepository interface.
+type MockAsynqInspectorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAsynqInspectorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAsynqInspectorRepositoryMockRecorder is the mock recorder for MockAsynqInspectorRepository.
+type MockAsynqInspectorRepositoryMockRecorder struct {
+	mock *MockAsynqInspectorRepository
+}
+
+// NewMockAsynqInspectorRepository creates a new mock instance.
+func NewMockAsynqInspectorRepository(ctrl *gomock.Controller) *MockAsynqInspectorRepository {
+	mock := &MockAsynqInspectorRepository{ctrl: ctrl}
+	mock.recorder = &MockAsynqInspectorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAsynqInspectorRepository) EXPECT() *MockAsynqInspectorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyTaskAction mocks base method.
+func (m *MockAsynqInspectorRepository) ApplyTaskAction(queue, action, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTaskAction", queue, action, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyTaskAction indicates an expected call of ApplyTaskAction.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) ApplyTaskAction(queue, action, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTaskAction", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).ApplyTaskAction), queue, action, taskID)
+}
+
+// ApplyTaskActionToAll mocks base method.
+func (m *MockAsynqInspectorRepository) ApplyTaskActionToAll(queue, action, state string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTaskActionToAll", queue, action, state)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTaskActionToAll indicates an expected call of ApplyTaskActionToAll.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) ApplyTaskActionToAll(queue, action, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTaskActionToAll", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).ApplyTaskActionToAll), queue, action, state)
+}
+
+// ListQueues mocks base method.
+func (m *MockAsynqInspectorRepository) ListQueues() ([]*asynq.QueueInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueues")
+	ret0, _ := ret[0].([]*asynq.QueueInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueues indicates an expected call of ListQueues.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) ListQueues() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueues", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).ListQueues))
+}
+
+// ListTasks mocks base method.
+func (m *MockAsynqInspectorRepository) ListTasks(queue, state string, page, size int) ([]*asynq.TaskInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", queue, state, page, size)
+	ret0, _ := ret[0].([]*asynq.TaskInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) ListTasks(queue, state, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).ListTasks), queue, state, page, size)
+}
+
+// PauseQueue mocks base method.
+func (m *MockAsynqInspectorRepository) PauseQueue(queue string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseQueue", queue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseQueue indicates an expected call of PauseQueue.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) PauseQueue(queue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseQueue", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).PauseQueue), queue)
+}
+
+// UnpauseQueue mocks base method.
+func (m *MockAsynqInspectorRepository) UnpauseQueue(queue string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpauseQueue", queue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpauseQueue indicates an expected call of UnpauseQueue.
+func (mr *MockAsynqInspectorRepositoryMockRecorder) UnpauseQueue(queue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpauseQueue", reflect.TypeOf((*MockAsynqInspectorRepository)(nil).UnpauseQueue), queue)
+}
+
+// MockTaskAdminService is a mock of TaskAdminService interface.
+type MockTaskAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskAdminServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskAdminServiceMockRecorder is the mock recorder for MockTaskAdminService.
+type MockTaskAdminServiceMockRecorder struct {
+	mock *MockTaskAdminService
+}
+
+// NewMockTaskAdminService creates a new mock instance.
+func NewMockTaskAdminService(ctrl *gomock.Controller) *MockTaskAdminService {
+	mock := &MockTaskAdminService{ctrl: ctrl}
+	mock.recorder = &MockTaskAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskAdminService) EXPECT() *MockTaskAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyTaskAction mocks base method.
+func (m *MockTaskAdminService) ApplyTaskAction(ctx context.Context, req *types.TaskActionReq) (*types.TaskActionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTaskAction", ctx, req)
+	ret0, _ := ret[0].(*types.TaskActionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTaskAction indicates an expected call of ApplyTaskAction.
+func (mr *MockTaskAdminServiceMockRecorder) ApplyTaskAction(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTaskAction", reflect.TypeOf((*MockTaskAdminService)(nil).ApplyTaskAction), ctx, req)
+}
+
+// ListQueues mocks base method.
+func (m *MockTaskAdminService) ListQueues(ctx context.Context) ([]types.QueueInfoResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueues", ctx)
+	ret0, _ := ret[0].([]types.QueueInfoResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueues indicates an expected call of ListQueues.
+func (mr *MockTaskAdminServiceMockRecorder) ListQueues(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueues", reflect.TypeOf((*MockTaskAdminService)(nil).ListQueues), ctx)
+}
+
+// ListTasks mocks base method.
+func (m *MockTaskAdminService) ListTasks(ctx context.Context, req *types.ListTasksReq) ([]types.TaskInfoResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", ctx, req)
+	ret0, _ := ret[0].([]types.TaskInfoResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockTaskAdminServiceMockRecorder) ListTasks(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskAdminService)(nil).ListTasks), ctx, req)
+}
+
+// PauseQueue mocks base method.
+func (m *MockTaskAdminService) PauseQueue(ctx context.Context, queue string, pause bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseQueue", ctx, queue, pause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseQueue indicates an expected call of PauseQueue.
+func (mr *MockTaskAdminServiceMockRecorder) PauseQueue(ctx, queue, pause any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseQueue", reflect.TypeOf((*MockTaskAdminService)(nil).PauseQueue), ctx, queue, pause)
+}
+
+// MockAsynqService is a mock of AsynqService interface.
+type MockAsynqService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAsynqServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAsynqServiceMockRecorder is the mock recorder for MockAsynqService.
+type MockAsynqServiceMockRecorder struct {
+	mock *MockAsynqService
+}
+
+// NewMockAsynqService creates a new mock instance.
+func NewMockAsynqService(ctrl *gomock.Controller) *MockAsynqService {
+	mock := &MockAsynqService{ctrl: ctrl}
+	mock.recorder = &MockAsynqServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAsynqService) EXPECT() *MockAsynqServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateEventCancellationTask mocks base method.
+func (m *MockAsynqService) CreateEventCancellationTask(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventCancellationTask", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventCancellationTask indicates an expected call of CreateEventCancellationTask.
+func (mr *MockAsynqServiceMockRecorder) CreateEventCancellationTask(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventCancellationTask", reflect.TypeOf((*MockAsynqService)(nil).CreateEventCancellationTask), ctx, event)
+}
+
+// CreateEventCancellationTasks mocks base method.
+func (m *MockAsynqService) CreateEventCancellationTasks(ctx context.Context, eventID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventCancellationTasks", ctx, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventCancellationTasks indicates an expected call of CreateEventCancellationTasks.
+func (mr *MockAsynqServiceMockRecorder) CreateEventCancellationTasks(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventCancellationTasks", reflect.TypeOf((*MockAsynqService)(nil).CreateEventCancellationTasks), ctx, eventID)
+}
+
+// CreateEventReminderEmailTasks mocks base method.
+func (m *MockAsynqService) CreateEventReminderEmailTasks(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventReminderEmailTasks", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventReminderEmailTasks indicates an expected call of CreateEventReminderEmailTasks.
+func (mr *MockAsynqServiceMockRecorder) CreateEventReminderEmailTasks(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventReminderEmailTasks", reflect.TypeOf((*MockAsynqService)(nil).CreateEventReminderEmailTasks), ctx, event)
+}
+
+// CreateEventReminderTask mocks base method.
+func (m *MockAsynqService) CreateEventReminderTask(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventReminderTask", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventReminderTask indicates an expected call of CreateEventReminderTask.
+func (mr *MockAsynqServiceMockRecorder) CreateEventReminderTask(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventReminderTask", reflect.TypeOf((*MockAsynqService)(nil).CreateEventReminderTask), ctx, event)
+}
+
+// CreateInvitationBatchTask mocks base method.
+func (m *MockAsynqService) CreateInvitationBatchTask(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitationBatchTask", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitationBatchTask indicates an expected call of CreateInvitationBatchTask.
+func (mr *MockAsynqServiceMockRecorder) CreateInvitationBatchTask(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitationBatchTask", reflect.TypeOf((*MockAsynqService)(nil).CreateInvitationBatchTask), ctx, event)
+}
+
+// CreatePurgeTrashTask mocks base method.
+func (m *MockAsynqService) CreatePurgeTrashTask(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurgeTrashTask", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePurgeTrashTask indicates an expected call of CreatePurgeTrashTask.
+func (mr *MockAsynqServiceMockRecorder) CreatePurgeTrashTask(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurgeTrashTask", reflect.TypeOf((*MockAsynqService)(nil).CreatePurgeTrashTask), ctx, before)
+}
+
+// InviteUsers mocks base method.
+func (m *MockAsynqService) InviteUsers(ctx context.Context, event *models.Event, users []models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteUsers", ctx, event, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteUsers indicates an expected call of InviteUsers.
+func (mr *MockAsynqServiceMockRecorder) InviteUsers(ctx, event, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUsers", reflect.TypeOf((*MockAsynqService)(nil).InviteUsers), ctx, event, users)
+}
+
+// ProcessInvitationBatch mocks base method.
+func (m *MockAsynqService) ProcessInvitationBatch(ctx context.Context, eventID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessInvitationBatch", ctx, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessInvitationBatch indicates an expected call of ProcessInvitationBatch.
+func (mr *MockAsynqServiceMockRecorder) ProcessInvitationBatch(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessInvitationBatch", reflect.TypeOf((*MockAsynqService)(nil).ProcessInvitationBatch), ctx, eventID)
+}
+
+// ReadInvitationProgress mocks base method.
+func (m *MockAsynqService) ReadInvitationProgress(ctx context.Context, eventID int) (*types.InvitationProgressResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInvitationProgress", ctx, eventID)
+	ret0, _ := ret[0].(*types.InvitationProgressResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInvitationProgress indicates an expected call of ReadInvitationProgress.
+func (mr *MockAsynqServiceMockRecorder) ReadInvitationProgress(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInvitationProgress", reflect.TypeOf((*MockAsynqService)(nil).ReadInvitationProgress), ctx, eventID)
+}
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "github.com/vivasoft-ltd/go-ems/models"
@@ -41,69 +42,159 @@ func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelEvent mocks base method.
+func (m *MockEventRepository) CancelEvent(ctx context.Context, event *models.Event, reason string) (*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", ctx, event, reason)
+	ret0, _ := ret[0].(*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockEventRepositoryMockRecorder) CancelEvent(ctx, event, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockEventRepository)(nil).CancelEvent), ctx, event, reason)
+}
+
+// CountEvents mocks base method.
+func (m *MockEventRepository) CountEvents(ctx context.Context, filter *types.EventFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEvents", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEvents indicates an expected call of CountEvents.
+func (mr *MockEventRepositoryMockRecorder) CountEvents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEvents", reflect.TypeOf((*MockEventRepository)(nil).CountEvents), ctx, filter)
+}
+
 // CreateEvent mocks base method.
-func (m *MockEventRepository) CreateEvent(event *models.Event) (*models.Event, error) {
+func (m *MockEventRepository) CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvent", event)
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, event)
 	ret0, _ := ret[0].(*models.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateEvent indicates an expected call of CreateEvent.
-func (mr *MockEventRepositoryMockRecorder) CreateEvent(event any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) CreateEvent(ctx, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventRepository)(nil).CreateEvent), event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventRepository)(nil).CreateEvent), ctx, event)
 }
 
 // DeleteEvent mocks base method.
-func (m *MockEventRepository) DeleteEvent(id int) error {
+func (m *MockEventRepository) DeleteEvent(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEvent", id)
+	ret := m.ctrl.Call(m, "DeleteEvent", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEvent indicates an expected call of DeleteEvent.
-func (mr *MockEventRepositoryMockRecorder) DeleteEvent(id any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) DeleteEvent(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventRepository)(nil).DeleteEvent), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventRepository)(nil).DeleteEvent), ctx, id, version)
 }
 
 // GetAcceptedEventAttendees mocks base method.
-func (m *MockEventRepository) GetAcceptedEventAttendees(eventID int) ([]models.EventAttendee, error) {
+func (m *MockEventRepository) GetAcceptedEventAttendees(ctx context.Context, eventID int) ([]models.EventAttendee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAcceptedEventAttendees", eventID)
+	ret := m.ctrl.Call(m, "GetAcceptedEventAttendees", ctx, eventID)
 	ret0, _ := ret[0].([]models.EventAttendee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAcceptedEventAttendees indicates an expected call of GetAcceptedEventAttendees.
-func (mr *MockEventRepositoryMockRecorder) GetAcceptedEventAttendees(eventID any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) GetAcceptedEventAttendees(ctx, eventID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptedEventAttendees", reflect.TypeOf((*MockEventRepository)(nil).GetAcceptedEventAttendees), eventID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptedEventAttendees", reflect.TypeOf((*MockEventRepository)(nil).GetAcceptedEventAttendees), ctx, eventID)
 }
 
 // GetEventAttendeesCount mocks base method.
-func (m *MockEventRepository) GetEventAttendeesCount(eventID int) (int, error) {
+func (m *MockEventRepository) GetEventAttendeesCount(ctx context.Context, eventID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEventAttendeesCount", eventID)
+	ret := m.ctrl.Call(m, "GetEventAttendeesCount", ctx, eventID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEventAttendeesCount indicates an expected call of GetEventAttendeesCount.
-func (mr *MockEventRepositoryMockRecorder) GetEventAttendeesCount(eventID any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) GetEventAttendeesCount(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventAttendeesCount", reflect.TypeOf((*MockEventRepository)(nil).GetEventAttendeesCount), ctx, eventID)
+}
+
+// ListEventAttendees mocks base method.
+func (m *MockEventRepository) ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventAttendees", ctx, eventID)
+	ret0, _ := ret[0].([]types.EventAttendeeResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventAttendees indicates an expected call of ListEventAttendees.
+func (mr *MockEventRepositoryMockRecorder) ListEventAttendees(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventAttendees", reflect.TypeOf((*MockEventRepository)(nil).ListEventAttendees), ctx, eventID)
+}
+
+// ListEventInvitees mocks base method.
+func (m *MockEventRepository) ListEventInvitees(ctx context.Context, eventID, afterUserID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventInvitees", ctx, eventID, afterUserID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventInvitees indicates an expected call of ListEventInvitees.
+func (mr *MockEventRepositoryMockRecorder) ListEventInvitees(ctx, eventID, afterUserID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventInvitees", reflect.TypeOf((*MockEventRepository)(nil).ListEventInvitees), ctx, eventID, afterUserID, limit)
+}
+
+// ListEventReviews mocks base method.
+func (m *MockEventRepository) ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventReviews", ctx, eventID)
+	ret0, _ := ret[0].([]models.EventReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventReviews indicates an expected call of ListEventReviews.
+func (mr *MockEventRepositoryMockRecorder) ListEventReviews(ctx, eventID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventAttendeesCount", reflect.TypeOf((*MockEventRepository)(nil).GetEventAttendeesCount), eventID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventReviews", reflect.TypeOf((*MockEventRepository)(nil).ListEventReviews), ctx, eventID)
+}
+
+// ListEventUsersByStatus mocks base method.
+func (m *MockEventRepository) ListEventUsersByStatus(ctx context.Context, eventID int, statusIDs []int, afterUserID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventUsersByStatus", ctx, eventID, statusIDs, afterUserID, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventUsersByStatus indicates an expected call of ListEventUsersByStatus.
+func (mr *MockEventRepositoryMockRecorder) ListEventUsersByStatus(ctx, eventID, statusIDs, afterUserID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventUsersByStatus", reflect.TypeOf((*MockEventRepository)(nil).ListEventUsersByStatus), ctx, eventID, statusIDs, afterUserID, limit)
 }
 
 // ListEvents mocks base method.
-func (m *MockEventRepository) ListEvents(filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error) {
+func (m *MockEventRepository) ListEvents(ctx context.Context, filter *types.EventFilter, limit, offset int) ([]*models.Event, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEvents", filter, limit, offset)
+	ret := m.ctrl.Call(m, "ListEvents", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]*models.Event)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -111,68 +202,195 @@ func (m *MockEventRepository) ListEvents(filter *types.EventFilter, limit, offse
 }
 
 // ListEvents indicates an expected call of ListEvents.
-func (mr *MockEventRepositoryMockRecorder) ListEvents(filter, limit, offset any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) ListEvents(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventRepository)(nil).ListEvents), ctx, filter, limit, offset)
+}
+
+// ListEventsAfter mocks base method.
+func (m *MockEventRepository) ListEventsAfter(ctx context.Context, filter *types.EventFilter, cursor *types.Cursor, limit int) ([]*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsAfter", ctx, filter, cursor, limit)
+	ret0, _ := ret[0].([]*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsAfter indicates an expected call of ListEventsAfter.
+func (mr *MockEventRepositoryMockRecorder) ListEventsAfter(ctx, filter, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventRepository)(nil).ListEvents), filter, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsAfter", reflect.TypeOf((*MockEventRepository)(nil).ListEventsAfter), ctx, filter, cursor, limit)
 }
 
 // ReadEventByID mocks base method.
-func (m *MockEventRepository) ReadEventByID(id int) (*models.Event, error) {
+func (m *MockEventRepository) ReadEventByID(ctx context.Context, id int) (*models.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEventByID", id)
+	ret := m.ctrl.Call(m, "ReadEventByID", ctx, id)
 	ret0, _ := ret[0].(*models.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEventByID indicates an expected call of ReadEventByID.
-func (mr *MockEventRepositoryMockRecorder) ReadEventByID(id any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) ReadEventByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventByID", reflect.TypeOf((*MockEventRepository)(nil).ReadEventByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventByID", reflect.TypeOf((*MockEventRepository)(nil).ReadEventByID), ctx, id)
 }
 
 // ReadEventInvitation mocks base method.
-func (m *MockEventRepository) ReadEventInvitation(eventID, userID int) (*models.EventAttendee, error) {
+func (m *MockEventRepository) ReadEventInvitation(ctx context.Context, eventID, userID int) (*models.EventAttendee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEventInvitation", eventID, userID)
+	ret := m.ctrl.Call(m, "ReadEventInvitation", ctx, eventID, userID)
 	ret0, _ := ret[0].(*models.EventAttendee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEventInvitation indicates an expected call of ReadEventInvitation.
-func (mr *MockEventRepositoryMockRecorder) ReadEventInvitation(eventID, userID any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) ReadEventInvitation(ctx, eventID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventInvitation", reflect.TypeOf((*MockEventRepository)(nil).ReadEventInvitation), ctx, eventID, userID)
+}
+
+// ReadEventStatus mocks base method.
+func (m *MockEventRepository) ReadEventStatus(ctx context.Context, id int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEventStatus", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEventStatus indicates an expected call of ReadEventStatus.
+func (mr *MockEventRepositoryMockRecorder) ReadEventStatus(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventInvitation", reflect.TypeOf((*MockEventRepository)(nil).ReadEventInvitation), eventID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventStatus", reflect.TypeOf((*MockEventRepository)(nil).ReadEventStatus), ctx, id)
+}
+
+// ReplaceEvent mocks base method.
+func (m *MockEventRepository) ReplaceEvent(ctx context.Context, event *models.Event, addedAttendees, removedAttendees []int) (*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceEvent", ctx, event, addedAttendees, removedAttendees)
+	ret0, _ := ret[0].(*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceEvent indicates an expected call of ReplaceEvent.
+func (mr *MockEventRepositoryMockRecorder) ReplaceEvent(ctx, event, addedAttendees, removedAttendees any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceEvent", reflect.TypeOf((*MockEventRepository)(nil).ReplaceEvent), ctx, event, addedAttendees, removedAttendees)
 }
 
 // UpdateEvent mocks base method.
-func (m *MockEventRepository) UpdateEvent(event *models.Event) (*models.Event, error) {
+func (m *MockEventRepository) UpdateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEvent", event)
+	ret := m.ctrl.Call(m, "UpdateEvent", ctx, event)
 	ret0, _ := ret[0].(*models.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateEvent indicates an expected call of UpdateEvent.
-func (mr *MockEventRepositoryMockRecorder) UpdateEvent(event any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) UpdateEvent(ctx, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEventRepository)(nil).UpdateEvent), event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEventRepository)(nil).UpdateEvent), ctx, event)
+}
+
+// UpdateEventStatus mocks base method.
+func (m *MockEventRepository) UpdateEventStatus(ctx context.Context, event *models.Event, status string, review *models.EventReview) (*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventStatus", ctx, event, status, review)
+	ret0, _ := ret[0].(*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEventStatus indicates an expected call of UpdateEventStatus.
+func (mr *MockEventRepositoryMockRecorder) UpdateEventStatus(ctx, event, status, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventStatus", reflect.TypeOf((*MockEventRepository)(nil).UpdateEventStatus), ctx, event, status, review)
 }
 
 // UpsertEventInvitation mocks base method.
-func (m *MockEventRepository) UpsertEventInvitation(event *models.EventAttendee) error {
+func (m *MockEventRepository) UpsertEventInvitation(ctx context.Context, event *models.EventAttendee) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertEventInvitation", event)
+	ret := m.ctrl.Call(m, "UpsertEventInvitation", ctx, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertEventInvitation indicates an expected call of UpsertEventInvitation.
-func (mr *MockEventRepositoryMockRecorder) UpsertEventInvitation(event any) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) UpsertEventInvitation(ctx, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEventInvitation", reflect.TypeOf((*MockEventRepository)(nil).UpsertEventInvitation), event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEventInvitation", reflect.TypeOf((*MockEventRepository)(nil).UpsertEventInvitation), ctx, event)
+}
+
+// MockInvitationBatchRepository is a mock of InvitationBatchRepository interface.
+type MockInvitationBatchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationBatchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInvitationBatchRepositoryMockRecorder is the mock recorder for MockInvitationBatchRepository.
+type MockInvitationBatchRepositoryMockRecorder struct {
+	mock *MockInvitationBatchRepository
+}
+
+// NewMockInvitationBatchRepository creates a new mock instance.
+func NewMockInvitationBatchRepository(ctrl *gomock.Controller) *MockInvitationBatchRepository {
+	mock := &MockInvitationBatchRepository{ctrl: ctrl}
+	mock.recorder = &MockInvitationBatchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationBatchRepository) EXPECT() *MockInvitationBatchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateInvitationBatch mocks base method.
+func (m *MockInvitationBatchRepository) CreateInvitationBatch(ctx context.Context, batch *models.InvitationBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitationBatch", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitationBatch indicates an expected call of CreateInvitationBatch.
+func (mr *MockInvitationBatchRepositoryMockRecorder) CreateInvitationBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitationBatch", reflect.TypeOf((*MockInvitationBatchRepository)(nil).CreateInvitationBatch), ctx, batch)
+}
+
+// ReadInvitationBatch mocks base method.
+func (m *MockInvitationBatchRepository) ReadInvitationBatch(ctx context.Context, eventID int) (*models.InvitationBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInvitationBatch", ctx, eventID)
+	ret0, _ := ret[0].(*models.InvitationBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInvitationBatch indicates an expected call of ReadInvitationBatch.
+func (mr *MockInvitationBatchRepositoryMockRecorder) ReadInvitationBatch(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInvitationBatch", reflect.TypeOf((*MockInvitationBatchRepository)(nil).ReadInvitationBatch), ctx, eventID)
+}
+
+// UpdateInvitationBatch mocks base method.
+func (m *MockInvitationBatchRepository) UpdateInvitationBatch(ctx context.Context, eventID int, updates map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvitationBatch", ctx, eventID, updates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInvitationBatch indicates an expected call of UpdateInvitationBatch.
+func (mr *MockInvitationBatchRepositoryMockRecorder) UpdateInvitationBatch(ctx, eventID, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitationBatch", reflect.TypeOf((*MockInvitationBatchRepository)(nil).UpdateInvitationBatch), ctx, eventID, updates)
 }
 
 // MockEventService is a mock of EventService interface.
@@ -199,91 +417,211 @@ func (m *MockEventService) EXPECT() *MockEventServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelEvent mocks base method.
+func (m *MockEventService) CancelEvent(ctx context.Context, req *types.CancelEventRequest) (*types.CancelEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", ctx, req)
+	ret0, _ := ret[0].(*types.CancelEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockEventServiceMockRecorder) CancelEvent(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockEventService)(nil).CancelEvent), ctx, req)
+}
+
+// CompleteEvent mocks base method.
+func (m *MockEventService) CompleteEvent(ctx context.Context, req *types.EventStatusRequest) (*types.UpdateEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteEvent", ctx, req)
+	ret0, _ := ret[0].(*types.UpdateEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteEvent indicates an expected call of CompleteEvent.
+func (mr *MockEventServiceMockRecorder) CompleteEvent(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteEvent", reflect.TypeOf((*MockEventService)(nil).CompleteEvent), ctx, req)
+}
+
 // CreateEvent mocks base method.
-func (m *MockEventService) CreateEvent(eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error) {
+func (m *MockEventService) CreateEvent(ctx context.Context, eventReq *types.CreateEventRequest) (*types.CreateEventResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvent", eventReq)
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, eventReq)
 	ret0, _ := ret[0].(*types.CreateEventResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateEvent indicates an expected call of CreateEvent.
-func (mr *MockEventServiceMockRecorder) CreateEvent(eventReq any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) CreateEvent(ctx, eventReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventService)(nil).CreateEvent), eventReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventService)(nil).CreateEvent), ctx, eventReq)
 }
 
 // DeleteEvent mocks base method.
-func (m *MockEventService) DeleteEvent(id int) (*types.DeleteEventResponse, error) {
+func (m *MockEventService) DeleteEvent(ctx context.Context, id, version int) (*types.DeleteEventResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEvent", id)
+	ret := m.ctrl.Call(m, "DeleteEvent", ctx, id, version)
 	ret0, _ := ret[0].(*types.DeleteEventResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteEvent indicates an expected call of DeleteEvent.
-func (mr *MockEventServiceMockRecorder) DeleteEvent(id any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) DeleteEvent(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventService)(nil).DeleteEvent), ctx, id, version)
+}
+
+// ListEventAttendees mocks base method.
+func (m *MockEventService) ListEventAttendees(ctx context.Context, eventID int) ([]types.EventAttendeeResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventAttendees", ctx, eventID)
+	ret0, _ := ret[0].([]types.EventAttendeeResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventAttendees indicates an expected call of ListEventAttendees.
+func (mr *MockEventServiceMockRecorder) ListEventAttendees(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventAttendees", reflect.TypeOf((*MockEventService)(nil).ListEventAttendees), ctx, eventID)
+}
+
+// ListEventReviews mocks base method.
+func (m *MockEventService) ListEventReviews(ctx context.Context, eventID int) ([]models.EventReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventReviews", ctx, eventID)
+	ret0, _ := ret[0].([]models.EventReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventReviews indicates an expected call of ListEventReviews.
+func (mr *MockEventServiceMockRecorder) ListEventReviews(ctx, eventID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventService)(nil).DeleteEvent), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventReviews", reflect.TypeOf((*MockEventService)(nil).ListEventReviews), ctx, eventID)
 }
 
 // ListEvents mocks base method.
-func (m *MockEventService) ListEvents(req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error) {
+func (m *MockEventService) ListEvents(ctx context.Context, req types.ListEventRequest, user *types.CurrentUser) (*types.PaginatedEventResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEvents", req, user)
+	ret := m.ctrl.Call(m, "ListEvents", ctx, req, user)
 	ret0, _ := ret[0].(*types.PaginatedEventResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListEvents indicates an expected call of ListEvents.
-func (mr *MockEventServiceMockRecorder) ListEvents(req, user any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) ListEvents(ctx, req, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventService)(nil).ListEvents), ctx, req, user)
+}
+
+// ListEventsByCursor mocks base method.
+func (m *MockEventService) ListEventsByCursor(ctx context.Context, req types.ListEventRequest, cursor *types.Cursor, user *types.CurrentUser) (*types.CursorEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsByCursor", ctx, req, cursor, user)
+	ret0, _ := ret[0].(*types.CursorEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsByCursor indicates an expected call of ListEventsByCursor.
+func (mr *MockEventServiceMockRecorder) ListEventsByCursor(ctx, req, cursor, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventService)(nil).ListEvents), req, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsByCursor", reflect.TypeOf((*MockEventService)(nil).ListEventsByCursor), ctx, req, cursor, user)
+}
+
+// PatchEvent mocks base method.
+func (m *MockEventService) PatchEvent(ctx context.Context, req *types.PatchEventRequest) (*types.PatchEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchEvent", ctx, req)
+	ret0, _ := ret[0].(*types.PatchEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchEvent indicates an expected call of PatchEvent.
+func (mr *MockEventServiceMockRecorder) PatchEvent(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchEvent", reflect.TypeOf((*MockEventService)(nil).PatchEvent), ctx, req)
+}
+
+// PublishEvent mocks base method.
+func (m *MockEventService) PublishEvent(ctx context.Context, req *types.EventStatusRequest, user *types.CurrentUser) (*types.UpdateEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEvent", ctx, req, user)
+	ret0, _ := ret[0].(*types.UpdateEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishEvent indicates an expected call of PublishEvent.
+func (mr *MockEventServiceMockRecorder) PublishEvent(ctx, req, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockEventService)(nil).PublishEvent), ctx, req, user)
 }
 
 // ReadEventByID mocks base method.
-func (m *MockEventService) ReadEventByID(id int) (*models.Event, error) {
+func (m *MockEventService) ReadEventByID(ctx context.Context, id int) (*models.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEventByID", id)
+	ret := m.ctrl.Call(m, "ReadEventByID", ctx, id)
 	ret0, _ := ret[0].(*models.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEventByID indicates an expected call of ReadEventByID.
-func (mr *MockEventServiceMockRecorder) ReadEventByID(id any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) ReadEventByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventByID", reflect.TypeOf((*MockEventService)(nil).ReadEventByID), ctx, id)
+}
+
+// ReviewEvent mocks base method.
+func (m *MockEventService) ReviewEvent(ctx context.Context, req *types.EventReviewRequest) (*types.UpdateEventResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewEvent", ctx, req)
+	ret0, _ := ret[0].(*types.UpdateEventResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewEvent indicates an expected call of ReviewEvent.
+func (mr *MockEventServiceMockRecorder) ReviewEvent(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEventByID", reflect.TypeOf((*MockEventService)(nil).ReadEventByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewEvent", reflect.TypeOf((*MockEventService)(nil).ReviewEvent), ctx, req)
 }
 
 // RsvpEvent mocks base method.
-func (m *MockEventService) RsvpEvent(request types.RsvpEventRequest) error {
+func (m *MockEventService) RsvpEvent(ctx context.Context, request types.RsvpEventRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RsvpEvent", request)
+	ret := m.ctrl.Call(m, "RsvpEvent", ctx, request)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RsvpEvent indicates an expected call of RsvpEvent.
-func (mr *MockEventServiceMockRecorder) RsvpEvent(request any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) RsvpEvent(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RsvpEvent", reflect.TypeOf((*MockEventService)(nil).RsvpEvent), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RsvpEvent", reflect.TypeOf((*MockEventService)(nil).RsvpEvent), ctx, request)
 }
 
 // UpdateEvent mocks base method.
-func (m *MockEventService) UpdateEvent(eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error) {
+func (m *MockEventService) UpdateEvent(ctx context.Context, eventReq *types.UpdateEventRequest) (*types.UpdateEventResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEvent", eventReq)
+	ret := m.ctrl.Call(m, "UpdateEvent", ctx, eventReq)
 	ret0, _ := ret[0].(*types.UpdateEventResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateEvent indicates an expected call of UpdateEvent.
-func (mr *MockEventServiceMockRecorder) UpdateEvent(eventReq any) *gomock.Call {
+func (mr *MockEventServiceMockRecorder) UpdateEvent(ctx, eventReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEventService)(nil).UpdateEvent), eventReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEventService)(nil).UpdateEvent), ctx, eventReq)
 }
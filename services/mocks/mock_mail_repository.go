@@ -0,0 +1,263 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/mail.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/mail.go -destination=services/mocks/mock_mail_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/vivasoft-ltd/go-ems/models"
+	types "github.com/vivasoft-ltd/go-ems/types"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMailService is a mock of MailService interface.
+type MockMailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMailServiceMockRecorder is the mock recorder for MockMailService.
+type MockMailServiceMockRecorder struct {
+	mock *MockMailService
+}
+
+// NewMockMailService creates a new mock instance.
+func NewMockMailService(ctrl *gomock.Controller) *MockMailService {
+	mock := &MockMailService{ctrl: ctrl}
+	mock.recorder = &MockMailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailService) EXPECT() *MockMailServiceMockRecorder {
+	return m.recorder
+}
+
+// EnqueueEventReminderEmailNotification mocks base method.
+func (m *MockMailService) EnqueueEventReminderEmailNotification(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueEventReminderEmailNotification", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueEventReminderEmailNotification indicates an expected call of EnqueueEventReminderEmailNotification.
+func (mr *MockMailServiceMockRecorder) EnqueueEventReminderEmailNotification(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueEventReminderEmailNotification", reflect.TypeOf((*MockMailService)(nil).EnqueueEventReminderEmailNotification), ctx, event)
+}
+
+// HandleDeliveryEvent mocks base method.
+func (m *MockMailService) HandleDeliveryEvent(ctx context.Context, req *types.EmailDeliveryEventReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeliveryEvent", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleDeliveryEvent indicates an expected call of HandleDeliveryEvent.
+func (mr *MockMailServiceMockRecorder) HandleDeliveryEvent(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeliveryEvent", reflect.TypeOf((*MockMailService)(nil).HandleDeliveryEvent), ctx, req)
+}
+
+// SendEmail mocks base method.
+func (m *MockMailService) SendEmail(ctx context.Context, reqData types.EmailPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, reqData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockMailServiceMockRecorder) SendEmail(ctx, reqData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockMailService)(nil).SendEmail), ctx, reqData)
+}
+
+// SendInvitationEmail mocks base method.
+func (m *MockMailService) SendInvitationEmail(ctx context.Context, userIds []int, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvitationEmail", ctx, userIds, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInvitationEmail indicates an expected call of SendInvitationEmail.
+func (mr *MockMailServiceMockRecorder) SendInvitationEmail(ctx, userIds, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvitationEmail", reflect.TypeOf((*MockMailService)(nil).SendInvitationEmail), ctx, userIds, event)
+}
+
+// MockMailRepository is a mock of MailRepository interface.
+type MockMailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMailRepositoryMockRecorder is the mock recorder for MockMailRepository.
+type MockMailRepositoryMockRecorder struct {
+	mock *MockMailRepository
+}
+
+// NewMockMailRepository creates a new mock instance.
+func NewMockMailRepository(ctrl *gomock.Controller) *MockMailRepository {
+	mock := &MockMailRepository{ctrl: ctrl}
+	mock.recorder = &MockMailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailRepository) EXPECT() *MockMailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SendEmail mocks base method.
+func (m *MockMailRepository) SendEmail(ctx context.Context, reqData *types.EmailPayload) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, reqData)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockMailRepositoryMockRecorder) SendEmail(ctx, reqData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockMailRepository)(nil).SendEmail), ctx, reqData)
+}
+
+// MockEmailDeliveryRepository is a mock of EmailDeliveryRepository interface.
+type MockEmailDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailDeliveryRepositoryMockRecorder is the mock recorder for MockEmailDeliveryRepository.
+type MockEmailDeliveryRepositoryMockRecorder struct {
+	mock *MockEmailDeliveryRepository
+}
+
+// NewMockEmailDeliveryRepository creates a new mock instance.
+func NewMockEmailDeliveryRepository(ctrl *gomock.Controller) *MockEmailDeliveryRepository {
+	mock := &MockEmailDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailDeliveryRepository) EXPECT() *MockEmailDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountEmailDeliveriesByStatus mocks base method.
+func (m *MockEmailDeliveryRepository) CountEmailDeliveriesByStatus(ctx context.Context, eventID int, taskType string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEmailDeliveriesByStatus", ctx, eventID, taskType)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEmailDeliveriesByStatus indicates an expected call of CountEmailDeliveriesByStatus.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) CountEmailDeliveriesByStatus(ctx, eventID, taskType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEmailDeliveriesByStatus", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).CountEmailDeliveriesByStatus), ctx, eventID, taskType)
+}
+
+// CreateEmailDelivery mocks base method.
+func (m *MockEmailDeliveryRepository) CreateEmailDelivery(ctx context.Context, delivery *models.EmailDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailDelivery indicates an expected call of CreateEmailDelivery.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) CreateEmailDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailDelivery", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).CreateEmailDelivery), ctx, delivery)
+}
+
+// DeleteEmailDelivery mocks base method.
+func (m *MockEmailDeliveryRepository) DeleteEmailDelivery(ctx context.Context, messageID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailDelivery", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailDelivery indicates an expected call of DeleteEmailDelivery.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) DeleteEmailDelivery(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailDelivery", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).DeleteEmailDelivery), ctx, messageID)
+}
+
+// IsEmailSuppressed mocks base method.
+func (m *MockEmailDeliveryRepository) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmailSuppressed", ctx, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmailSuppressed indicates an expected call of IsEmailSuppressed.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) IsEmailSuppressed(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailSuppressed", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).IsEmailSuppressed), ctx, email)
+}
+
+// ReadEmailDelivery mocks base method.
+func (m *MockEmailDeliveryRepository) ReadEmailDelivery(ctx context.Context, messageID, providerMessageID string) (*models.EmailDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEmailDelivery", ctx, messageID, providerMessageID)
+	ret0, _ := ret[0].(*models.EmailDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEmailDelivery indicates an expected call of ReadEmailDelivery.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) ReadEmailDelivery(ctx, messageID, providerMessageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEmailDelivery", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).ReadEmailDelivery), ctx, messageID, providerMessageID)
+}
+
+// SuppressEmail mocks base method.
+func (m *MockEmailDeliveryRepository) SuppressEmail(ctx context.Context, suppression *models.EmailSuppression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuppressEmail", ctx, suppression)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuppressEmail indicates an expected call of SuppressEmail.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) SuppressEmail(ctx, suppression any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuppressEmail", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).SuppressEmail), ctx, suppression)
+}
+
+// UpdateEmailDelivery mocks base method.
+func (m *MockEmailDeliveryRepository) UpdateEmailDelivery(ctx context.Context, messageID string, updates map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailDelivery", ctx, messageID, updates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailDelivery indicates an expected call of UpdateEmailDelivery.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) UpdateEmailDelivery(ctx, messageID, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailDelivery", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).UpdateEmailDelivery), ctx, messageID, updates)
+}
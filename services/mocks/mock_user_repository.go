@@ -10,7 +10,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/vivasoft-ltd/go-ems/models"
 	types "github.com/vivasoft-ltd/go-ems/types"
@@ -42,134 +44,205 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 }
 
 // CreateUser mocks base method.
-func (m *MockUserService) CreateUser(req *types.CreateUserReq) error {
+func (m *MockUserService) CreateUser(ctx context.Context, req *types.CreateUserReq) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", req)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserServiceMockRecorder) CreateUser(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) CreateUser(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), ctx, req)
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserService) DeleteUser(id int) error {
+func (m *MockUserService) DeleteUser(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceMockRecorder) DeleteUser(id any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id, version)
 }
 
 // ListAttendees mocks base method.
-func (m *MockUserService) ListAttendees(user types.CurrentUser) ([]types.AttendeeResp, error) {
+func (m *MockUserService) ListAttendees(ctx context.Context, user types.CurrentUser) ([]types.AttendeeResp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAttendees", user)
+	ret := m.ctrl.Call(m, "ListAttendees", ctx, user)
 	ret0, _ := ret[0].([]types.AttendeeResp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAttendees indicates an expected call of ListAttendees.
-func (mr *MockUserServiceMockRecorder) ListAttendees(user any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ListAttendees(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendees", reflect.TypeOf((*MockUserService)(nil).ListAttendees), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendees", reflect.TypeOf((*MockUserService)(nil).ListAttendees), ctx, user)
 }
 
 // ListUsers mocks base method.
-func (m *MockUserService) ListUsers(req types.ListUserReq) (*types.PaginatedUserResp, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, req types.ListUserReq) (*types.PaginatedUserResp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", req)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, req)
 	ret0, _ := ret[0].(*types.PaginatedUserResp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserServiceMockRecorder) ListUsers(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ListUsers(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, req)
+}
+
+// ListUsersByCursor mocks base method.
+func (m *MockUserService) ListUsersByCursor(ctx context.Context, req types.ListUserReq, cursor *types.Cursor) (*types.CursorUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByCursor", ctx, req, cursor)
+	ret0, _ := ret[0].(*types.CursorUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByCursor indicates an expected call of ListUsersByCursor.
+func (mr *MockUserServiceMockRecorder) ListUsersByCursor(ctx, req, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByCursor", reflect.TypeOf((*MockUserService)(nil).ListUsersByCursor), ctx, req, cursor)
 }
 
 // ReadPermissionsByRole mocks base method.
-func (m *MockUserService) ReadPermissionsByRole(roleID int) ([]*models.Permission, error) {
+func (m *MockUserService) ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadPermissionsByRole", roleID)
+	ret := m.ctrl.Call(m, "ReadPermissionsByRole", ctx, roleID)
 	ret0, _ := ret[0].([]*models.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadPermissionsByRole indicates an expected call of ReadPermissionsByRole.
-func (mr *MockUserServiceMockRecorder) ReadPermissionsByRole(roleID any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ReadPermissionsByRole(ctx, roleID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPermissionsByRole", reflect.TypeOf((*MockUserService)(nil).ReadPermissionsByRole), roleID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPermissionsByRole", reflect.TypeOf((*MockUserService)(nil).ReadPermissionsByRole), ctx, roleID)
 }
 
 // ReadUser mocks base method.
-func (m *MockUserService) ReadUser(id int, fromCache bool) (*types.UserInfo, error) {
+func (m *MockUserService) ReadUser(ctx context.Context, id int, fromCache bool) (*types.UserInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUser", id, fromCache)
+	ret := m.ctrl.Call(m, "ReadUser", ctx, id, fromCache)
 	ret0, _ := ret[0].(*types.UserInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUser indicates an expected call of ReadUser.
-func (mr *MockUserServiceMockRecorder) ReadUser(id, fromCache any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ReadUser(ctx, id, fromCache any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUser", reflect.TypeOf((*MockUserService)(nil).ReadUser), id, fromCache)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUser", reflect.TypeOf((*MockUserService)(nil).ReadUser), ctx, id, fromCache)
 }
 
 // ReadUserByEmail mocks base method.
-func (m *MockUserService) ReadUserByEmail(email string) (*models.User, error) {
+func (m *MockUserService) ReadUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserByEmail", email)
+	ret := m.ctrl.Call(m, "ReadUserByEmail", ctx, email)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUserByEmail indicates an expected call of ReadUserByEmail.
-func (mr *MockUserServiceMockRecorder) ReadUserByEmail(email any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ReadUserByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmail", reflect.TypeOf((*MockUserService)(nil).ReadUserByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmail", reflect.TypeOf((*MockUserService)(nil).ReadUserByEmail), ctx, email)
+}
+
+// RequestPhoneVerification mocks base method.
+func (m *MockUserService) RequestPhoneVerification(ctx context.Context, userID int, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPhoneVerification", ctx, userID, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPhoneVerification indicates an expected call of RequestPhoneVerification.
+func (mr *MockUserServiceMockRecorder) RequestPhoneVerification(ctx, userID, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPhoneVerification", reflect.TypeOf((*MockUserService)(nil).RequestPhoneVerification), ctx, userID, phone)
 }
 
 // StoreInCache mocks base method.
-func (m *MockUserService) StoreInCache(user *types.UserInfo) error {
+func (m *MockUserService) StoreInCache(ctx context.Context, user *types.UserInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreInCache", user)
+	ret := m.ctrl.Call(m, "StoreInCache", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StoreInCache indicates an expected call of StoreInCache.
-func (mr *MockUserServiceMockRecorder) StoreInCache(user any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) StoreInCache(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreInCache", reflect.TypeOf((*MockUserService)(nil).StoreInCache), ctx, user)
+}
+
+// UpdateNotificationChannels mocks base method.
+func (m *MockUserService) UpdateNotificationChannels(ctx context.Context, userID int, req *types.UpdateNotificationChannelsReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationChannels", ctx, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationChannels indicates an expected call of UpdateNotificationChannels.
+func (mr *MockUserServiceMockRecorder) UpdateNotificationChannels(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationChannels", reflect.TypeOf((*MockUserService)(nil).UpdateNotificationChannels), ctx, userID, req)
+}
+
+// UpdateTimezone mocks base method.
+func (m *MockUserService) UpdateTimezone(ctx context.Context, userID int, req *types.UpdateTimezoneReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimezone", ctx, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTimezone indicates an expected call of UpdateTimezone.
+func (mr *MockUserServiceMockRecorder) UpdateTimezone(ctx, userID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreInCache", reflect.TypeOf((*MockUserService)(nil).StoreInCache), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimezone", reflect.TypeOf((*MockUserService)(nil).UpdateTimezone), ctx, userID, req)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserService) UpdateUser(req *types.UpdateUserReq) error {
+func (m *MockUserService) UpdateUser(ctx context.Context, req *types.UpdateUserReq) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", req)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserServiceMockRecorder) UpdateUser(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) UpdateUser(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), ctx, req)
+}
+
+// VerifyPhone mocks base method.
+func (m *MockUserService) VerifyPhone(ctx context.Context, userID int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPhone", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyPhone indicates an expected call of VerifyPhone.
+func (mr *MockUserServiceMockRecorder) VerifyPhone(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhone", reflect.TypeOf((*MockUserService)(nil).VerifyPhone), ctx, userID, code)
 }
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -196,54 +269,69 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountUsers mocks base method.
+func (m *MockUserRepository) CountUsers(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserRepositoryMockRecorder) CountUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserRepository)(nil).CountUsers), ctx)
+}
+
 // CreateUser mocks base method.
-func (m *MockUserRepository) CreateUser(user *models.User) (*models.User, error) {
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserRepositoryMockRecorder) CreateUser(user any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), ctx, user)
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserRepository) DeleteUser(id int) error {
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserRepositoryMockRecorder) DeleteUser(id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), ctx, id, version)
 }
 
 // ListAttendees mocks base method.
-func (m *MockUserRepository) ListAttendees(filter *types.AttendeeFilter) ([]types.AttendeeResp, error) {
+func (m *MockUserRepository) ListAttendees(ctx context.Context, filter *types.AttendeeFilter) ([]types.AttendeeResp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAttendees", filter)
+	ret := m.ctrl.Call(m, "ListAttendees", ctx, filter)
 	ret0, _ := ret[0].([]types.AttendeeResp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAttendees indicates an expected call of ListAttendees.
-func (mr *MockUserRepositoryMockRecorder) ListAttendees(filter any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ListAttendees(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendees", reflect.TypeOf((*MockUserRepository)(nil).ListAttendees), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendees", reflect.TypeOf((*MockUserRepository)(nil).ListAttendees), ctx, filter)
 }
 
 // ReadPaginatedUsers mocks base method.
-func (m *MockUserRepository) ReadPaginatedUsers(limit, offset int) ([]*types.UserInfo, int, error) {
+func (m *MockUserRepository) ReadPaginatedUsers(ctx context.Context, limit, offset int) ([]*types.UserInfo, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadPaginatedUsers", limit, offset)
+	ret := m.ctrl.Call(m, "ReadPaginatedUsers", ctx, limit, offset)
 	ret0, _ := ret[0].([]*types.UserInfo)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -251,96 +339,168 @@ func (m *MockUserRepository) ReadPaginatedUsers(limit, offset int) ([]*types.Use
 }
 
 // ReadPaginatedUsers indicates an expected call of ReadPaginatedUsers.
-func (mr *MockUserRepositoryMockRecorder) ReadPaginatedUsers(limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ReadPaginatedUsers(ctx, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPaginatedUsers", reflect.TypeOf((*MockUserRepository)(nil).ReadPaginatedUsers), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPaginatedUsers", reflect.TypeOf((*MockUserRepository)(nil).ReadPaginatedUsers), ctx, limit, offset)
 }
 
 // ReadPermissionsByRole mocks base method.
-func (m *MockUserRepository) ReadPermissionsByRole(roleID int) ([]*models.Permission, error) {
+func (m *MockUserRepository) ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadPermissionsByRole", roleID)
+	ret := m.ctrl.Call(m, "ReadPermissionsByRole", ctx, roleID)
 	ret0, _ := ret[0].([]*models.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadPermissionsByRole indicates an expected call of ReadPermissionsByRole.
-func (mr *MockUserRepositoryMockRecorder) ReadPermissionsByRole(roleID any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ReadPermissionsByRole(ctx, roleID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPermissionsByRole", reflect.TypeOf((*MockUserRepository)(nil).ReadPermissionsByRole), roleID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPermissionsByRole", reflect.TypeOf((*MockUserRepository)(nil).ReadPermissionsByRole), ctx, roleID)
 }
 
 // ReadUserByEmail mocks base method.
-func (m *MockUserRepository) ReadUserByEmail(email string) (*models.User, error) {
+func (m *MockUserRepository) ReadUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserByEmail", email)
+	ret := m.ctrl.Call(m, "ReadUserByEmail", ctx, email)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUserByEmail indicates an expected call of ReadUserByEmail.
-func (mr *MockUserRepositoryMockRecorder) ReadUserByEmail(email any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ReadUserByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).ReadUserByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).ReadUserByEmail), ctx, email)
 }
 
 // ReadUserById mocks base method.
-func (m *MockUserRepository) ReadUserById(id int) (*models.User, error) {
+func (m *MockUserRepository) ReadUserById(ctx context.Context, id int) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserById", id)
+	ret := m.ctrl.Call(m, "ReadUserById", ctx, id)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUserById indicates an expected call of ReadUserById.
-func (mr *MockUserRepositoryMockRecorder) ReadUserById(id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ReadUserById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserById", reflect.TypeOf((*MockUserRepository)(nil).ReadUserById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserById", reflect.TypeOf((*MockUserRepository)(nil).ReadUserById), ctx, id)
 }
 
 // ReadUsers mocks base method.
-func (m *MockUserRepository) ReadUsers(id []int) ([]models.User, error) {
+func (m *MockUserRepository) ReadUsers(ctx context.Context, id []int) ([]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUsers", id)
+	ret := m.ctrl.Call(m, "ReadUsers", ctx, id)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUsers indicates an expected call of ReadUsers.
-func (mr *MockUserRepositoryMockRecorder) ReadUsers(id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ReadUsers(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockUserRepository)(nil).ReadUsers), ctx, id)
+}
+
+// ReadUsersAfter mocks base method.
+func (m *MockUserRepository) ReadUsersAfter(ctx context.Context, cursor *types.Cursor, limit int) ([]*types.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsersAfter", ctx, cursor, limit)
+	ret0, _ := ret[0].([]*types.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUsersAfter indicates an expected call of ReadUsersAfter.
+func (mr *MockUserRepositoryMockRecorder) ReadUsersAfter(ctx, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockUserRepository)(nil).ReadUsers), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsersAfter", reflect.TypeOf((*MockUserRepository)(nil).ReadUsersAfter), ctx, cursor, limit)
+}
+
+// UpdateNotificationChannels mocks base method.
+func (m *MockUserRepository) UpdateNotificationChannels(ctx context.Context, id int, smsEnabled, pushEnabled bool, pushToken *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationChannels", ctx, id, smsEnabled, pushEnabled, pushToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationChannels indicates an expected call of UpdateNotificationChannels.
+func (mr *MockUserRepositoryMockRecorder) UpdateNotificationChannels(ctx, id, smsEnabled, pushEnabled, pushToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationChannels", reflect.TypeOf((*MockUserRepository)(nil).UpdateNotificationChannels), ctx, id, smsEnabled, pushEnabled, pushToken)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserRepository) UpdateUser(user *models.User) error {
+func (m *MockUserRepository) UpdateUser(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", user)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserRepositoryMockRecorder) UpdateUser(user any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, user)
+}
+
+// UpdateUserPhone mocks base method.
+func (m *MockUserRepository) UpdateUserPhone(ctx context.Context, id int, phone string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPhone", ctx, id, phone, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPhone indicates an expected call of UpdateUserPhone.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserPhone(ctx, id, phone, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPhone", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserPhone), ctx, id, phone, verifiedAt)
+}
+
+// UpdateUserTimezone mocks base method.
+func (m *MockUserRepository) UpdateUserTimezone(ctx context.Context, id int, timezone *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTimezone", ctx, id, timezone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTimezone indicates an expected call of UpdateUserTimezone.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserTimezone(ctx, id, timezone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTimezone", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserTimezone), ctx, id, timezone)
 }
 
 // UserCountByEmail mocks base method.
-func (m *MockUserRepository) UserCountByEmail(email string) (int, error) {
+func (m *MockUserRepository) UserCountByEmail(ctx context.Context, email string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserCountByEmail", email)
+	ret := m.ctrl.Call(m, "UserCountByEmail", ctx, email)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserCountByEmail indicates an expected call of UserCountByEmail.
-func (mr *MockUserRepositoryMockRecorder) UserCountByEmail(email any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) UserCountByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCountByEmail", reflect.TypeOf((*MockUserRepository)(nil).UserCountByEmail), ctx, email)
+}
+
+// UserCountByPhone mocks base method.
+func (m *MockUserRepository) UserCountByPhone(ctx context.Context, phone string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCountByPhone", ctx, phone)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserCountByPhone indicates an expected call of UserCountByPhone.
+func (mr *MockUserRepositoryMockRecorder) UserCountByPhone(ctx, phone any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCountByEmail", reflect.TypeOf((*MockUserRepository)(nil).UserCountByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCountByPhone", reflect.TypeOf((*MockUserRepository)(nil).UserCountByPhone), ctx, phone)
 }
@@ -0,0 +1,360 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/venue.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/venue.go -destination=services/mocks/mock_venue_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vivasoft-ltd/go-ems/models"
+	types "github.com/vivasoft-ltd/go-ems/types"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVenueRepository is a mock of VenueRepository interface.
+type MockVenueRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueRepositoryMockRecorder is the mock recorder for MockVenueRepository.
+type MockVenueRepositoryMockRecorder struct {
+	mock *MockVenueRepository
+}
+
+// NewMockVenueRepository creates a new mock instance.
+func NewMockVenueRepository(ctrl *gomock.Controller) *MockVenueRepository {
+	mock := &MockVenueRepository{ctrl: ctrl}
+	mock.recorder = &MockVenueRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueRepository) EXPECT() *MockVenueRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRoom mocks base method.
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, room *models.Room) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoom", ctx, room)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRoom indicates an expected call of CreateRoom.
+func (mr *MockVenueRepositoryMockRecorder) CreateRoom(ctx, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoom", reflect.TypeOf((*MockVenueRepository)(nil).CreateRoom), ctx, room)
+}
+
+// CreateVenue mocks base method.
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, venue *models.Venue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenue", ctx, venue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVenue indicates an expected call of CreateVenue.
+func (mr *MockVenueRepositoryMockRecorder) CreateVenue(ctx, venue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenue", reflect.TypeOf((*MockVenueRepository)(nil).CreateVenue), ctx, venue)
+}
+
+// DeleteRoom mocks base method.
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoom", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoom indicates an expected call of DeleteRoom.
+func (mr *MockVenueRepositoryMockRecorder) DeleteRoom(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoom", reflect.TypeOf((*MockVenueRepository)(nil).DeleteRoom), ctx, id)
+}
+
+// DeleteVenue mocks base method.
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVenue indicates an expected call of DeleteVenue.
+func (mr *MockVenueRepositoryMockRecorder) DeleteVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenue", reflect.TypeOf((*MockVenueRepository)(nil).DeleteVenue), ctx, id)
+}
+
+// ListRoomBookings mocks base method.
+func (m *MockVenueRepository) ListRoomBookings(ctx context.Context, roomID int, from, to time.Time) ([]types.RoomBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoomBookings", ctx, roomID, from, to)
+	ret0, _ := ret[0].([]types.RoomBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoomBookings indicates an expected call of ListRoomBookings.
+func (mr *MockVenueRepositoryMockRecorder) ListRoomBookings(ctx, roomID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoomBookings", reflect.TypeOf((*MockVenueRepository)(nil).ListRoomBookings), ctx, roomID, from, to)
+}
+
+// ListVenues mocks base method.
+func (m *MockVenueRepository) ListVenues(ctx context.Context) ([]models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenues", ctx)
+	ret0, _ := ret[0].([]models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenues indicates an expected call of ListVenues.
+func (mr *MockVenueRepositoryMockRecorder) ListVenues(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenues", reflect.TypeOf((*MockVenueRepository)(nil).ListVenues), ctx)
+}
+
+// ReadRoom mocks base method.
+func (m *MockVenueRepository) ReadRoom(ctx context.Context, id int) (*models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRoom", ctx, id)
+	ret0, _ := ret[0].(*models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRoom indicates an expected call of ReadRoom.
+func (mr *MockVenueRepositoryMockRecorder) ReadRoom(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRoom", reflect.TypeOf((*MockVenueRepository)(nil).ReadRoom), ctx, id)
+}
+
+// ReadVenue mocks base method.
+func (m *MockVenueRepository) ReadVenue(ctx context.Context, id int) (*models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadVenue", ctx, id)
+	ret0, _ := ret[0].(*models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadVenue indicates an expected call of ReadVenue.
+func (mr *MockVenueRepositoryMockRecorder) ReadVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadVenue", reflect.TypeOf((*MockVenueRepository)(nil).ReadVenue), ctx, id)
+}
+
+// RoomCountByName mocks base method.
+func (m *MockVenueRepository) RoomCountByName(ctx context.Context, venueID int, name string, excludeID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoomCountByName", ctx, venueID, name, excludeID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoomCountByName indicates an expected call of RoomCountByName.
+func (mr *MockVenueRepositoryMockRecorder) RoomCountByName(ctx, venueID, name, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoomCountByName", reflect.TypeOf((*MockVenueRepository)(nil).RoomCountByName), ctx, venueID, name, excludeID)
+}
+
+// UpdateRoom mocks base method.
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, room *models.Room) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoom", ctx, room)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoom indicates an expected call of UpdateRoom.
+func (mr *MockVenueRepositoryMockRecorder) UpdateRoom(ctx, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoom", reflect.TypeOf((*MockVenueRepository)(nil).UpdateRoom), ctx, room)
+}
+
+// UpdateVenue mocks base method.
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, venue *models.Venue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVenue", ctx, venue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVenue indicates an expected call of UpdateVenue.
+func (mr *MockVenueRepositoryMockRecorder) UpdateVenue(ctx, venue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVenue", reflect.TypeOf((*MockVenueRepository)(nil).UpdateVenue), ctx, venue)
+}
+
+// VenueCountByName mocks base method.
+func (m *MockVenueRepository) VenueCountByName(ctx context.Context, name string, excludeID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VenueCountByName", ctx, name, excludeID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VenueCountByName indicates an expected call of VenueCountByName.
+func (mr *MockVenueRepositoryMockRecorder) VenueCountByName(ctx, name, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VenueCountByName", reflect.TypeOf((*MockVenueRepository)(nil).VenueCountByName), ctx, name, excludeID)
+}
+
+// MockVenueService is a mock of VenueService interface.
+type MockVenueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueServiceMockRecorder is the mock recorder for MockVenueService.
+type MockVenueServiceMockRecorder struct {
+	mock *MockVenueService
+}
+
+// NewMockVenueService creates a new mock instance.
+func NewMockVenueService(ctrl *gomock.Controller) *MockVenueService {
+	mock := &MockVenueService{ctrl: ctrl}
+	mock.recorder = &MockVenueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueService) EXPECT() *MockVenueServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRoom mocks base method.
+func (m *MockVenueService) CreateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoom", ctx, req)
+	ret0, _ := ret[0].(*models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRoom indicates an expected call of CreateRoom.
+func (mr *MockVenueServiceMockRecorder) CreateRoom(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoom", reflect.TypeOf((*MockVenueService)(nil).CreateRoom), ctx, req)
+}
+
+// CreateVenue mocks base method.
+func (m *MockVenueService) CreateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenue", ctx, req)
+	ret0, _ := ret[0].(*models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenue indicates an expected call of CreateVenue.
+func (mr *MockVenueServiceMockRecorder) CreateVenue(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenue", reflect.TypeOf((*MockVenueService)(nil).CreateVenue), ctx, req)
+}
+
+// DeleteRoom mocks base method.
+func (m *MockVenueService) DeleteRoom(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoom", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoom indicates an expected call of DeleteRoom.
+func (mr *MockVenueServiceMockRecorder) DeleteRoom(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoom", reflect.TypeOf((*MockVenueService)(nil).DeleteRoom), ctx, id)
+}
+
+// DeleteVenue mocks base method.
+func (m *MockVenueService) DeleteVenue(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVenue indicates an expected call of DeleteVenue.
+func (mr *MockVenueServiceMockRecorder) DeleteVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenue", reflect.TypeOf((*MockVenueService)(nil).DeleteVenue), ctx, id)
+}
+
+// ListVenues mocks base method.
+func (m *MockVenueService) ListVenues(ctx context.Context) ([]models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenues", ctx)
+	ret0, _ := ret[0].([]models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenues indicates an expected call of ListVenues.
+func (mr *MockVenueServiceMockRecorder) ListVenues(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenues", reflect.TypeOf((*MockVenueService)(nil).ListVenues), ctx)
+}
+
+// RoomAvailability mocks base method.
+func (m *MockVenueService) RoomAvailability(ctx context.Context, req *types.RoomAvailabilityReq) (*types.RoomAvailabilityResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoomAvailability", ctx, req)
+	ret0, _ := ret[0].(*types.RoomAvailabilityResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoomAvailability indicates an expected call of RoomAvailability.
+func (mr *MockVenueServiceMockRecorder) RoomAvailability(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoomAvailability", reflect.TypeOf((*MockVenueService)(nil).RoomAvailability), ctx, req)
+}
+
+// UpdateRoom mocks base method.
+func (m *MockVenueService) UpdateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoom", ctx, req)
+	ret0, _ := ret[0].(*models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRoom indicates an expected call of UpdateRoom.
+func (mr *MockVenueServiceMockRecorder) UpdateRoom(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoom", reflect.TypeOf((*MockVenueService)(nil).UpdateRoom), ctx, req)
+}
+
+// UpdateVenue mocks base method.
+func (m *MockVenueService) UpdateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVenue", ctx, req)
+	ret0, _ := ret[0].(*models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVenue indicates an expected call of UpdateVenue.
+func (mr *MockVenueServiceMockRecorder) UpdateVenue(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVenue", reflect.TypeOf((*MockVenueService)(nil).UpdateVenue), ctx, req)
+}
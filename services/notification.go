@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+)
+
+type NotificationServiceImpl struct {
+	repo   domain.NotificationRepository
+	broker domain.NotificationBroker
+}
+
+func NewNotificationServiceImpl(repo domain.NotificationRepository, broker domain.NotificationBroker) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		repo:   repo,
+		broker: broker,
+	}
+}
+
+// Notify stores the notifications in the users' inbox and pushes them to the
+// users' connected clients.
+func (svc *NotificationServiceImpl) Notify(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	if err := svc.repo.CreateNotifications(ctx, notifications); err != nil {
+		logutil.FromContext(ctx).Error("failed to store notifications", "err", err, "count", len(notifications))
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err := svc.broker.Publish(ctx, notification); err != nil {
+			// the notification is persisted, clients will pick it up from the inbox
+			logutil.FromContext(ctx).Error("failed to publish notification", "err", err, "notification_id", notification.ID, "user_id", notification.UserID)
+		}
+	}
+
+	return nil
+}
+
+func (svc *NotificationServiceImpl) ListNotifications(ctx context.Context, req types.ListNotificationReq, userID int) (*types.PaginatedNotificationResp, error) {
+	offset := (req.Page - 1) * req.Limit
+	filter := &types.NotificationFilter{Unread: req.Unread}
+
+	notifications, total, err := svc.repo.ListNotifications(ctx, userID, filter, req.Limit, offset)
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list notifications", "err", err)
+		return nil, err
+	}
+
+	unread, err := svc.repo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to count unread notifications", "err", err)
+		return nil, err
+	}
+
+	return &types.PaginatedNotificationResp{
+		Total:         total,
+		Unread:        unread,
+		Page:          req.Page,
+		Limit:         req.Limit,
+		Notifications: notifications,
+	}, nil
+}
+
+// MarkNotificationsRead marks the given notifications as read, or all of the
+// user's notifications when no id is given.
+func (svc *NotificationServiceImpl) MarkNotificationsRead(ctx context.Context, userID int, ids []int) error {
+	if err := svc.repo.MarkNotificationsRead(ctx, userID, ids); err != nil {
+		logutil.FromContext(ctx).Error("failed to mark notifications as read", "err", err)
+		return err
+	}
+	return nil
+}
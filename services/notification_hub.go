@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/tracing"
+)
+
+const notificationSubscriberBuffer = 16
+
+// NotificationHub publishes notifications on a redis channel and dispatches the
+// messages it receives to the SSE subscribers connected to this process.
+type NotificationHub struct {
+	client  *redis.Client
+	channel string
+
+	mu          sync.RWMutex
+	subscribers map[int]map[chan *models.Notification]struct{}
+	pubsub      *redis.PubSub
+	closed      bool
+}
+
+func NewNotificationHub(client *redis.Client, channel string) *NotificationHub {
+	return &NotificationHub{
+		client:      client,
+		channel:     channel,
+		subscribers: make(map[int]map[chan *models.Notification]struct{}),
+	}
+}
+
+// Run subscribes to the redis channel and blocks until Close is called. It
+// returns right away when the hub is already closed.
+func (h *NotificationHub) Run() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	// subscribed under the lock, so Close either sees it or stops Run above
+	pubsub := h.client.Subscribe(h.channel)
+	h.pubsub = pubsub
+	h.mu.Unlock()
+
+	slog.Info("notification hub subscribed", "channel", h.channel)
+
+	for msg := range pubsub.Channel() {
+		var notification models.Notification
+		if err := json.Unmarshal([]byte(msg.Payload), &notification); err != nil {
+			slog.Error("failed to decode notification message", "err", err)
+			continue
+		}
+		h.dispatch(&notification)
+	}
+}
+
+func (h *NotificationHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+
+	for userID, subs := range h.subscribers {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(h.subscribers, userID)
+	}
+
+	if h.pubsub == nil {
+		return nil
+	}
+	return h.pubsub.Close()
+}
+
+func (h *NotificationHub) Publish(ctx context.Context, notification *models.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return tracing.Redis(ctx, h.client).Publish(h.channel, string(payload)).Err()
+}
+
+// Subscribe registers a listener for the user's notifications. The returned
+// function must be called to release the subscription.
+func (h *NotificationHub) Subscribe(userID int) (<-chan *models.Notification, func()) {
+	ch := make(chan *models.Notification, notificationSubscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		// shutting down, the stream ends right away
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan *models.Notification]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		subs, ok := h.subscribers[userID]
+		if !ok {
+			return
+		}
+		if _, ok := subs[ch]; !ok {
+			return
+		}
+		delete(subs, ch)
+		close(ch)
+		if len(subs) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *NotificationHub) dispatch(notification *models.Notification) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+			// slow clients miss the push but still find it in their inbox
+			slog.Warn("dropped notification for slow subscriber", "notification_id", notification.ID, "user_id", notification.UserID)
+		}
+	}
+}
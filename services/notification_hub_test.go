@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+func newTestNotificationHub(t *testing.T) *NotificationHub {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewNotificationHub(client, "notifications")
+}
+
+// runHub runs the hub and returns a channel closed once Run returns.
+func runHub(h *NotificationHub) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		h.Run()
+		close(done)
+	}()
+	return done
+}
+
+func waitClosed(t *testing.T, done <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s didn't return", what)
+	}
+}
+
+// Test cases for NotificationHub
+func TestNotificationHub(t *testing.T) {
+	// Test case 1: Closing before Run started doesn't leave Run blocked
+	t.Run("CloseBeforeRun", func(t *testing.T) {
+		hub := newTestNotificationHub(t)
+
+		if err := hub.Close(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitClosed(t, runHub(hub), "Run")
+
+		notifications, unsubscribe := hub.Subscribe(1)
+		defer unsubscribe()
+		if _, ok := <-notifications; ok {
+			t.Error("Expected the stream of a closed hub to end")
+		}
+	})
+
+	// Test case 2: Published notifications reach the subscribers until Close
+	t.Run("SuccessfulDispatchAndClose", func(t *testing.T) {
+		hub := newTestNotificationHub(t)
+		notifications, unsubscribe := hub.Subscribe(5)
+		defer unsubscribe()
+		done := runHub(hub)
+
+		// Run subscribes in the background, publish until it listens
+		deadline := time.After(time.Second)
+		var received *models.Notification
+		for received == nil {
+			if err := hub.Publish(context.Background(), &models.Notification{ID: 7, UserID: 5}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			select {
+			case received = <-notifications:
+			case <-time.After(10 * time.Millisecond):
+			case <-deadline:
+				t.Fatal("notification wasn't dispatched")
+			}
+		}
+		if received.ID != 7 {
+			t.Errorf("Expected notification 7, got %d", received.ID)
+		}
+
+		if err := hub.Close(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitClosed(t, done, "Run")
+		// Close ends the stream, after the duplicates published above
+		for range notifications {
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+	"gorm.io/gorm"
+)
+
+type NotifierServiceImpl struct {
+	userRepo domain.UserRepository
+	channels []domain.NotifierChannel
+}
+
+func NewNotifierServiceImpl(userRepo domain.UserRepository, channels ...domain.NotifierChannel) *NotifierServiceImpl {
+	return &NotifierServiceImpl{
+		userRepo: userRepo,
+		channels: channels,
+	}
+}
+
+// EnabledChannels returns the names of the channels the user can be reached on.
+func (svc *NotifierServiceImpl) EnabledChannels(user *models.User) []string {
+	var names []string
+	for _, channel := range svc.channels {
+		if channel.Enabled(user) {
+			names = append(names, channel.Name())
+		}
+	}
+	return names
+}
+
+// Notify sends the message to the user on the given channel. The user's
+// preferences are re-read so that opting out takes effect for queued messages.
+func (svc *NotifierServiceImpl) Notify(ctx context.Context, channelName string, userID int, msg *types.ChannelMessage) error {
+	channel, err := svc.channel(channelName)
+	if err != nil {
+		return err
+	}
+
+	user, err := svc.userRepo.ReadUserById(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errutil.ErrUserNotFound
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to read user for notification", "err", err, "user_id", userID, "channel", channelName)
+		return err
+	}
+
+	if !channel.Enabled(user) {
+		return errutil.ErrNotifierChannelDisabled
+	}
+
+	if err := channel.Send(user, msg); err != nil {
+		logutil.FromContext(ctx).Error("failed to send notification", "err", err, "user_id", userID, "channel", channelName)
+		return err
+	}
+	return nil
+}
+
+// SendSms delivers a text to a phone number regardless of the owner's
+// preferences, e.g. a verification code for a number that is not verified yet.
+func (svc *NotifierServiceImpl) SendSms(ctx context.Context, phone string, text string) error {
+	channel, err := svc.channel(consts.NotifierChannelSms)
+	if err != nil {
+		return err
+	}
+
+	if err := channel.Send(&models.User{Phone: &phone}, &types.ChannelMessage{Body: text}); err != nil {
+		logutil.FromContext(ctx).Error("failed to send sms", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (svc *NotifierServiceImpl) channel(name string) (domain.NotifierChannel, error) {
+	for _, channel := range svc.channels {
+		if channel.Name() == name {
+			return channel, nil
+		}
+	}
+	return nil, errutil.ErrUnknownNotifierChannel
+}
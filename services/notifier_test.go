@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/models"
+	notifier_repo "github.com/vivasoft-ltd/go-ems/repositories/notifier"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"go.uber.org/mock/gomock"
+)
+
+func newStubNotifier(t *testing.T, userRepo *mocks.MockUserRepository) (*NotifierServiceImpl, string) {
+	path := filepath.Join(t.TempDir(), "notifier.log")
+	sms := notifier_repo.NewStubChannel(notifier_repo.NewSmsChannel(nil, ""), path)
+	push := notifier_repo.NewStubChannel(notifier_repo.NewPushChannel(nil, ""), path)
+	return NewNotifierServiceImpl(userRepo, sms, push), path
+}
+
+// Test cases for NotifierServiceImpl.EnabledChannels
+func TestEnabledChannels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := newStubNotifier(t, mocks.NewMockUserRepository(ctrl))
+
+	phone := "+8801712345678"
+	token := "device-token"
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name string
+		user *models.User
+		want []string
+	}{
+		{"NothingEnabled", &models.User{ID: 1}, nil},
+		{"SmsWithoutVerifiedPhone", &models.User{ID: 1, Phone: &phone, SmsEnabled: true}, nil},
+		{"SmsWithVerifiedPhone", &models.User{ID: 1, Phone: &phone, PhoneVerifiedAt: &verifiedAt, SmsEnabled: true}, []string{consts.NotifierChannelSms}},
+		{"PushWithoutToken", &models.User{ID: 1, PushEnabled: true}, nil},
+		{"Both", &models.User{ID: 1, Phone: &phone, PhoneVerifiedAt: &verifiedAt, SmsEnabled: true, PushEnabled: true, PushToken: &token}, []string{consts.NotifierChannelSms, consts.NotifierChannelPush}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.EnabledChannels(tt.user)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected channels %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected channels %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+// Test cases for NotifierServiceImpl.Notify
+func TestNotify(t *testing.T) {
+	phone := "+8801712345678"
+	verifiedAt := time.Now()
+
+	t.Run("SendsOnEnabledChannel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		service, path := newStubNotifier(t, mockUserRepo)
+
+		mockUserRepo.EXPECT().
+			ReadUserById(gomock.Any(), gomock.Eq(2)).
+			Return(&models.User{ID: 2, Phone: &phone, PhoneVerifiedAt: &verifiedAt, SmsEnabled: true}, nil)
+
+		msg := &types.ChannelMessage{Title: "Event Reminder: Test Event", Body: "Test Event is starting soon."}
+		if err := service.Notify(context.Background(), consts.NotifierChannelSms, 2, msg); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		records, err := notifier_repo.ReadStubRecords(path)
+		if err != nil {
+			t.Fatalf("Expected stub records, got %v", err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("Expected 1 record, got %d", len(records))
+		}
+		if records[0].Channel != consts.NotifierChannelSms || records[0].UserID != 2 || *records[0].Phone != phone {
+			t.Errorf("Unexpected record %+v", records[0])
+		}
+		if records[0].Message.Body != msg.Body {
+			t.Errorf("Expected body '%s', got '%s'", msg.Body, records[0].Message.Body)
+		}
+	})
+
+	t.Run("SkipsDisabledChannel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		service, _ := newStubNotifier(t, mockUserRepo)
+
+		mockUserRepo.EXPECT().
+			ReadUserById(gomock.Any(), gomock.Eq(2)).
+			Return(&models.User{ID: 2, Phone: &phone, PhoneVerifiedAt: &verifiedAt}, nil)
+
+		err := service.Notify(context.Background(), consts.NotifierChannelSms, 2, &types.ChannelMessage{Body: "hello"})
+		if !errors.Is(err, errutil.ErrNotifierChannelDisabled) {
+			t.Errorf("Expected ErrNotifierChannelDisabled, got %v", err)
+		}
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, _ := newStubNotifier(t, mocks.NewMockUserRepository(ctrl))
+
+		err := service.Notify(context.Background(), "carrier-pigeon", 2, &types.ChannelMessage{Body: "hello"})
+		if !errors.Is(err, errutil.ErrUnknownNotifierChannel) {
+			t.Errorf("Expected ErrUnknownNotifierChannel, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/tracing"
+	"github.com/vivasoft-ltd/go-ems/types"
+)
+
+// tokenBucketScript refills the bucket from the time elapsed since the last
+// take and takes a token when one is available. It returns 0 on success or the
+// milliseconds until the next token. Redis' clock is used so that every worker
+// process shares the same view of the bucket.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+type TokenBucketLimiter struct {
+	client *redis.Client
+	key    string
+	rate   float64
+	burst  int
+}
+
+// NewTokenBucketLimiter allows rate takes per second with bursts of up to burst.
+// A non-positive rate disables the limiter.
+func NewTokenBucketLimiter(client *redis.Client, key string, rate float64, burst int) *TokenBucketLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucketLimiter{
+		client: client,
+		key:    key,
+		rate:   rate,
+		burst:  burst,
+	}
+}
+
+func (l *TokenBucketLimiter) Take(ctx context.Context) (time.Duration, error) {
+	if l.rate <= 0 {
+		return 0, nil
+	}
+
+	waitMs, err := tokenBucketScript.Run(tracing.Redis(ctx, l.client), []string{l.key}, l.rate, l.burst).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(waitMs) * time.Millisecond, nil
+}
+
+// slidingWindowScript keeps the hits of the last window in a sorted set scored
+// by their time and adds the new hit when there is room for it. It returns
+// whether the hit was allowed, the hits in the window and the milliseconds until
+// the oldest of them expires.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, time[1] .. time[2] .. ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call("PEXPIRE", KEYS[1], window)
+
+local reset = window
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+type SlidingWindowLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewSlidingWindowLimiter limits keys under prefix, the windows are shared by
+// every process using the same Redis.
+func NewSlidingWindowLimiter(client *redis.Client, prefix string) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (l *SlidingWindowLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*types.RateLimitResult, error) {
+	// the member only has to be unique among the hits of the same millisecond
+	member := strconv.FormatInt(rand.Int63(), 36)
+	res, err := slidingWindowScript.Run(tracing.Redis(ctx, l.client), []string{l.prefix + key}, limit, window.Milliseconds(), member).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 3 {
+		return nil, fmt.Errorf("unexpected sliding window reply: %v", res)
+	}
+	allowed, _ := values[0].(int64)
+	count, _ := values[1].(int64)
+	resetMs, _ := values[2].(int64)
+
+	return &types.RateLimitResult{
+		Allowed:   allowed == 1,
+		Limit:     limit,
+		Remaining: max(limit-int(count), 0),
+		Reset:     time.Duration(resetMs) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+)
+
+// Test cases for SlidingWindowLimiter.Allow
+func TestSlidingWindowLimiter(t *testing.T) {
+	ctx := context.Background()
+	newLimiter := func(t *testing.T) (*SlidingWindowLimiter, *miniredis.Miniredis) {
+		srv := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+		t.Cleanup(func() { client.Close() })
+		return NewSlidingWindowLimiter(client, "test_"), srv
+	}
+
+	// Test case 1: Hits above the limit are denied until the oldest leaves the window
+	t.Run("DeniesAboveLimit", func(t *testing.T) {
+		limiter, srv := newLimiter(t)
+		start := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+
+		for i, offset := range []time.Duration{0, 10 * time.Second, 20 * time.Second} {
+			srv.SetTime(start.Add(offset))
+			result, err := limiter.Allow(ctx, "ip:1", 3, time.Minute)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !result.Allowed || result.Remaining != 2-i {
+				t.Fatalf("Expected hit %d to be allowed with %d remaining, got %+v", i+1, 2-i, result)
+			}
+		}
+
+		srv.SetTime(start.Add(30 * time.Second))
+		result, err := limiter.Allow(ctx, "ip:1", 3, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Allowed || result.Remaining != 0 || result.Limit != 3 {
+			t.Fatalf("Expected the hit to be denied, got %+v", result)
+		}
+		// the first hit leaves the window 30 seconds later
+		if result.Reset != 30*time.Second {
+			t.Errorf("Expected reset in 30s, got %s", result.Reset)
+		}
+
+		// other clients have their own window
+		if result, _ := limiter.Allow(ctx, "ip:2", 3, time.Minute); !result.Allowed {
+			t.Errorf("Expected another client to be allowed, got %+v", result)
+		}
+
+		srv.SetTime(start.Add(time.Minute + time.Millisecond))
+		result, err = limiter.Allow(ctx, "ip:1", 3, time.Minute)
+		if err != nil || !result.Allowed || result.Remaining != 0 {
+			t.Errorf("Expected a slot to be freed, got %+v, %v", result, err)
+		}
+	})
+}
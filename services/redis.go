@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/tracing"
 	"strconv"
 	"time"
 )
@@ -17,24 +19,24 @@ func NewRedisService(client *redis.Client) *RedisService {
 	}
 }
 
-func (svc *RedisService) Set(key string, value interface{}, ttl time.Duration) error {
-	return svc.client.Set(key, value, ttl*time.Second).Err()
+func (svc *RedisService) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return tracing.Redis(ctx, svc.client).Set(key, value, ttl*time.Second).Err()
 }
-func (svc *RedisService) SetStruct(key string, value interface{}, ttl time.Duration) error {
+func (svc *RedisService) SetStruct(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	serializedValue, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
 
-	return svc.client.Set(key, string(serializedValue), ttl*time.Second).Err()
+	return tracing.Redis(ctx, svc.client).Set(key, string(serializedValue), ttl*time.Second).Err()
 }
 
-func (svc *RedisService) Get(key string) (string, error) {
-	return svc.client.Get(key).Result()
+func (svc *RedisService) Get(ctx context.Context, key string) (string, error) {
+	return tracing.Redis(ctx, svc.client).Get(key).Result()
 }
 
-func (svc *RedisService) GetInt(key string) (int, error) {
-	str, err := svc.client.Get(key).Result()
+func (svc *RedisService) GetInt(ctx context.Context, key string) (int, error) {
+	str, err := tracing.Redis(ctx, svc.client).Get(key).Result()
 	if err != nil {
 		return 0, err
 	}
@@ -42,8 +44,8 @@ func (svc *RedisService) GetInt(key string) (int, error) {
 	return strconv.Atoi(str)
 }
 
-func (svc *RedisService) GetStruct(key string, outputStruct interface{}) error {
-	serializedValue, err := svc.client.Get(key).Result()
+func (svc *RedisService) GetStruct(ctx context.Context, key string, outputStruct interface{}) error {
+	serializedValue, err := tracing.Redis(ctx, svc.client).Get(key).Result()
 	if err != nil {
 		return err
 	}
@@ -55,6 +57,18 @@ func (svc *RedisService) GetStruct(key string, outputStruct interface{}) error {
 	return nil
 }
 
-func (svc *RedisService) Del(keys ...string) error {
-	return svc.client.Del(keys...).Err()
+// Incr increments the counter and returns its new value. The counter expires ttl
+// after its last increment.
+func (svc *RedisService) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := tracing.Redis(ctx, svc.client).TxPipeline()
+	incr := pipe.Incr(key)
+	pipe.Expire(key, ttl*time.Second)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (svc *RedisService) Del(ctx context.Context, keys ...string) error {
+	return tracing.Redis(ctx, svc.client).Del(keys...).Err()
 }
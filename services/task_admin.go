@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+)
+
+type TaskAdminServiceImpl struct {
+	repo domain.AsynqInspectorRepository
+}
+
+func NewTaskAdminServiceImpl(repo domain.AsynqInspectorRepository) *TaskAdminServiceImpl {
+	return &TaskAdminServiceImpl{
+		repo: repo,
+	}
+}
+
+func (svc *TaskAdminServiceImpl) ListQueues(ctx context.Context) ([]types.QueueInfoResp, error) {
+	queues, err := svc.repo.ListQueues()
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to list queues", "err", err)
+		return nil, err
+	}
+
+	resp := make([]types.QueueInfoResp, 0, len(queues))
+	for _, q := range queues {
+		resp = append(resp, types.QueueInfoResp{
+			Queue:       q.Queue,
+			Size:        q.Size,
+			Pending:     q.Pending,
+			Active:      q.Active,
+			Scheduled:   q.Scheduled,
+			Retry:       q.Retry,
+			Archived:    q.Archived,
+			Completed:   q.Completed,
+			Processed:   q.Processed,
+			Failed:      q.Failed,
+			Paused:      q.Paused,
+			LatencyMsec: q.Latency.Milliseconds(),
+		})
+	}
+	return resp, nil
+}
+
+func (svc *TaskAdminServiceImpl) ListTasks(ctx context.Context, req *types.ListTasksReq) ([]types.TaskInfoResp, error) {
+	tasks, err := svc.repo.ListTasks(req.Queue, req.State, req.Page, req.Limit)
+	if err != nil {
+		if !errors.Is(err, asynq.ErrQueueNotFound) {
+			logutil.FromContext(ctx).Error("failed to list tasks", "err", err, "queue", req.Queue, "state", req.State)
+		}
+		return nil, err
+	}
+
+	resp := make([]types.TaskInfoResp, 0, len(tasks))
+	for _, task := range tasks {
+		resp = append(resp, toTaskInfoResp(task))
+	}
+	return resp, nil
+}
+
+// ApplyTaskAction applies the action to the requested tasks. Tasks that could not
+// be processed are reported back instead of failing the whole request.
+func (svc *TaskAdminServiceImpl) ApplyTaskAction(ctx context.Context, req *types.TaskActionReq) (*types.TaskActionResp, error) {
+	if req.All {
+		affected, err := svc.repo.ApplyTaskActionToAll(req.Queue, req.Action, req.State)
+		if err != nil {
+			logutil.FromContext(ctx).Error("failed to apply task action", "err", err, "queue", req.Queue, "state", req.State, "action", req.Action)
+			return nil, err
+		}
+		return &types.TaskActionResp{Affected: affected}, nil
+	}
+
+	resp := &types.TaskActionResp{}
+	for _, id := range req.TaskIDs {
+		if err := svc.repo.ApplyTaskAction(req.Queue, req.Action, id); err != nil {
+			if errors.Is(err, asynq.ErrQueueNotFound) {
+				return nil, err
+			}
+			if resp.Failed == nil {
+				resp.Failed = map[string]string{}
+			}
+			resp.Failed[id] = err.Error()
+			continue
+		}
+		resp.Affected++
+	}
+	return resp, nil
+}
+
+func (svc *TaskAdminServiceImpl) PauseQueue(ctx context.Context, queue string, pause bool) error {
+	var err error
+	if pause {
+		err = svc.repo.PauseQueue(queue)
+	} else {
+		err = svc.repo.UnpauseQueue(queue)
+	}
+	if err != nil && !errors.Is(err, asynq.ErrQueueNotFound) {
+		logutil.FromContext(ctx).Error("failed to pause queue", "err", err, "queue", queue, "pause", pause)
+	}
+	return err
+}
+
+func toTaskInfoResp(task *asynq.TaskInfo) types.TaskInfoResp {
+	resp := types.TaskInfoResp{
+		ID:            task.ID,
+		Queue:         task.Queue,
+		Type:          task.Type,
+		State:         task.State.String(),
+		Payload:       decodeTaskPayload(task.Payload),
+		MaxRetry:      task.MaxRetry,
+		Retried:       task.Retried,
+		LastErr:       task.LastErr,
+		LastFailedAt:  timeOrNil(task.LastFailedAt),
+		NextProcessAt: timeOrNil(task.NextProcessAt),
+		CompletedAt:   timeOrNil(task.CompletedAt),
+		Result:        string(task.Result),
+	}
+	return resp
+}
+
+// decodeTaskPayload returns the payload as JSON when possible so that admins can
+// read it, falling back to the raw string.
+func decodeTaskPayload(payload []byte) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return string(payload)
+	}
+	return decoded
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/vivasoft-ltd/go-ems/consts"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"go.uber.org/mock/gomock"
+)
+
+// Test cases for TaskAdminServiceImpl.ListQueues
+func TestListQueues(t *testing.T) {
+	// Test case 1: The queue stats are returned with the latency in milliseconds
+	t.Run("Successful", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+
+		mockInspector.EXPECT().ListQueues().Return([]*asynq.QueueInfo{
+			{Queue: "critical", Size: 3, Pending: 2, Retry: 1, Paused: true, Latency: 1500 * time.Millisecond},
+		}, nil)
+
+		queues, err := service.ListQueues(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := []types.QueueInfoResp{{Queue: "critical", Size: 3, Pending: 2, Retry: 1, Paused: true, LatencyMsec: 1500}}
+		if !reflect.DeepEqual(queues, want) {
+			t.Errorf("Expected queues %+v, got %+v", want, queues)
+		}
+	})
+}
+
+// Test cases for TaskAdminServiceImpl.ListTasks
+func TestListTasks(t *testing.T) {
+	// Test case 1: JSON payloads are decoded, the others are returned as text
+	t.Run("Successful", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+		failedAt := time.Now()
+
+		mockInspector.EXPECT().
+			ListTasks(gomock.Eq("critical"), gomock.Eq("retry"), gomock.Eq(2), gomock.Eq(10)).
+			Return([]*asynq.TaskInfo{
+				{ID: "task-1", Queue: "critical", State: asynq.TaskStateRetry, Payload: []byte(`{"event_id":7}`), LastErr: "timeout", LastFailedAt: failedAt},
+				{ID: "task-2", Queue: "critical", State: asynq.TaskStateRetry, Payload: []byte("raw")},
+			}, nil)
+
+		tasks, err := service.ListTasks(context.Background(), &types.ListTasksReq{Queue: "critical", State: "retry", Page: 2, Limit: 10})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tasks) != 2 {
+			t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+		}
+		if payload, ok := tasks[0].Payload.(map[string]interface{}); !ok || payload["event_id"] != float64(7) {
+			t.Errorf("Expected a decoded payload, got %v", tasks[0].Payload)
+		}
+		if tasks[0].State != "retry" || tasks[0].LastFailedAt == nil || !tasks[0].LastFailedAt.Equal(failedAt) {
+			t.Errorf("Expected a retry task failed at %s, got %+v", failedAt, tasks[0])
+		}
+		if tasks[1].Payload != "raw" || tasks[1].LastFailedAt != nil {
+			t.Errorf("Expected a raw payload and no failure time, got %+v", tasks[1])
+		}
+	})
+
+	// Test case 2: An unknown queue is passed on for a 404
+	t.Run("ErrorQueueNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+
+		mockInspector.EXPECT().ListTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, asynq.ErrQueueNotFound)
+
+		_, err := service.ListTasks(context.Background(), &types.ListTasksReq{Queue: "missing", State: "pending"})
+		if !errors.Is(err, asynq.ErrQueueNotFound) {
+			t.Errorf("Expected error %v, got %v", asynq.ErrQueueNotFound, err)
+		}
+	})
+}
+
+// Test cases for TaskAdminServiceImpl.ApplyTaskAction
+func TestApplyTaskAction(t *testing.T) {
+	// Test case 1: Retrying listed tasks reports the ones that failed
+	t.Run("RunWithPartialFailure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+
+		mockInspector.EXPECT().ApplyTaskAction(gomock.Eq("critical"), gomock.Eq(consts.TaskActionRun), gomock.Eq("task-1")).Return(nil)
+		mockInspector.EXPECT().ApplyTaskAction(gomock.Eq("critical"), gomock.Eq(consts.TaskActionRun), gomock.Eq("task-2")).Return(asynq.ErrTaskNotFound)
+
+		resp, err := service.ApplyTaskAction(context.Background(), &types.TaskActionReq{
+			Queue: "critical", Action: consts.TaskActionRun, TaskIDs: []string{"task-1", "task-2"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Affected != 1 || resp.Failed["task-2"] != asynq.ErrTaskNotFound.Error() {
+			t.Errorf("Expected 1 affected and task-2 failed, got %+v", resp)
+		}
+	})
+
+	// Test case 2: Archiving every task in a state goes through one call
+	t.Run("ArchiveAll", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+
+		mockInspector.EXPECT().ApplyTaskActionToAll(gomock.Eq("bulk"), gomock.Eq(consts.TaskActionArchive), gomock.Eq("retry")).Return(12, nil)
+
+		resp, err := service.ApplyTaskAction(context.Background(), &types.TaskActionReq{
+			Queue: "bulk", Action: consts.TaskActionArchive, State: "retry", All: true,
+		})
+		if err != nil || resp.Affected != 12 || resp.Failed != nil {
+			t.Errorf("Expected 12 affected, got %+v, %v", resp, err)
+		}
+	})
+
+	// Test case 3: An unknown queue fails the whole request
+	t.Run("ErrorQueueNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+		service := NewTaskAdminServiceImpl(mockInspector)
+
+		mockInspector.EXPECT().ApplyTaskAction(gomock.Any(), gomock.Any(), gomock.Eq("task-1")).Return(asynq.ErrQueueNotFound)
+
+		_, err := service.ApplyTaskAction(context.Background(), &types.TaskActionReq{
+			Queue: "missing", Action: consts.TaskActionDelete, TaskIDs: []string{"task-1", "task-2"},
+		})
+		if !errors.Is(err, asynq.ErrQueueNotFound) {
+			t.Errorf("Expected error %v, got %v", asynq.ErrQueueNotFound, err)
+		}
+	})
+}
+
+// Test cases for TaskAdminServiceImpl.PauseQueue
+func TestPauseQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		pause   bool
+		err     error
+		wantErr error
+	}{
+		{name: "Pause", pause: true},
+		{name: "Unpause", pause: false},
+		{name: "ErrorQueueNotFound", pause: true, err: asynq.ErrQueueNotFound, wantErr: asynq.ErrQueueNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInspector := mocks.NewMockAsynqInspectorRepository(ctrl)
+			service := NewTaskAdminServiceImpl(mockInspector)
+
+			if tt.pause {
+				mockInspector.EXPECT().PauseQueue(gomock.Eq("bulk")).Return(tt.err)
+			} else {
+				mockInspector.EXPECT().UnpauseQueue(gomock.Eq("bulk")).Return(tt.err)
+			}
+
+			if err := service.PauseQueue(context.Background(), "bulk", tt.pause); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// defaultTagLimit is the number of tags suggested when the request sets none.
+const defaultTagLimit = 10
+
+type TaxonomyServiceImpl struct {
+	repo domain.TaxonomyRepository
+}
+
+func NewTaxonomyServiceImpl(repo domain.TaxonomyRepository) *TaxonomyServiceImpl {
+	return &TaxonomyServiceImpl{
+		repo: repo,
+	}
+}
+
+func (svc *TaxonomyServiceImpl) ListCategories(ctx context.Context) ([]models.Category, error) {
+	return svc.repo.ListCategories(ctx)
+}
+
+func (svc *TaxonomyServiceImpl) CreateCategory(ctx context.Context, req *types.CategoryRequest) (*models.Category, error) {
+	if err := svc.checkCategoryName(ctx, req.Name, 0); err != nil {
+		return nil, err
+	}
+	category := &models.Category{Name: req.Name}
+	if err := svc.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (svc *TaxonomyServiceImpl) UpdateCategory(ctx context.Context, req *types.CategoryRequest) (*models.Category, error) {
+	category, err := svc.repo.ReadCategory(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkCategoryName(ctx, req.Name, req.ID); err != nil {
+		return nil, err
+	}
+	category.Name = req.Name
+	if err := svc.repo.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (svc *TaxonomyServiceImpl) checkCategoryName(ctx context.Context, name string, id int) error {
+	count, err := svc.repo.CategoryCountByName(ctx, name, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errutil.ErrCategoryAlreadyExist
+	}
+	return nil
+}
+
+func (svc *TaxonomyServiceImpl) DeleteCategory(ctx context.Context, id int) error {
+	return svc.repo.DeleteCategory(ctx, id)
+}
+
+// ListTags suggests the tags starting with the search text, tag names are
+// lower case so the text is too.
+func (svc *TaxonomyServiceImpl) ListTags(ctx context.Context, req types.ListTagReq) ([]types.TagResp, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTagLimit
+	}
+	tags, err := svc.repo.ListTags(ctx, strings.ToLower(strings.TrimSpace(req.Search)), limit)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		tags = []types.TagResp{}
+	}
+	return tags, nil
+}
+
+func (svc *TaxonomyServiceImpl) DeleteTag(ctx context.Context, id int) error {
+	return svc.repo.DeleteTag(ctx, id)
+}
@@ -1,15 +1,15 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
-	"github.com/labstack/gommon/log"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
 	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
+	"log/slog"
 	"time"
 )
 
@@ -43,7 +43,7 @@ func (*TokenServiceImpl) CreateToken(userID int) (*types.Token, error) {
 	var err error
 	token.AccessToken, err = at.SignedString([]byte(jwtConf.AccessTokenSecret))
 	if err != nil {
-		logger.Error(err)
+		slog.Error("failed to sign access token", "err", err)
 		return nil, errutil.ErrAccessTokenSign
 	}
 
@@ -56,7 +56,7 @@ func (*TokenServiceImpl) CreateToken(userID int) (*types.Token, error) {
 	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
 	token.RefreshToken, err = rt.SignedString([]byte(jwtConf.RefreshTokenSecret))
 	if err != nil {
-		log.Error(err)
+		slog.Error("failed to sign refresh token", "err", err)
 		return nil, errutil.ErrRefreshTokenSign
 	}
 
@@ -66,7 +66,7 @@ func (*TokenServiceImpl) CreateToken(userID int) (*types.Token, error) {
 func (svc *TokenServiceImpl) ParseAccessToken(accessToken string) (*types.Token, error) {
 	parsedToken, err := methodutil.ParseJwtToken(accessToken, config.Jwt().AccessTokenSecret)
 	if err != nil {
-		log.Error(err)
+		slog.Warn("failed to parse access token", "err", err)
 		return nil, errutil.ErrParseJwt
 	}
 
@@ -82,13 +82,13 @@ func (svc *TokenServiceImpl) ParseAccessToken(accessToken string) (*types.Token,
 	return mapClaimsToToken(claims)
 }
 
-func (svc *TokenServiceImpl) StoreTokenUUID(token *types.Token) error {
-	err := svc.redisSvc.Set(methodutil.AccessUuidCacheKey(token.AccessUuid), token.UserID, time.Duration(token.AccessExpiry))
+func (svc *TokenServiceImpl) StoreTokenUUID(ctx context.Context, token *types.Token) error {
+	err := svc.redisSvc.Set(ctx, methodutil.AccessUuidCacheKey(token.AccessUuid), token.UserID, time.Duration(token.AccessExpiry))
 	if err != nil {
 		return err
 	}
 
-	err = svc.redisSvc.Set(methodutil.RefreshUuidCacheKey(token.RefreshUuid), token.UserID, time.Duration(token.RefreshExpiry))
+	err = svc.redisSvc.Set(ctx, methodutil.RefreshUuidCacheKey(token.RefreshUuid), token.UserID, time.Duration(token.RefreshExpiry))
 	if err != nil {
 		return err
 	}
@@ -96,8 +96,8 @@ func (svc *TokenServiceImpl) StoreTokenUUID(token *types.Token) error {
 	return nil
 }
 
-func (svc *TokenServiceImpl) DeleteTokenUUID(token *types.Token) error {
-	err := svc.redisSvc.Del(methodutil.AccessUuidCacheKey(token.AccessUuid), methodutil.RefreshUuidCacheKey(token.RefreshUuid))
+func (svc *TokenServiceImpl) DeleteTokenUUID(ctx context.Context, token *types.Token) error {
+	err := svc.redisSvc.Del(ctx, methodutil.AccessUuidCacheKey(token.AccessUuid), methodutil.RefreshUuidCacheKey(token.RefreshUuid))
 
 	if err != nil {
 		return err
@@ -106,8 +106,8 @@ func (svc *TokenServiceImpl) DeleteTokenUUID(token *types.Token) error {
 	return nil
 }
 
-func (svc *TokenServiceImpl) ReadUserIDFromAccessTokenUUID(accessTokenUuid string) (int, error) {
-	userID, err := svc.redisSvc.GetInt(methodutil.AccessUuidCacheKey(accessTokenUuid))
+func (svc *TokenServiceImpl) ReadUserIDFromAccessTokenUUID(ctx context.Context, accessTokenUuid string) (int, error) {
+	userID, err := svc.redisSvc.GetInt(ctx, methodutil.AccessUuidCacheKey(accessTokenUuid))
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
+)
+
+type TrashServiceImpl struct {
+	repo domain.TrashRepository
+}
+
+func NewTrashServiceImpl(repo domain.TrashRepository) *TrashServiceImpl {
+	return &TrashServiceImpl{
+		repo: repo,
+	}
+}
+
+func (svc *TrashServiceImpl) ListEvents(ctx context.Context, req types.ListTrashReq) (*types.PaginatedTrashedEventResp, error) {
+	events, total, err := svc.repo.ListDeletedEvents(ctx, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PaginatedTrashedEventResp{
+		Total:  total,
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Events: events,
+	}, nil
+}
+
+func (svc *TrashServiceImpl) RestoreEvent(ctx context.Context, id int) error {
+	return svc.repo.RestoreEvent(ctx, id)
+}
+
+func (svc *TrashServiceImpl) ListUsers(ctx context.Context, req types.ListTrashReq) (*types.PaginatedTrashedUserResp, error) {
+	users, total, err := svc.repo.ListDeletedUsers(ctx, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PaginatedTrashedUserResp{
+		Total: total,
+		Page:  req.Page,
+		Limit: req.Limit,
+		Users: users,
+	}, nil
+}
+
+func (svc *TrashServiceImpl) RestoreUser(ctx context.Context, id int) error {
+	return svc.repo.RestoreUser(ctx, id)
+}
+
+// Purge permanently deletes what was deleted before the cutoff. Events go
+// first so the users who created them can be purged in the same run.
+func (svc *TrashServiceImpl) Purge(ctx context.Context, before time.Time) error {
+	log := logutil.FromContext(ctx)
+
+	events, err := svc.repo.PurgeDeletedEvents(ctx, before)
+	if err != nil {
+		return err
+	}
+	users, err := svc.repo.PurgeDeletedUsers(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	log.Info("purged trash", "before", before, "events", events, "users", users)
+	return nil
+}
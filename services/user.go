@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis"
@@ -10,27 +13,32 @@ import (
 	"github.com/vivasoft-ltd/go-ems/models"
 	"github.com/vivasoft-ltd/go-ems/types"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/logutil"
 	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
-	"github.com/vivasoft-ltd/golang-course-utils/logger"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type UserServiceImpl struct {
-	redisSvc *RedisService
-	repo     domain.UserRepository
+	redisSvc    *RedisService
+	repo        domain.UserRepository
+	notifierSvc domain.NotifierService
 }
 
-func NewUserServiceImpl(redisSvc *RedisService, userRepo domain.UserRepository) *UserServiceImpl {
+func NewUserServiceImpl(redisSvc *RedisService, userRepo domain.UserRepository, notifierSvc domain.NotifierService) *UserServiceImpl {
 	return &UserServiceImpl{
-		redisSvc: redisSvc,
-		repo:     userRepo,
+		redisSvc:    redisSvc,
+		repo:        userRepo,
+		notifierSvc: notifierSvc,
 	}
 }
 
-func (svc *UserServiceImpl) CreateUser(req *types.CreateUserReq) error {
-	isExist, err := svc.IsEmailExist(req.Email)
+func (svc *UserServiceImpl) CreateUser(ctx context.Context, req *types.CreateUserReq) error {
+	isExist, err := svc.IsEmailExist(ctx, req.Email)
 	if err != nil {
 		return err
 	}
@@ -41,7 +49,7 @@ func (svc *UserServiceImpl) CreateUser(req *types.CreateUserReq) error {
 
 	hashedPass, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while generating password hash", err))
+		logutil.FromContext(ctx).Error("failed to generate password hash", "err", err)
 		return err
 	}
 
@@ -53,62 +61,62 @@ func (svc *UserServiceImpl) CreateUser(req *types.CreateUserReq) error {
 		RoleID:    req.RoleID,
 	}
 
-	if _, err := svc.repo.CreateUser(user); err != nil {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while creating user, email: [%s]", err, user.Email))
+	if _, err := svc.repo.CreateUser(ctx, user); err != nil {
+		logutil.FromContext(ctx).Error("failed to create user", "err", err, "email", user.Email)
 		return err
 	}
 
 	return nil
 }
 
-func (svc *UserServiceImpl) UpdateUser(req *types.UpdateUserReq) error {
-	existingUser, err := svc.ReadUser(req.ID, false)
+func (svc *UserServiceImpl) UpdateUser(ctx context.Context, req *types.UpdateUserReq) error {
+	existingUser, err := svc.ReadUser(ctx, req.ID, false)
 	if err != nil {
 		return err
 	}
+	if req.Version != 0 && req.Version != existingUser.Version {
+		return errutil.ErrVersionConflict
+	}
 	user := &models.User{
 		ID:        existingUser.ID,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		// without If-Match, still don't overwrite a change made since the read
+		Version: existingUser.Version,
 	}
 
-	if err := svc.repo.UpdateUser(user); err != nil {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while updating user, user id: [%d]", err, user.ID))
+	if err := svc.repo.UpdateUser(ctx, user); err != nil {
+		logutil.FromContext(ctx).Error("failed to update user", "err", err, "user_id", user.ID)
 		return err
 	}
 
-	go func() {
-		if err := svc.redisSvc.Del(methodutil.UserCacheKey(existingUser.ID)); err != nil {
-			logger.Error(err)
-		}
-	}()
+	svc.evictCache(ctx, methodutil.UserCacheKey(existingUser.ID))
 
 	return nil
 }
 
-func (svc *UserServiceImpl) DeleteUser(id int) error {
-	existingUser, err := svc.ReadUser(id, false)
+func (svc *UserServiceImpl) DeleteUser(ctx context.Context, id, version int) error {
+	existingUser, err := svc.ReadUser(ctx, id, false)
 	if err != nil {
 		return err
 	}
+	if version != 0 && version != existingUser.Version {
+		return errutil.ErrVersionConflict
+	}
 
-	if err := svc.repo.DeleteUser(existingUser.ID); err != nil {
+	if err := svc.repo.DeleteUser(ctx, existingUser.ID, version); err != nil {
 		return err
 	}
 
-	go func() {
-		if err := svc.redisSvc.Del(methodutil.UserCacheKey(existingUser.ID)); err != nil {
-			logger.Error(err)
-		}
-	}()
+	svc.evictCache(ctx, methodutil.UserCacheKey(existingUser.ID))
 
 	return nil
 }
 
-func (svc *UserServiceImpl) IsEmailExist(email string) (bool, error) {
-	count, err := svc.repo.UserCountByEmail(email)
+func (svc *UserServiceImpl) IsEmailExist(ctx context.Context, email string) (bool, error) {
+	count, err := svc.repo.UserCountByEmail(ctx, email)
 	if err != nil {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while fetching user by email", err))
+		logutil.FromContext(ctx).Error("failed to count users by email", "err", err)
 		return false, err
 	}
 
@@ -116,10 +124,10 @@ func (svc *UserServiceImpl) IsEmailExist(email string) (bool, error) {
 
 }
 
-func (svc *UserServiceImpl) ReadUserByEmail(email string) (*models.User, error) {
-	user, err := svc.repo.ReadUserByEmail(email)
+func (svc *UserServiceImpl) ReadUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	user, err := svc.repo.ReadUserByEmail(ctx, email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while fetching user by user email: [%s]", err, email))
+		logutil.FromContext(ctx).Error("failed to fetch user by email", "err", err, "email", email)
 		return nil, err
 	}
 
@@ -130,33 +138,33 @@ func (svc *UserServiceImpl) ReadUserByEmail(email string) (*models.User, error)
 	return user, nil
 }
 
-func (svc *UserServiceImpl) ReadUser(id int, fromCache bool) (*types.UserInfo, error) {
+func (svc *UserServiceImpl) ReadUser(ctx context.Context, id int, fromCache bool) (*types.UserInfo, error) {
 	if fromCache {
-		return svc.readUserFromCache(id)
+		return svc.readUserFromCache(ctx, id)
 	}
 
-	return svc.readUserFromDB(id)
+	return svc.readUserFromDB(ctx, id)
 }
 
-func (svc *UserServiceImpl) readUserFromCache(id int) (*types.UserInfo, error) {
+func (svc *UserServiceImpl) readUserFromCache(ctx context.Context, id int) (*types.UserInfo, error) {
 	var user *types.UserInfo
-	err := svc.redisSvc.GetStruct(methodutil.UserCacheKey(id), &user)
+	err := svc.redisSvc.GetStruct(ctx, methodutil.UserCacheKey(id), &user)
 	if err != nil && !errors.Is(err, redis.Nil) {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while fetching user from cache by user id: [%d]", err, id))
+		logutil.FromContext(ctx).Error("failed to fetch user from cache", "err", err, "user_id", id)
 		return nil, err
 	}
 
 	if errors.Is(err, redis.Nil) {
-		return svc.readUserFromDB(id)
+		return svc.readUserFromDB(ctx, id)
 	}
 
 	return user, nil
 }
 
-func (svc *UserServiceImpl) readUserFromDB(id int) (*types.UserInfo, error) {
-	user, err := svc.repo.ReadUserById(id)
+func (svc *UserServiceImpl) readUserFromDB(ctx context.Context, id int) (*types.UserInfo, error) {
+	user, err := svc.repo.ReadUserById(ctx, id)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Error(fmt.Sprintf("error occurred: [%v] while fetching user by user id: [%d]", err, id))
+		logutil.FromContext(ctx).Error("failed to fetch user", "err", err, "user_id", id)
 		return nil, err
 	}
 
@@ -165,25 +173,31 @@ func (svc *UserServiceImpl) readUserFromDB(id int) (*types.UserInfo, error) {
 	}
 
 	return &types.UserInfo{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		RoleID:    user.RoleID,
-		Role:      consts.RoleMap[user.RoleID],
-		Events:    user.Events,
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		RoleID:        user.RoleID,
+		Role:          consts.RoleMap[user.RoleID],
+		Phone:         user.Phone,
+		PhoneVerified: user.IsPhoneVerified(),
+		SmsEnabled:    user.SmsEnabled,
+		PushEnabled:   user.PushEnabled,
+		Timezone:      user.Timezone,
+		Events:        user.Events,
+		Version:       user.Version,
 	}, nil
 }
 
-func (svc *UserServiceImpl) StoreInCache(user *types.UserInfo) error {
-	if err := svc.redisSvc.SetStruct(methodutil.UserCacheKey(user.ID), user, config.Redis().UserCacheTTL); err != nil {
-		logger.Error(fmt.Sprintf("could not cache user in redis, id: [%d], err: [%v]", user.ID, err))
+func (svc *UserServiceImpl) StoreInCache(ctx context.Context, user *types.UserInfo) error {
+	if err := svc.redisSvc.SetStruct(ctx, methodutil.UserCacheKey(user.ID), user, config.Redis().UserCacheTTL); err != nil {
+		logutil.FromContext(ctx).Error("could not cache user in redis", "err", err, "user_id", user.ID)
 	}
 	return nil
 }
 
-func (svc *UserServiceImpl) ReadPermissionsByRole(roleID int) ([]*models.Permission, error) {
-	permissions, err := svc.readPermissionFromCache(roleID)
+func (svc *UserServiceImpl) ReadPermissionsByRole(ctx context.Context, roleID int) ([]*models.Permission, error) {
+	permissions, err := svc.readPermissionFromCache(ctx, roleID)
 	if err != nil {
 		return nil, err
 	}
@@ -192,43 +206,83 @@ func (svc *UserServiceImpl) ReadPermissionsByRole(roleID int) ([]*models.Permiss
 		return permissions, nil
 	}
 
-	permissions, err = svc.repo.ReadPermissionsByRole(roleID)
+	permissions, err = svc.repo.ReadPermissionsByRole(ctx, roleID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := svc.storePermissionInCache(roleID, permissions); err != nil {
+	if err := svc.storePermissionInCache(ctx, roleID, permissions); err != nil {
 		return nil, err
 	}
 
 	return permissions, nil
 }
 
-func (svc *UserServiceImpl) readPermissionFromCache(roleID int) ([]*models.Permission, error) {
+func (svc *UserServiceImpl) readPermissionFromCache(ctx context.Context, roleID int) ([]*models.Permission, error) {
 	var permissions []*models.Permission
-	err := svc.redisSvc.GetStruct(config.Redis().MandatoryPrefix+config.Redis().PermissionPrefix+strconv.Itoa(roleID), &permissions)
+	err := svc.redisSvc.GetStruct(ctx, config.Redis().MandatoryPrefix+config.Redis().PermissionPrefix+strconv.Itoa(roleID), &permissions)
 	if err != nil && !errors.Is(err, redis.Nil) {
-		logger.Error(err)
+		logutil.FromContext(ctx).Error("failed to fetch permissions from cache", "err", err, "role_id", roleID)
 		return nil, err
 	}
 
 	return permissions, nil
 }
 
-func (svc *UserServiceImpl) storePermissionInCache(roleID int, permissions []*models.Permission) error {
-	if err := svc.redisSvc.SetStruct(methodutil.PermissionCacheKey(roleID), permissions, config.Redis().PermissionCacheTTL); err != nil {
-		logger.Error(fmt.Sprintf("could not cache permissions in redis, role_id: [%d], err: [%v]", roleID, err))
+func (svc *UserServiceImpl) storePermissionInCache(ctx context.Context, roleID int, permissions []*models.Permission) error {
+	if err := svc.redisSvc.SetStruct(ctx, methodutil.PermissionCacheKey(roleID), permissions, config.Redis().PermissionCacheTTL); err != nil {
+		logutil.FromContext(ctx).Error("could not cache permissions in redis", "err", err, "role_id", roleID)
 		return err
 	}
 	return nil
 }
 
-func (svc *UserServiceImpl) ListUsers(req types.ListUserReq) (*types.PaginatedUserResp, error) {
+// ListUsersByCursor lists the users next to the cursor, from the first one
+// without a cursor.
+func (svc *UserServiceImpl) ListUsersByCursor(ctx context.Context, req types.ListUserReq, cursor *types.Cursor) (*types.CursorUserResponse, error) {
+	users, err := svc.repo.ReadUsersAfter(ctx, cursor, req.Limit+1)
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to fetch users", "err", err)
+		return nil, err
+	}
+
+	more := len(users) > req.Limit
+	if more && cursor.IsBackward() {
+		users = users[1:]
+	} else if more {
+		users = users[:req.Limit]
+	}
+	for i, user := range users {
+		users[i].Role = consts.RoleMap[user.RoleID]
+	}
+
+	resp := &types.CursorUserResponse{
+		CursorPage: types.CursorPage{Limit: req.Limit},
+		Users:      users,
+	}
+	if len(users) > 0 {
+		resp.CursorPage = types.NewCursorPage(req.Limit, cursor, more,
+			types.Cursor{ID: users[0].ID},
+			types.Cursor{ID: users[len(users)-1].ID},
+		)
+	}
+	if req.IncludeTotal {
+		total, err := svc.repo.CountUsers(ctx)
+		if err != nil {
+			logutil.FromContext(ctx).Error("failed to count users", "err", err)
+			return nil, err
+		}
+		resp.Total = &total
+	}
+	return resp, nil
+}
+
+func (svc *UserServiceImpl) ListUsers(ctx context.Context, req types.ListUserReq) (*types.PaginatedUserResp, error) {
 	offset := (req.Page - 1) * req.Limit
 
-	users, total, err := svc.repo.ReadPaginatedUsers(req.Limit, offset)
+	users, total, err := svc.repo.ReadPaginatedUsers(ctx, req.Limit, offset)
 	if err != nil {
-		logger.Error(fmt.Errorf("error occurred: [%v] while fetching paginated users", err))
+		logutil.FromContext(ctx).Error("failed to fetch paginated users", "err", err)
 		return nil, err
 	}
 
@@ -246,16 +300,197 @@ func (svc *UserServiceImpl) ListUsers(req types.ListUserReq) (*types.PaginatedUs
 	return resp, nil
 }
 
-func (svc *UserServiceImpl) ListAttendees(user types.CurrentUser) ([]types.AttendeeResp, error) {
+func (svc *UserServiceImpl) ListAttendees(ctx context.Context, user types.CurrentUser) ([]types.AttendeeResp, error) {
 	filter := &types.AttendeeFilter{}
 	if !user.HasPermission(consts.PermissionFetchAllUserAsAttendee) {
 		filter.RoleID = consts.RoleIdAttendee
 	}
-	users, err := svc.repo.ListAttendees(filter)
+	users, err := svc.repo.ListAttendees(ctx, filter)
 
 	if err != nil {
-		logger.Error(fmt.Errorf("error occurred: [%v] while fetching attendees for user ID: %d", err, user.ID))
+		logutil.FromContext(ctx).Error("failed to fetch attendees", "err", err)
 		return nil, err
 	}
 	return users, nil
 }
+
+// RequestPhoneVerification sends a one-time code to the phone number. The number
+// is only stored on the user once the code is verified.
+func (svc *UserServiceImpl) RequestPhoneVerification(ctx context.Context, userID int, phone string) error {
+	log := logutil.FromContext(ctx)
+
+	user, err := svc.repo.ReadUserById(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errutil.ErrUserNotFound
+	}
+	if err != nil {
+		log.Error("failed to fetch user", "err", err, "user_id", userID)
+		return err
+	}
+
+	if user.IsPhoneVerified() && *user.Phone == phone {
+		return errutil.ErrPhoneAlreadyVerified
+	}
+
+	count, err := svc.repo.UserCountByPhone(ctx, phone)
+	if err != nil {
+		log.Error("failed to count users by phone", "err", err)
+		return err
+	}
+	if count != 0 {
+		return errutil.ErrPhoneAlreadyInUse
+	}
+
+	code, err := generateOtp(config.Notifier().PhoneOtpLength)
+	if err != nil {
+		log.Error("failed to generate phone otp", "err", err)
+		return err
+	}
+
+	// a new code gets a fresh set of attempts
+	if err := svc.redisSvc.Del(ctx, methodutil.PhoneOtpAttemptsCacheKey(userID)); err != nil {
+		log.Error("could not reset phone otp attempts", "err", err, "user_id", userID)
+		return err
+	}
+
+	otp := &types.PhoneOtp{Phone: phone, Code: code}
+	if err := svc.redisSvc.SetStruct(ctx, methodutil.PhoneOtpCacheKey(userID), otp, config.Notifier().PhoneOtpTTL); err != nil {
+		log.Error("could not store phone otp in redis", "err", err, "user_id", userID)
+		return err
+	}
+
+	text := fmt.Sprintf("Your %s verification code is %s", config.App().Name, code)
+	return svc.notifierSvc.SendSms(ctx, phone, text)
+}
+
+// VerifyPhone stores the pending phone number on the user once the code
+// matches. The code is invalidated after PhoneOtpMaxAttempts wrong guesses.
+func (svc *UserServiceImpl) VerifyPhone(ctx context.Context, userID int, code string) error {
+	var otp *types.PhoneOtp
+	err := svc.redisSvc.GetStruct(ctx, methodutil.PhoneOtpCacheKey(userID), &otp)
+	if errors.Is(err, redis.Nil) {
+		return errutil.ErrInvalidOtp
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to fetch phone otp", "err", err, "user_id", userID)
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(otp.Code)) != 1 {
+		return svc.countFailedOtpAttempt(ctx, userID)
+	}
+
+	// the number could have been verified by someone else while the code was pending
+	count, err := svc.repo.UserCountByPhone(ctx, otp.Phone)
+	if err != nil {
+		return err
+	}
+	if count != 0 {
+		return errutil.ErrPhoneAlreadyInUse
+	}
+
+	if err := svc.repo.UpdateUserPhone(ctx, userID, otp.Phone, time.Now()); err != nil {
+		logutil.FromContext(ctx).Error("failed to verify phone", "err", err, "user_id", userID)
+		return err
+	}
+
+	svc.evictCache(ctx, methodutil.PhoneOtpCacheKey(userID), methodutil.PhoneOtpAttemptsCacheKey(userID), methodutil.UserCacheKey(userID))
+
+	return nil
+}
+
+// countFailedOtpAttempt records a wrong code and deletes the pending code once
+// the attempts are used up. It always fails with ErrInvalidOtp unless Redis does.
+func (svc *UserServiceImpl) countFailedOtpAttempt(ctx context.Context, userID int) error {
+	log := logutil.FromContext(ctx)
+	attemptsKey := methodutil.PhoneOtpAttemptsCacheKey(userID)
+
+	attempts, err := svc.redisSvc.Incr(ctx, attemptsKey, config.Notifier().PhoneOtpTTL)
+	if err != nil {
+		log.Error("failed to count phone otp attempt", "err", err, "user_id", userID)
+		return err
+	}
+
+	if attempts >= int64(config.Notifier().PhoneOtpMaxAttempts) {
+		log.Warn("too many wrong phone otp attempts, invalidating the code", "user_id", userID, "attempts", attempts)
+		if err := svc.redisSvc.Del(ctx, methodutil.PhoneOtpCacheKey(userID), attemptsKey); err != nil {
+			log.Error("failed to invalidate phone otp", "err", err, "user_id", userID)
+			return err
+		}
+	}
+
+	return errutil.ErrInvalidOtp
+}
+
+func (svc *UserServiceImpl) UpdateNotificationChannels(ctx context.Context, userID int, req *types.UpdateNotificationChannelsReq) error {
+	user, err := svc.repo.ReadUserById(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errutil.ErrUserNotFound
+	}
+	if err != nil {
+		logutil.FromContext(ctx).Error("failed to fetch user", "err", err, "user_id", userID)
+		return err
+	}
+
+	if req.SmsEnabled && !user.IsPhoneVerified() {
+		return errutil.ErrNoPhoneNumberIsSet
+	}
+
+	pushToken := user.PushToken
+	if req.PushToken != nil {
+		pushToken = req.PushToken
+	}
+	if req.PushEnabled && (pushToken == nil || *pushToken == "") {
+		return errutil.ErrNoPushTokenIsSet
+	}
+
+	if err := svc.repo.UpdateNotificationChannels(ctx, userID, req.SmsEnabled, req.PushEnabled, pushToken); err != nil {
+		logutil.FromContext(ctx).Error("failed to update notification channels", "err", err, "user_id", userID)
+		return err
+	}
+
+	svc.evictCache(ctx, methodutil.UserCacheKey(userID))
+
+	return nil
+}
+
+func (svc *UserServiceImpl) UpdateTimezone(ctx context.Context, userID int, req *types.UpdateTimezoneReq) error {
+	if err := svc.repo.UpdateUserTimezone(ctx, userID, req.Timezone); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errutil.ErrUserNotFound
+		}
+		logutil.FromContext(ctx).Error("failed to update timezone", "err", err, "user_id", userID)
+		return err
+	}
+
+	svc.evictCache(ctx, methodutil.UserCacheKey(userID))
+
+	return nil
+}
+
+// evictCache deletes the keys in the background, a failure only leaves stale
+// entries until they expire.
+func (svc *UserServiceImpl) evictCache(ctx context.Context, keys ...string) {
+	log := logutil.FromContext(ctx)
+	go func() {
+		if err := svc.redisSvc.Del(ctx, keys...); err != nil {
+			log.Error("failed to evict cache", "err", err, "keys", keys)
+		}
+	}()
+}
+
+func generateOtp(length int) (string, error) {
+	if length <= 0 {
+		length = 6
+	}
+
+	var sb strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(n.String())
+	}
+	return sb.String(), nil
+}
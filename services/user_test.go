@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/vivasoft-ltd/go-ems/config"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"github.com/vivasoft-ltd/go-ems/utils/methodutil"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestRedisService(t *testing.T) (*RedisService, *miniredis.Miniredis) {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisService(client), srv
+}
+
+// Test cases for UserServiceImpl.VerifyPhone
+func TestVerifyPhone(t *testing.T) {
+	config.LoadConfig()
+	maxAttempts := config.Notifier().PhoneOtpMaxAttempts
+
+	// Test case 1: The code is invalidated after too many wrong guesses
+	t.Run("ErrorTooManyAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		redisSvc, srv := newTestRedisService(t)
+		service := NewUserServiceImpl(redisSvc, mocks.NewMockUserRepository(ctrl), nil)
+		otp := &types.PhoneOtp{Phone: "+61400000000", Code: "123456"}
+		if err := redisSvc.SetStruct(context.Background(), methodutil.PhoneOtpCacheKey(1), otp, 600); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < maxAttempts; i++ {
+			if err := service.VerifyPhone(context.Background(), 1, "000000"); !errors.Is(err, errutil.ErrInvalidOtp) {
+				t.Fatalf("Expected error %v, got %v", errutil.ErrInvalidOtp, err)
+			}
+		}
+		if srv.Exists(methodutil.PhoneOtpCacheKey(1)) {
+			t.Error("Expected the code to be deleted")
+		}
+		// the right code doesn't help anymore
+		if err := service.VerifyPhone(context.Background(), 1, otp.Code); !errors.Is(err, errutil.ErrInvalidOtp) {
+			t.Errorf("Expected error %v, got %v", errutil.ErrInvalidOtp, err)
+		}
+	})
+
+	// Test case 2: The right code within the attempts verifies the phone
+	t.Run("SuccessfulAfterWrongCode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		redisSvc, srv := newTestRedisService(t)
+		service := NewUserServiceImpl(redisSvc, mockUserRepo, nil)
+		otp := &types.PhoneOtp{Phone: "+61400000000", Code: "123456"}
+		if err := redisSvc.SetStruct(context.Background(), methodutil.PhoneOtpCacheKey(1), otp, 600); err != nil {
+			t.Fatal(err)
+		}
+
+		mockUserRepo.EXPECT().UserCountByPhone(gomock.Any(), gomock.Eq(otp.Phone)).Return(0, nil)
+		mockUserRepo.EXPECT().UpdateUserPhone(gomock.Any(), gomock.Eq(1), gomock.Eq(otp.Phone), gomock.Any()).Return(nil)
+
+		if err := service.VerifyPhone(context.Background(), 1, "000000"); !errors.Is(err, errutil.ErrInvalidOtp) {
+			t.Fatalf("Expected error %v, got %v", errutil.ErrInvalidOtp, err)
+		}
+		if attempts, _ := srv.Get(methodutil.PhoneOtpAttemptsCacheKey(1)); attempts != "1" {
+			t.Errorf("Expected 1 counted attempt, got '%s'", attempts)
+		}
+		if err := service.VerifyPhone(context.Background(), 1, otp.Code); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+// Test cases for UserServiceImpl.UpdateNotificationChannels
+func TestUpdateNotificationChannels(t *testing.T) {
+	config.LoadConfig()
+
+	// Test case 1: Push can be enabled with the token already stored
+	t.Run("SuccessfulWithStoredPushToken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		redisSvc, _ := newTestRedisService(t)
+		service := NewUserServiceImpl(redisSvc, mockUserRepo, nil)
+		token := "stored-token"
+
+		mockUserRepo.EXPECT().ReadUserById(gomock.Any(), gomock.Eq(1)).Return(&models.User{ID: 1, PushToken: &token}, nil)
+		mockUserRepo.EXPECT().UpdateNotificationChannels(gomock.Any(), gomock.Eq(1), false, true, gomock.Eq(&token)).Return(nil)
+
+		req := &types.UpdateNotificationChannelsReq{PushEnabled: true}
+		if err := req.Validate(); err != nil {
+			t.Fatalf("Expected a valid request, got %v", err)
+		}
+		if err := service.UpdateNotificationChannels(context.Background(), 1, req); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	// Test case 2: Push can't be enabled without any token
+	t.Run("ErrorNoPushToken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		redisSvc, _ := newTestRedisService(t)
+		service := NewUserServiceImpl(redisSvc, mockUserRepo, nil)
+
+		mockUserRepo.EXPECT().ReadUserById(gomock.Any(), gomock.Eq(1)).Return(&models.User{ID: 1}, nil)
+
+		err := service.UpdateNotificationChannels(context.Background(), 1, &types.UpdateNotificationChannelsReq{PushEnabled: true})
+		if !errors.Is(err, errutil.ErrNoPushTokenIsSet) {
+			t.Errorf("Expected error %v, got %v", errutil.ErrNoPushTokenIsSet, err)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"github.com/vivasoft-ltd/go-ems/domain"
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+type VenueServiceImpl struct {
+	repo domain.VenueRepository
+}
+
+func NewVenueServiceImpl(repo domain.VenueRepository) *VenueServiceImpl {
+	return &VenueServiceImpl{
+		repo: repo,
+	}
+}
+
+func (svc *VenueServiceImpl) ListVenues(ctx context.Context) ([]models.Venue, error) {
+	return svc.repo.ListVenues(ctx)
+}
+
+func (svc *VenueServiceImpl) CreateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error) {
+	if err := svc.checkVenueName(ctx, req.Name, 0); err != nil {
+		return nil, err
+	}
+	venue := &models.Venue{
+		Name:     req.Name,
+		Address:  req.Address,
+		Timezone: req.Timezone,
+	}
+	if err := svc.repo.CreateVenue(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (svc *VenueServiceImpl) UpdateVenue(ctx context.Context, req *types.VenueRequest) (*models.Venue, error) {
+	venue, err := svc.repo.ReadVenue(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkVenueName(ctx, req.Name, req.ID); err != nil {
+		return nil, err
+	}
+	venue.Name = req.Name
+	venue.Address = req.Address
+	venue.Timezone = req.Timezone
+	if err := svc.repo.UpdateVenue(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (svc *VenueServiceImpl) checkVenueName(ctx context.Context, name string, id int) error {
+	count, err := svc.repo.VenueCountByName(ctx, name, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errutil.ErrVenueAlreadyExist
+	}
+	return nil
+}
+
+func (svc *VenueServiceImpl) DeleteVenue(ctx context.Context, id int) error {
+	return svc.repo.DeleteVenue(ctx, id)
+}
+
+func (svc *VenueServiceImpl) CreateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error) {
+	if _, err := svc.repo.ReadVenue(ctx, req.VenueID); err != nil {
+		return nil, err
+	}
+	if err := svc.checkRoomName(ctx, req.VenueID, req.Name, 0); err != nil {
+		return nil, err
+	}
+	room := &models.Room{
+		VenueID:  req.VenueID,
+		Name:     req.Name,
+		Capacity: req.Capacity,
+	}
+	if err := svc.repo.CreateRoom(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// UpdateRoom renames the room and changes its capacity, the room stays in its
+// venue.
+func (svc *VenueServiceImpl) UpdateRoom(ctx context.Context, req *types.RoomRequest) (*models.Room, error) {
+	room, err := svc.repo.ReadRoom(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkRoomName(ctx, room.VenueID, req.Name, req.ID); err != nil {
+		return nil, err
+	}
+	room.Name = req.Name
+	room.Capacity = req.Capacity
+	if err := svc.repo.UpdateRoom(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (svc *VenueServiceImpl) checkRoomName(ctx context.Context, venueID int, name string, id int) error {
+	count, err := svc.repo.RoomCountByName(ctx, venueID, name, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errutil.ErrRoomAlreadyExist
+	}
+	return nil
+}
+
+func (svc *VenueServiceImpl) DeleteRoom(ctx context.Context, id int) error {
+	return svc.repo.DeleteRoom(ctx, id)
+}
+
+// RoomAvailability lists the bookings of the room in the time range, the room
+// is available when there are none.
+func (svc *VenueServiceImpl) RoomAvailability(ctx context.Context, req *types.RoomAvailabilityReq) (*types.RoomAvailabilityResp, error) {
+	if _, err := svc.repo.ReadRoom(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	from, to := req.Range()
+	bookings, err := svc.repo.ListRoomBookings(ctx, req.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if bookings == nil {
+		bookings = []types.RoomBooking{}
+	}
+	return &types.RoomAvailabilityResp{
+		RoomID:    req.ID,
+		From:      from,
+		To:        to,
+		Available: len(bookings) == 0,
+		Bookings:  bookings,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/services/mocks"
+	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+	"go.uber.org/mock/gomock"
+)
+
+// Test cases for VenueServiceImpl.RoomAvailability
+func TestRoomAvailability(t *testing.T) {
+	from := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	to := from.Add(8 * time.Hour)
+	req := &types.RoomAvailabilityReq{
+		ID:   2,
+		From: from.Format(time.RFC3339),
+		To:   to.Format(time.RFC3339),
+	}
+
+	// Test case 1: no event books the room
+	t.Run("Available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockVenueRepo := mocks.NewMockVenueRepository(ctrl)
+		mockVenueRepo.EXPECT().ReadRoom(gomock.Any(), gomock.Eq(2)).Return(&models.Room{ID: 2, Capacity: 40}, nil)
+		mockVenueRepo.EXPECT().
+			ListRoomBookings(gomock.Any(), gomock.Eq(2), gomock.Eq(from), gomock.Eq(to)).
+			Return(nil, nil)
+
+		service := NewVenueServiceImpl(mockVenueRepo)
+		resp, err := service.RoomAvailability(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !resp.Available {
+			t.Error("Expected the room to be available")
+		}
+		if resp.Bookings == nil {
+			t.Error("Expected an empty list of bookings, got nil")
+		}
+	})
+
+	// Test case 2: an event books the room
+	t.Run("Booked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		bookings := []types.RoomBooking{{
+			EventID:   5,
+			Title:     "Kickoff",
+			StartTime: from.Add(time.Hour),
+			EndTime:   from.Add(2 * time.Hour),
+		}}
+		mockVenueRepo := mocks.NewMockVenueRepository(ctrl)
+		mockVenueRepo.EXPECT().ReadRoom(gomock.Any(), gomock.Eq(2)).Return(&models.Room{ID: 2, Capacity: 40}, nil)
+		mockVenueRepo.EXPECT().
+			ListRoomBookings(gomock.Any(), gomock.Eq(2), gomock.Eq(from), gomock.Eq(to)).
+			Return(bookings, nil)
+
+		service := NewVenueServiceImpl(mockVenueRepo)
+		resp, err := service.RoomAvailability(context.Background(), req)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Available {
+			t.Error("Expected the room to be booked")
+		}
+		if len(resp.Bookings) != 1 || resp.Bookings[0].EventID != 5 {
+			t.Errorf("Expected the booking of event 5, got %v", resp.Bookings)
+		}
+	})
+
+	// Test case 3: the room doesn't exist
+	t.Run("ErrorRoomNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockVenueRepo := mocks.NewMockVenueRepository(ctrl)
+		mockVenueRepo.EXPECT().ReadRoom(gomock.Any(), gomock.Eq(2)).Return(nil, errutil.ErrRoomNotFound)
+
+		service := NewVenueServiceImpl(mockVenueRepo)
+		_, err := service.RoomAvailability(context.Background(), req)
+
+		if !errors.Is(err, errutil.ErrRoomNotFound) {
+			t.Errorf("Expected ErrRoomNotFound, got %v", err)
+		}
+	})
+}
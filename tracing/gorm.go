@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GormPlugin records a client span for every statement run through GORM. The
+// span is a child of the statement's context, so repositories must pass theirs
+// with db.WithContext to join the caller's trace.
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+type gormCallback interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	hooks := []struct {
+		name     string
+		callback gormCallback
+		hook     func(*gorm.DB)
+	}{
+		{"before:create", cb.Create().Before("gorm:create"), gormBefore("create")},
+		{"after:create", cb.Create().After("gorm:create"), gormAfter},
+		{"before:query", cb.Query().Before("gorm:query"), gormBefore("query")},
+		{"after:query", cb.Query().After("gorm:query"), gormAfter},
+		{"before:update", cb.Update().Before("gorm:update"), gormBefore("update")},
+		{"after:update", cb.Update().After("gorm:update"), gormAfter},
+		{"before:delete", cb.Delete().Before("gorm:delete"), gormBefore("delete")},
+		{"after:delete", cb.Delete().After("gorm:delete"), gormAfter},
+		{"before:row", cb.Row().Before("gorm:row"), gormBefore("row")},
+		{"after:row", cb.Row().After("gorm:row"), gormAfter},
+		{"before:raw", cb.Raw().Before("gorm:raw"), gormBefore("raw")},
+		{"after:raw", cb.Raw().After("gorm:raw"), gormAfter},
+	}
+
+	for _, h := range hooks {
+		if err := h.callback.Register("tracing:"+h.name, h.hook); err != nil {
+			return fmt.Errorf("could not register gorm callback %s: %w", h.name, err)
+		}
+	}
+	return nil
+}
+
+// statementContext keeps the context the statement came with, so that it can
+// be restored once the span ends.
+type statementContext struct {
+	context.Context
+	parent context.Context
+}
+
+func gormBefore(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		parent := tx.Statement.Context
+		ctx, _ := Start(parent, "gorm "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", tx.Dialector.Name()),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		tx.Statement.Context = statementContext{Context: ctx, parent: parent}
+	}
+}
+
+func gormAfter(tx *gorm.DB) {
+	stmtCtx, ok := tx.Statement.Context.(statementContext)
+	if !ok {
+		return
+	}
+	tx.Statement.Context = stmtCtx.parent
+
+	span := trace.SpanFromContext(stmtCtx)
+	if tx.Statement.Table != "" {
+		span.SetAttributes(attribute.String("db.collection.name", tx.Statement.Table))
+	}
+	// the statement without its variables, they may hold credentials
+	span.SetAttributes(
+		attribute.String("db.query.text", tx.Statement.SQL.String()),
+		attribute.Int64("db.response.affected_rows", tx.Statement.RowsAffected),
+	)
+
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// a lookup miss is an answer and not a failure
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// payloadKey is the task payload field carrying the trace context. Task handlers
+// decode the payload into their own types and ignore it.
+const payloadKey = "_trace"
+
+// InjectPayload adds the trace context of ctx to the JSON object payload. The
+// payload is returned unchanged when there's nothing to propagate or it isn't an
+// object.
+func InjectPayload(ctx context.Context, payload []byte) []byte {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil || fields == nil {
+		return payload
+	}
+
+	trace, err := json.Marshal(carrier)
+	if err != nil {
+		return payload
+	}
+	fields[payloadKey] = trace
+
+	injected, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return injected
+}
+
+// ExtractPayload returns ctx carrying the trace context found in the payload.
+func ExtractPayload(ctx context.Context, payload []byte) context.Context {
+	var fields struct {
+		Trace propagation.MapCarrier `json:"_trace"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || len(fields.Trace) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, fields.Trace)
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPayloadRoundTrip(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	payload := InjectPayload(ctx, []byte(`{"event_id":7}`))
+
+	var decoded struct {
+		EventID int `json:"event_id"`
+	}
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.EventID != 7 {
+		t.Fatalf("payload fields not kept: %s, err: %v", payload, err)
+	}
+
+	got := trace.SpanContextFromContext(ExtractPayload(context.Background(), payload))
+	if got.TraceID() != traceID || got.SpanID() != spanID {
+		t.Fatalf("trace context not carried, got %v/%v", got.TraceID(), got.SpanID())
+	}
+}
+
+func TestInjectPayloadWithoutTrace(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	payload := []byte(`{"event_id":7}`)
+	if got := InjectPayload(context.Background(), payload); string(got) != string(payload) {
+		t.Fatalf("payload changed without a trace: %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedisSpansJoinCallerTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+
+	ctx, parent := Start(context.Background(), "request")
+	rc := Redis(ctx, client)
+	if err := rc.Set("key", "value", 0).Err(); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := rc.Get("missing").Err(); err != redis.Nil {
+		t.Fatalf("expected redis.Nil, got %v", err)
+	}
+	pipe := rc.TxPipeline()
+	pipe.Incr("counter")
+	pipe.Expire("counter", 0)
+	if _, err := pipe.Exec(); err != nil {
+		t.Fatalf("pipeline failed: %v", err)
+	}
+	parent.End()
+
+	// the shared client is left uninstrumented
+	if err := client.Get("key").Err(); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 3 redis spans and the parent, got %d", len(spans))
+	}
+	for _, span := range spans[:3] {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the request span", span.Name())
+		}
+		if span.Status().Code != 0 {
+			t.Errorf("span %q recorded an error: %s", span.Name(), span.Status().Description)
+		}
+	}
+	if spans[0].Name() != "redis set" || spans[2].Name() != "redis pipeline" {
+		t.Errorf("unexpected span names %q, %q", spans[0].Name(), spans[2].Name())
+	}
+}
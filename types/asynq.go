@@ -2,27 +2,132 @@ package types
 
 import (
 	"time"
+
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
 )
 
 type (
 	AsynqOption struct {
-		TaskID           string
-		Retry            int
-		Queue            string
-		RetentionHours   time.Duration
-		DelaySeconds     time.Duration
+		TaskID         string
+		Retry          int
+		Queue          string
+		RetentionHours time.Duration
+		DelaySeconds   time.Duration
+		// ProcessAt schedules the task at an instant, it takes precedence over DelaySeconds.
+		ProcessAt        time.Time
 		UniqueTTLSeconds time.Duration
 	}
 
 	AsynqTaskType string
+
+	QueueInfoResp struct {
+		Queue       string `json:"queue"`
+		Size        int    `json:"size"`
+		Pending     int    `json:"pending"`
+		Active      int    `json:"active"`
+		Scheduled   int    `json:"scheduled"`
+		Retry       int    `json:"retry"`
+		Archived    int    `json:"archived"`
+		Completed   int    `json:"completed"`
+		Processed   int    `json:"processed"`
+		Failed      int    `json:"failed"`
+		Paused      bool   `json:"paused"`
+		LatencyMsec int64  `json:"latency_msec"`
+	}
+
+	TaskInfoResp struct {
+		ID            string      `json:"id"`
+		Queue         string      `json:"queue"`
+		Type          string      `json:"type"`
+		State         string      `json:"state"`
+		Payload       interface{} `json:"payload"`
+		MaxRetry      int         `json:"max_retry"`
+		Retried       int         `json:"retried"`
+		LastErr       string      `json:"last_error,omitempty"`
+		LastFailedAt  *time.Time  `json:"last_failed_at,omitempty"`
+		NextProcessAt *time.Time  `json:"next_process_at,omitempty"`
+		CompletedAt   *time.Time  `json:"completed_at,omitempty"`
+		Result        string      `json:"result,omitempty"`
+	}
+
+	ListTasksReq struct {
+		Queue string `param:"queue"`
+		State string `query:"state"`
+		Page  int    `query:"page"`
+		Limit int    `query:"limit"`
+	}
+
+	// TaskActionReq applies the action either to the listed tasks or, with All,
+	// to every task of the queue in the given state.
+	TaskActionReq struct {
+		Queue   string   `param:"queue"`
+		Action  string   `param:"action"`
+		State   string   `json:"state"`
+		TaskIDs []string `json:"task_ids"`
+		All     bool     `json:"all"`
+	}
+
+	TaskActionResp struct {
+		Affected int               `json:"affected"`
+		Failed   map[string]string `json:"failed,omitempty"`
+	}
+
+	QueueReq struct {
+		Queue string `param:"queue"`
+	}
+
+	InvitationBatchPayload struct {
+		EventID int `json:"event_id"`
+	}
+
+	EventCancellationPayload struct {
+		EventID int `json:"event_id"`
+	}
 )
 
+var taskStates = []interface{}{
+	consts.TaskStatePending,
+	consts.TaskStateActive,
+	consts.TaskStateScheduled,
+	consts.TaskStateRetry,
+	consts.TaskStateArchived,
+	consts.TaskStateCompleted,
+}
+
+func (r *ListTasksReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Queue, v.Required),
+		v.Field(&r.State, v.Required, v.In(taskStates...)),
+	)
+}
+
+func (r *TaskActionReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Queue, v.Required),
+		v.Field(&r.Action, v.Required, v.In(consts.TaskActionRun, consts.TaskActionArchive, consts.TaskActionDelete)),
+		v.Field(&r.State, v.When(r.All, v.Required, v.In(taskStates...))),
+		v.Field(&r.TaskIDs, v.When(!r.All, v.Required)),
+	)
+}
+
+func (r *QueueReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Queue, v.Required),
+	)
+}
+
 func (t AsynqTaskType) String() string {
 	return string(t)
 }
 
 const (
-	AsynqTaskTypeInvitationEmail    AsynqTaskType = "go:ems:invitation_email"
-	AsynqTaskTypeEventReminder      AsynqTaskType = "go:ems:event_reminder"
-	AsynqTaskTypeEventReminderEmail AsynqTaskType = "go:ems:event_reminder_email"
+	AsynqTaskTypeInvitationEmail     AsynqTaskType = "go:ems:invitation_email"
+	AsynqTaskTypeEventReminder       AsynqTaskType = "go:ems:event_reminder"
+	AsynqTaskTypeEventReminderEmail  AsynqTaskType = "go:ems:event_reminder_email"
+	AsynqTaskTypeEventCancellation   AsynqTaskType = "go:ems:event_cancellation"
+	AsynqTaskTypeCancellationEmail   AsynqTaskType = "go:ems:cancellation_email"
+	AsynqTaskTypeChannelNotification AsynqTaskType = "go:ems:channel_notification"
+	AsynqTaskTypeInvitationBatch     AsynqTaskType = "go:ems:invitation_batch"
+	AsynqTaskTypePurgeTrash          AsynqTaskType = "go:ems:purge_trash"
 )
@@ -1,7 +1,33 @@
 package types
 
-type EmailPayload struct {
-	MailTo  string      `json:"mail_to"`
-	Subject string      `json:"subject"`
-	Body    interface{} `json:"body"`
+import (
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/vivasoft-ltd/go-ems/consts"
+)
+
+type (
+	EmailPayload struct {
+		MessageID string      `json:"message_id,omitempty"`
+		MailTo    string      `json:"mail_to"`
+		Subject   string      `json:"subject"`
+		Body      interface{} `json:"body"`
+	}
+
+	// EmailDeliveryEventReq is the bounce/complaint notification posted by the mail provider.
+	EmailDeliveryEventReq struct {
+		Type              string `json:"type"`
+		Email             string `json:"email"`
+		MessageID         string `json:"message_id"`
+		ProviderMessageID string `json:"provider_message_id"`
+		Reason            string `json:"reason"`
+	}
+)
+
+func (r *EmailDeliveryEventReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Type, v.Required, v.In(consts.EmailDeliveryEventBounce, consts.EmailDeliveryEventComplaint)),
+		v.Field(&r.Email, v.Required, is.Email),
+		v.Field(&r.Reason, v.Length(0, 500)),
+	)
 }
@@ -1,30 +1,57 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/consts"
 	"github.com/vivasoft-ltd/go-ems/models"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
 )
 
 type (
 	CreateEventRequest struct {
-		Title         string  `json:"title"`
-		Description   *string `json:"description"`
-		Location      *string `json:"location"`
-		StartTime     *string `json:"start_time"`
-		EndTime       *string `json:"end_time"`
-		CreatedBy     int     `json:"created_by"`
-		IsPublic      bool    `json:"is_public"`
-		AttendeeLimit *int    `json:"attendee_limit"`
-		Attendees     []int   `json:"attendees"`
+		Title         string   `json:"title"`
+		Description   *string  `json:"description"`
+		Location      *string  `json:"location"`
+		StartTime     *string  `json:"start_time"`
+		EndTime       *string  `json:"end_time"`
+		CreatedBy     int      `json:"created_by"`
+		IsPublic      bool     `json:"is_public"`
+		AttendeeLimit *int     `json:"attendee_limit"`
+		Attendees     []int    `json:"attendees"`
+		CategoryID    *int     `json:"category_id"`
+		Tags          []string `json:"tags"`
+		RoomID        *int     `json:"room_id"`
+		// Timezone is the IANA time zone of the event, times without an
+		// offset are read in it. UTC when empty.
+		Timezone string `json:"timezone"`
 	}
 
 	UpdateEventRequest struct {
-		ID int `param:"id"`
+		ID      int `param:"id"`
+		Version int `json:"-"` // from If-Match, 0 updates any version
 		CreateEventRequest
 	}
 
+	// PatchEventRequest carries an RFC 7396 merge patch of the event's
+	// CreateEventRequest representation.
+	PatchEventRequest struct {
+		ID      int
+		Version int // from If-Match, 0 patches any version
+		Patch   []byte
+	}
+
+	PatchEventResponse struct {
+		UpdateEventResponse
+		// Invitees are the users added to the event by the patch.
+		Invitees []models.User `json:"-"`
+	}
+
 	CreateEventResponse struct {
 		Message string        `json:"message"`
 		Event   *models.Event `json:"event"`
@@ -43,14 +70,57 @@ type (
 		UserID   int `json:"user_id"`
 		StatusID int `json:"status_id"`
 	}
+	// EventFilter scopes the events to what the user may see, from CreatedBy,
+	// Attendee and IsPublic, and narrows them down with the search criteria of
+	// the request.
 	EventFilter struct {
 		CreatedBy *int  `query:"created_by"`
 		Attendee  *int  `query:"attendee"`
 		IsPublic  *bool `query:"is_public"`
+
+		From       *time.Time
+		To         *time.Time
+		Search     string
+		Creator    *int
+		RsvpUserID int
+		RsvpStatus int
+		HasSeats   bool
+		CategoryID *int
+		Tags       []string
+		Status     string
+		Sort       string
+		Order      string
 	}
 	ListEventRequest struct {
-		Page  int `query:"page"`
-		Limit int `query:"limit"`
+		Page       int      `query:"page"`
+		Limit      int      `query:"limit"`
+		From       string   `query:"from"`
+		To         string   `query:"to"`
+		Search     string   `query:"q"`
+		CreatedBy  *int     `query:"created_by"`
+		RsvpStatus int      `query:"rsvp_status"`
+		HasSeats   bool     `query:"has_seats"`
+		CategoryID *int     `query:"category_id"`
+		Tags       []string `query:"tag"` // repeatable, events have all of them
+		Status     string   `query:"status"`
+		Sort       string   `query:"sort"`
+		Order      string   `query:"order"`
+		// Cursor switches to keyset pagination, empty for the first page.
+		Cursor       *string `query:"cursor"`
+		IncludeTotal bool    `query:"include_total"`
+	}
+	EventAttendeeResp struct {
+		UserID            int        `json:"user_id"`
+		Email             string     `json:"email"`
+		FirstName         string     `json:"first_name"`
+		LastName          string     `json:"last_name"`
+		StatusID          int        `json:"status_id"`
+		DeliveryStatus    *string    `json:"delivery_status"`
+		DeliveryUpdatedAt *time.Time `json:"delivery_updated_at"`
+	}
+	InvitationProgressResp struct {
+		models.InvitationBatch
+		Deliveries map[string]int `json:"deliveries"`
 	}
 	PaginatedEventResponse struct {
 		Total  int             `json:"total"`
@@ -58,8 +128,67 @@ type (
 		Limit  int             `json:"limit"`
 		Events []*models.Event `json:"events"`
 	}
+
+	EventStatusRequest struct {
+		ID      int `param:"id"`
+		Version int `json:"-"`
+	}
+
+	EventReviewRequest struct {
+		ID         int    `param:"id"`
+		Comment    string `json:"comment"`
+		Version    int    `json:"-"`
+		ReviewerID int    `json:"-"`
+		// Approve publishes the event, a rejection sends it back to draft.
+		Approve bool `json:"-"`
+	}
+
+	CancelEventRequest struct {
+		ID      int    `param:"id"`
+		Reason  string `json:"reason"`
+		Version int    `json:"-"`
+	}
+
+	CancelEventResponse struct {
+		UpdateEventResponse
+		// WasPublished is set when the event had been published, so invitations
+		// and reminders may be out.
+		WasPublished bool `json:"-"`
+	}
+
+	// EventTransitionError is returned for a status change the event doesn't
+	// allow from its current status.
+	EventTransitionError struct {
+		From string
+		To   string
+	}
 )
 
+func (e *EventTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s to %s", errutil.ErrEventTransitionNotAllowed, e.From, e.To)
+}
+
+func (e *EventTransitionError) Unwrap() error {
+	return errutil.ErrEventTransitionNotAllowed
+}
+
+func (r *CancelEventRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	return v.ValidateStruct(r,
+		v.Field(&r.ID, v.Required),
+		v.Field(&r.Reason, v.Required, v.Length(1, 500)),
+	)
+}
+
+func (r *EventReviewRequest) Validate() error {
+	r.Comment = strings.TrimSpace(r.Comment)
+	return v.ValidateStruct(r,
+		v.Field(&r.ID, v.Required),
+		// the creator needs to know what to change
+		v.Field(&r.Comment, v.When(!r.Approve, v.Required), v.Length(0, 500)),
+	)
+}
+
 func (r *RsvpEventRequest) Validate() error {
 	return v.ValidateStruct(r,
 		v.Field(&r.EventID, v.Required),
@@ -67,17 +196,116 @@ func (r *RsvpEventRequest) Validate() error {
 	)
 }
 
+func (r *ListEventRequest) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.From, v.Date(time.RFC3339)),
+		v.Field(&r.To, v.Date(time.RFC3339)),
+		v.Field(&r.Search, v.Length(0, 100)),
+		v.Field(&r.RsvpStatus, v.In(consts.StatusInvited, consts.StatusAccepted, consts.StatusRejected)),
+		v.Field(&r.Tags, v.Length(0, consts.MaxEventTags), v.Each(v.Length(1, consts.MaxTagLength))),
+		v.Field(&r.Status, v.In(consts.EventStatusDraft, consts.EventStatusPendingApproval, consts.EventStatusPublished, consts.EventStatusCancelled, consts.EventStatusCompleted)),
+		v.Field(&r.Sort,
+			v.In("start_time", "end_time", "created_at", "title"),
+			// the cursor is a position on (start_time, id)
+			v.When(r.Cursor != nil, v.In("start_time")),
+		),
+		v.Field(&r.Order, v.In(consts.SortAsc, consts.SortDesc)),
+	)
+}
+
+// Filter returns the search criteria of the request, the caller adds the scope.
+func (r *ListEventRequest) Filter() *EventFilter {
+	filter := &EventFilter{
+		Search:     strings.TrimSpace(r.Search),
+		Creator:    r.CreatedBy,
+		RsvpStatus: r.RsvpStatus,
+		HasSeats:   r.HasSeats,
+		CategoryID: r.CategoryID,
+		Tags:       NormalizeTags(r.Tags),
+		Status:     r.Status,
+		Sort:       r.Sort,
+		Order:      r.Order,
+	}
+	if r.From != "" {
+		filter.From, _ = parseTime(r.From, time.RFC3339)
+	}
+	if r.To != "" {
+		filter.To, _ = parseTime(r.To, time.RFC3339)
+	}
+	return filter
+}
+
 func (cereq *CreateEventRequest) Validate() error {
 	return v.ValidateStruct(cereq,
 		v.Field(&cereq.Title, v.Required),
 		v.Field(&cereq.Description, v.When(cereq.Description != nil, v.Length(0, 500))),
 		v.Field(&cereq.Location, v.When(cereq.Location != nil, v.Length(0, 255))),
-		v.Field(&cereq.StartTime, v.When(cereq.StartTime != nil, v.Date(time.RFC3339))),
-		v.Field(&cereq.EndTime, v.When(cereq.EndTime != nil, v.Date(time.RFC3339))),
+		// a room is booked for a time range
+		v.Field(&cereq.StartTime, v.When(cereq.RoomID != nil, v.Required), v.By(cereq.eventTime)),
+		v.Field(&cereq.EndTime, v.When(cereq.RoomID != nil, v.Required, v.By(cereq.endsAfterStart)), v.By(cereq.eventTime)),
+		v.Field(&cereq.Timezone, v.Length(0, 64), v.By(validTimezone)),
 		v.Field(&cereq.Attendees, v.When(!cereq.IsPublic, v.Required, v.Length(1, 0))),
+		v.Field(&cereq.Tags, v.Length(0, consts.MaxEventTags), v.Each(v.Length(1, consts.MaxTagLength))),
 	)
 }
 
+func (cereq *CreateEventRequest) eventTime(value interface{}) error {
+	t, _ := value.(*string)
+	if t == nil {
+		return nil
+	}
+	if _, err := parseEventTime(*t, cereq.Timezone); err != nil {
+		return errors.New("must be an RFC 3339 time or a local time like 2006-01-02T15:04:05")
+	}
+	return nil
+}
+
+func (cereq *CreateEventRequest) endsAfterStart(interface{}) error {
+	if cereq.StartTime == nil || cereq.EndTime == nil {
+		return nil
+	}
+	start, err := parseEventTime(*cereq.StartTime, cereq.Timezone)
+	if err != nil {
+		return nil
+	}
+	end, err := parseEventTime(*cereq.EndTime, cereq.Timezone)
+	if err != nil {
+		return nil
+	}
+	if !end.After(*start) {
+		return errors.New("must be after start_time")
+	}
+	return nil
+}
+
+// NormalizeTags trims and lower cases the tag names and drops the empty and
+// repeated ones. nil stays nil, so callers can tell "keep" from "clear".
+func NormalizeTags(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+func toTags(names []string) []models.Tag {
+	names = NormalizeTags(names)
+	if names == nil {
+		return nil
+	}
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{Name: name})
+	}
+	return tags
+}
+
 func (uereq *UpdateEventRequest) Validate() error {
 	return v.ValidateStruct(uereq,
 		v.Field(&uereq.ID, v.Required),
@@ -93,16 +321,59 @@ func (cereq *CreateEventRequest) ToEvent() *models.Event {
 		CreatedBy:   cereq.CreatedBy,
 		IsPublic:    cereq.IsPublic,
 		Limit:       cereq.AttendeeLimit,
+		CategoryID:  cereq.CategoryID,
+		RoomID:      cereq.RoomID,
+		Tags:        toTags(cereq.Tags),
+		Timezone:    cereq.Timezone,
+		// invitations go out once the event is published
+		Status: consts.EventStatusDraft,
+	}
+	if event.Timezone == "" {
+		event.Timezone = time.UTC.String()
 	}
 	if cereq.StartTime != nil {
-		event.StartTime, _ = parseTime(*cereq.StartTime, time.RFC3339)
+		event.StartTime, _ = parseEventTime(*cereq.StartTime, cereq.Timezone)
 	}
 	if cereq.EndTime != nil {
-		event.EndTime, _ = parseTime(*cereq.EndTime, time.RFC3339)
+		event.EndTime, _ = parseEventTime(*cereq.EndTime, cereq.Timezone)
 	}
 	return event
 }
 
+// NewCreateEventRequest returns the event as the request that would create it,
+// the document merge patches are applied to.
+func NewCreateEventRequest(event *models.Event) *CreateEventRequest {
+	req := &CreateEventRequest{
+		Title:         event.Title,
+		Description:   event.Description,
+		Location:      event.Location,
+		CreatedBy:     event.CreatedBy,
+		IsPublic:      event.IsPublic,
+		AttendeeLimit: event.Limit,
+		Attendees:     make([]int, 0, len(event.Attendees)),
+		CategoryID:    event.CategoryID,
+		RoomID:        event.RoomID,
+		Tags:          make([]string, 0, len(event.Tags)),
+		Timezone:      event.Timezone,
+	}
+	loc := Location(event.Timezone)
+	if event.StartTime != nil {
+		startTime := event.StartTime.In(loc).Format(time.RFC3339)
+		req.StartTime = &startTime
+	}
+	if event.EndTime != nil {
+		endTime := event.EndTime.In(loc).Format(time.RFC3339)
+		req.EndTime = &endTime
+	}
+	for _, attendee := range event.Attendees {
+		req.Attendees = append(req.Attendees, attendee.ID)
+	}
+	for _, tag := range event.Tags {
+		req.Tags = append(req.Tags, tag.Name)
+	}
+	return req
+}
+
 func (uereq *UpdateEventRequest) ToEvent() *models.Event {
 	event := &models.Event{
 		ID:          uereq.ID,
@@ -110,12 +381,17 @@ func (uereq *UpdateEventRequest) ToEvent() *models.Event {
 		Description: uereq.Description,
 		Location:    uereq.Location,
 		CreatedBy:   uereq.CreatedBy,
+		CategoryID:  uereq.CategoryID,
+		RoomID:      uereq.RoomID,
+		Tags:        toTags(uereq.Tags),
+		// empty keeps the time zone of the event
+		Timezone: uereq.Timezone,
 	}
 	if uereq.StartTime != nil {
-		event.StartTime, _ = parseTime(*uereq.StartTime, time.RFC3339)
+		event.StartTime, _ = parseEventTime(*uereq.StartTime, uereq.Timezone)
 	}
 	if uereq.EndTime != nil {
-		event.EndTime, _ = parseTime(*uereq.EndTime, time.RFC3339)
+		event.EndTime, _ = parseEventTime(*uereq.EndTime, uereq.Timezone)
 	}
 	return event
 }
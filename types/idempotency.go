@@ -0,0 +1,19 @@
+package types
+
+type (
+	// IdempotencyRecord is what is kept of the first request made with an
+	// Idempotency-Key, Status stays 0 until its response is stored. Token
+	// identifies the request holding the reservation.
+	IdempotencyRecord struct {
+		BodyHash    string            `json:"body_hash"`
+		Token       string            `json:"token,omitempty"`
+		Status      int               `json:"status"`
+		ContentType string            `json:"content_type,omitempty"`
+		Header      map[string]string `json:"header,omitempty"`
+		Body        []byte            `json:"body,omitempty"`
+	}
+)
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
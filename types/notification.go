@@ -0,0 +1,36 @@
+package types
+
+import (
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+type (
+	ListNotificationReq struct {
+		Page   int   `query:"page"`
+		Limit  int   `query:"limit"`
+		Unread *bool `query:"unread"`
+	}
+
+	NotificationFilter struct {
+		Unread *bool
+	}
+
+	PaginatedNotificationResp struct {
+		Total         int                    `json:"total"`
+		Unread        int                    `json:"unread"`
+		Page          int                    `json:"page"`
+		Limit         int                    `json:"limit"`
+		Notifications []*models.Notification `json:"notifications"`
+	}
+
+	NotificationReq struct {
+		ID int `param:"id"`
+	}
+)
+
+func (rq *NotificationReq) Validate() error {
+	return v.ValidateStruct(rq,
+		v.Field(&rq.ID, v.Required, v.Min(1)),
+	)
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"regexp"
+
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// phoneRegex accepts E.164 formatted numbers, e.g. +8801712345678
+var phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+type (
+	ChannelMessage struct {
+		Title string                 `json:"title"`
+		Body  string                 `json:"body"`
+		Data  map[string]interface{} `json:"data,omitempty"`
+	}
+
+	ChannelNotificationPayload struct {
+		UserID  int            `json:"user_id"`
+		Channel string         `json:"channel"`
+		Message ChannelMessage `json:"message"`
+	}
+
+	SmsPayload struct {
+		To      string `json:"to"`
+		Message string `json:"message"`
+	}
+
+	PushPayload struct {
+		Token string                 `json:"token"`
+		Title string                 `json:"title"`
+		Body  string                 `json:"body"`
+		Data  map[string]interface{} `json:"data,omitempty"`
+	}
+
+	UpdatePhoneReq struct {
+		Phone string `json:"phone"`
+	}
+
+	VerifyPhoneReq struct {
+		Code string `json:"code"`
+	}
+
+	UpdateNotificationChannelsReq struct {
+		SmsEnabled  bool    `json:"sms_enabled"`
+		PushEnabled bool    `json:"push_enabled"`
+		PushToken   *string `json:"push_token"`
+	}
+
+	PhoneOtp struct {
+		Phone string `json:"phone"`
+		Code  string `json:"code"`
+	}
+)
+
+func (r *UpdatePhoneReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Phone, v.Required, v.Match(phoneRegex).Error(errutil.ErrInvalidPhoneNumber.Error())),
+	)
+}
+
+func (r *VerifyPhoneReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Code, v.Required),
+	)
+}
+
+func (r *UpdateNotificationChannelsReq) Validate() error {
+	return v.ValidateStruct(r,
+		// enabling push without a token keeps the stored one
+		v.Field(&r.PushToken, v.NilOrNotEmpty),
+	)
+}
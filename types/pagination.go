@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+type (
+	// Cursor is the position a keyset page continues from. StartTime is only
+	// part of the position of events.
+	Cursor struct {
+		StartTime *time.Time `json:"t,omitempty"`
+		ID        int        `json:"id"`
+		// Backward pages towards the start, the page ends before the position.
+		Backward bool `json:"b,omitempty"`
+	}
+
+	CursorPage struct {
+		Limit int    `json:"limit"`
+		Total *int   `json:"total,omitempty"`
+		Next  string `json:"next,omitempty"`
+		Prev  string `json:"prev,omitempty"`
+		// NextCursor and PrevCursor become the Next and Prev links.
+		NextCursor *Cursor `json:"-"`
+		PrevCursor *Cursor `json:"-"`
+	}
+
+	CursorEventResponse struct {
+		CursorPage
+		Events []*models.Event `json:"events"`
+	}
+
+	CursorUserResponse struct {
+		CursorPage
+		Users []*UserInfo `json:"users"`
+	}
+)
+
+// IsBackward tells whether the page before the cursor is requested.
+func (c *Cursor) IsBackward() bool {
+	return c != nil && c.Backward
+}
+
+// NewCursorPage links a page of rows, from first to last, to the pages around
+// it. more tells whether rows are left past the page in the cursor's direction.
+func NewCursorPage(limit int, cursor *Cursor, more bool, first, last Cursor) CursorPage {
+	page := CursorPage{Limit: limit}
+	hasNext, hasPrev := more, cursor != nil
+	if cursor.IsBackward() {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		last.Backward = false
+		page.NextCursor = &last
+	}
+	if hasPrev {
+		first.Backward = true
+		page.PrevCursor = &first
+	}
+	return page
+}
@@ -0,0 +1,13 @@
+package types
+
+import "time"
+
+type (
+	RateLimitResult struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+		// Reset is when the oldest hit in the window expires and frees a slot.
+		Reset time.Duration
+	}
+)
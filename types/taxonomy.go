@@ -0,0 +1,40 @@
+package types
+
+import (
+	"strings"
+
+	v "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	CategoryRequest struct {
+		ID   int    `param:"id"`
+		Name string `json:"name"`
+	}
+
+	ListTagReq struct {
+		Search string `query:"q"`
+		Limit  int    `query:"limit"`
+	}
+
+	// TagResp is a tag with the number of public, upcoming events that have it.
+	TagResp struct {
+		ID         int    `json:"id"`
+		Name       string `json:"name"`
+		EventCount int    `json:"event_count"`
+	}
+)
+
+func (r *CategoryRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return v.ValidateStruct(r,
+		v.Field(&r.Name, v.Required, v.Length(1, 50)),
+	)
+}
+
+func (r *ListTagReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Search, v.Length(0, 50)),
+		v.Field(&r.Limit, v.Min(0), v.Max(50)),
+	)
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vivasoft-ltd/go-ems/models"
+)
+
+// localTimeLayout is an event time without an offset, read as a wall clock
+// time in the time zone of the event.
+const localTimeLayout = "2006-01-02T15:04:05"
+
+func validTimezone(value interface{}) error {
+	var name string
+	switch tz := value.(type) {
+	case string:
+		name = tz
+	case *string:
+		if tz == nil {
+			return nil
+		}
+		name = *tz
+	}
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return errors.New("must be an IANA time zone")
+	}
+	return nil
+}
+
+// Location returns the first of the named time zones that loads, UTC when none
+// does.
+func Location(names ...string) *time.Location {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// parseEventTime reads an RFC 3339 time, or a time without an offset in the
+// named time zone, and returns it in UTC. The offset of a wall clock time is
+// the one the zone has on that day, so DST is accounted for.
+func parseEventTime(value, timezone string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.ParseInLocation(localTimeLayout, value, Location(timezone))
+	}
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// LocalizeEvent returns a copy of the event with its times in loc, the stored
+// event is left as it is.
+func LocalizeEvent(event *models.Event, loc *time.Location) *models.Event {
+	if event == nil {
+		return nil
+	}
+	localized := *event
+	if event.StartTime != nil {
+		startTime := event.StartTime.In(loc)
+		localized.StartTime = &startTime
+	}
+	if event.EndTime != nil {
+		endTime := event.EndTime.In(loc)
+		localized.EndTime = &endTime
+	}
+	return &localized
+}
@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+type (
+	ListTrashReq struct {
+		Page  int `query:"page"`
+		Limit int `query:"limit"`
+	}
+
+	TrashedEvent struct {
+		ID        int       `json:"id"`
+		Title     string    `json:"title"`
+		CreatedBy int       `json:"created_by"`
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+
+	TrashedUser struct {
+		ID        int       `json:"id"`
+		Email     string    `json:"email"`
+		FirstName string    `json:"first_name"`
+		LastName  string    `json:"last_name"`
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+
+	PaginatedTrashedEventResp struct {
+		Total  int            `json:"total"`
+		Page   int            `json:"page"`
+		Limit  int            `json:"limit"`
+		Events []TrashedEvent `json:"events"`
+	}
+
+	PaginatedTrashedUserResp struct {
+		Total int           `json:"total"`
+		Page  int           `json:"page"`
+		Limit int           `json:"limit"`
+		Users []TrashedUser `json:"users"`
+	}
+
+	PurgeTrashPayload struct {
+		// Before is the cutoff, rows deleted earlier are purged.
+		Before time.Time `json:"before"`
+	}
+)
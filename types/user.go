@@ -16,6 +16,8 @@ type (
 		AccessUuid  string   `json:"access_uuid"`
 		RefreshUuid string   `json:"refresh_uuid"`
 		Permissions []string `json:"-"`
+		// Timezone is the preferred time zone of the user, empty when not set.
+		Timezone string `json:"-"`
 	}
 
 	CreateUserReq struct {
@@ -31,6 +33,7 @@ type (
 		FirstName string `json:"first_name"`
 		LastName  string `json:"last_name"`
 		RoleID    int    `json:"role_id"`
+		Version   int    `json:"-"` // from If-Match, 0 updates any version
 	}
 
 	UserReq struct {
@@ -38,18 +41,27 @@ type (
 	}
 
 	UserInfo struct {
-		ID        int            `json:"id"`
-		Email     string         `json:"email"`
-		FirstName string         `json:"first_name"`
-		LastName  string         `json:"last_name"`
-		RoleID    int            `json:"role_id"`
-		Role      string         `json:"role,omitempty" gorm:"-"`
-		Events    []models.Event `json:"events,omitempty" gorm:"-"`
+		ID            int            `json:"id"`
+		Email         string         `json:"email"`
+		FirstName     string         `json:"first_name"`
+		LastName      string         `json:"last_name"`
+		RoleID        int            `json:"role_id"`
+		Role          string         `json:"role,omitempty" gorm:"-"`
+		Phone         *string        `json:"phone,omitempty"`
+		PhoneVerified bool           `json:"phone_verified" gorm:"-"`
+		SmsEnabled    bool           `json:"sms_enabled"`
+		PushEnabled   bool           `json:"push_enabled"`
+		Timezone      *string        `json:"timezone"`
+		Events        []models.Event `json:"events,omitempty" gorm:"-"`
+		Version       int            `json:"-"`
 	}
 
 	ListUserReq struct {
 		Page  int `query:"page"`
 		Limit int `query:"limit"`
+		// Cursor switches to keyset pagination, empty for the first page.
+		Cursor       *string `query:"cursor"`
+		IncludeTotal bool    `query:"include_total"`
 	}
 
 	PaginatedUserResp struct {
@@ -58,6 +70,10 @@ type (
 		Limit int         `json:"limit"`
 		Users []*UserInfo `json:"users"`
 	}
+	UpdateTimezoneReq struct {
+		// Timezone is an IANA time zone, null falls back to the zone of each event.
+		Timezone *string `json:"timezone"`
+	}
 	AttendeeFilter struct {
 		RoleID int
 	}
@@ -87,6 +103,12 @@ func (urq *UpdateUserReq) Validate() error {
 	)
 }
 
+func (r *UpdateTimezoneReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.Timezone, v.NilOrNotEmpty, v.Length(0, 64), v.By(validTimezone)),
+	)
+}
+
 func (rq *UserReq) Validate() error {
 	return v.ValidateStruct(rq,
 		v.Field(&rq.ID, v.Required, v.Min(1)),
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	v "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// maxAvailabilityRange bounds the time range of a room availability query.
+const maxAvailabilityRange = 31 * 24 * time.Hour
+
+type (
+	VenueRequest struct {
+		ID       int     `param:"id"`
+		Name     string  `json:"name"`
+		Address  *string `json:"address"`
+		Timezone string  `json:"timezone"`
+	}
+
+	RoomRequest struct {
+		ID       int    `param:"id"`
+		VenueID  int    `json:"venue_id"`
+		Name     string `json:"name"`
+		Capacity int    `json:"capacity"`
+	}
+
+	RoomAvailabilityReq struct {
+		ID   int    `param:"id"`
+		From string `query:"from"`
+		To   string `query:"to"`
+	}
+
+	// RoomBooking is an event holding a room for its time range.
+	RoomBooking struct {
+		EventID   int       `json:"event_id"`
+		Title     string    `json:"title"`
+		StartTime time.Time `json:"start_time"`
+		EndTime   time.Time `json:"end_time"`
+	}
+
+	RoomAvailabilityResp struct {
+		RoomID    int           `json:"room_id"`
+		From      time.Time     `json:"from"`
+		To        time.Time     `json:"to"`
+		Available bool          `json:"available"`
+		Bookings  []RoomBooking `json:"bookings"`
+	}
+
+	// RoomConflictError is returned when an event would overlap the bookings
+	// of its room.
+	RoomConflictError struct {
+		Bookings []RoomBooking
+	}
+)
+
+func (e *RoomConflictError) Error() string {
+	return errutil.ErrRoomDoubleBooked.Error()
+}
+
+func (e *RoomConflictError) Unwrap() error {
+	return errutil.ErrRoomDoubleBooked
+}
+
+func (r *VenueRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+	return v.ValidateStruct(r,
+		v.Field(&r.Name, v.Required, v.Length(1, 100)),
+		v.Field(&r.Address, v.When(r.Address != nil, v.Length(0, 255))),
+		v.Field(&r.Timezone, v.Length(1, 64), v.By(validTimezone)),
+	)
+}
+
+func (r *RoomRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return v.ValidateStruct(r,
+		v.Field(&r.VenueID, v.When(r.ID == 0, v.Required)),
+		v.Field(&r.Name, v.Required, v.Length(1, 100)),
+		v.Field(&r.Capacity, v.Required, v.Min(1)),
+	)
+}
+
+func (r *RoomAvailabilityReq) Validate() error {
+	return v.ValidateStruct(r,
+		v.Field(&r.ID, v.Required),
+		v.Field(&r.From, v.Required, v.Date(time.RFC3339)),
+		v.Field(&r.To, v.Required, v.Date(time.RFC3339), v.By(r.rangeRule)),
+	)
+}
+
+// rangeRule keeps to after from and the range within maxAvailabilityRange.
+func (r *RoomAvailabilityReq) rangeRule(interface{}) error {
+	from, err := time.Parse(time.RFC3339, r.From)
+	if err != nil {
+		return nil
+	}
+	to, err := time.Parse(time.RFC3339, r.To)
+	if err != nil {
+		return nil
+	}
+	if !to.After(from) {
+		return errors.New("must be after from")
+	}
+	if to.Sub(from) > maxAvailabilityRange {
+		return errors.New("must be at most 31 days after from")
+	}
+	return nil
+}
+
+// Range returns the time range of a validated request.
+func (r *RoomAvailabilityReq) Range() (time.Time, time.Time) {
+	from, _ := time.Parse(time.RFC3339, r.From)
+	to, _ := time.Parse(time.RFC3339, r.To)
+	return from, to
+}
@@ -2,6 +2,7 @@ package errutil
 
 import (
 	"errors"
+	"time"
 )
 
 var (
@@ -29,6 +30,9 @@ var (
 	ErrParseJwt                  = errors.New("failed to parse JWT token")
 	ErrDeleteOldTokenUuid        = errors.New("failed to delete old token uuids")
 	ErrSendingEmail              = errors.New("failed to send email")
+	ErrEmailSuppressed           = errors.New("email address is suppressed")
+	ErrEmailRateLimited          = errors.New("email rate limit reached")
+	ErrEmailProviderThrottled    = errors.New("email provider is throttling or unavailable")
 	ErrUserCreate                = errors.New("failed to create user")
 	ErrUserNotFound              = errors.New("user not found")
 
@@ -42,6 +46,7 @@ var (
 	ErrEmailUpdateNotAllowed            = errors.New("email update not allowed")
 	ErrPhoneUpdateNotAllowed            = errors.New("phone update not allowed")
 	ErrNoPhoneNumberIsSet               = errors.New("no phone number is set")
+	ErrNoPushTokenIsSet                 = errors.New("no push token is set")
 	ErrNoEmailIsSet                     = errors.New("no email is set")
 	ErrEmailAlreadyVerified             = errors.New("email already verified")
 	ErrEmailMisMatched                  = errors.New("email mismatched")
@@ -62,6 +67,24 @@ var (
 	ErrInvalidLineLoginCountry          = errors.New("invalid login country")
 	ErrEventCapacityExceeded            = errors.New("event capacity exceeded")
 	ErrEventReminderEmailNotEnqueued    = errors.New("event reminder email notification not enqueued")
+	ErrUnknownNotifierChannel           = errors.New("unknown notifier channel")
+	ErrNotifierChannelDisabled          = errors.New("notifier channel disabled for user")
+	ErrUnsupportedTaskState             = errors.New("unsupported task state")
+	ErrUnsupportedTaskAction            = errors.New("unsupported task action")
+	ErrVersionConflict                  = errors.New("resource was modified by another request")
+	ErrInvalidMergePatch                = errors.New("merge patch must be a JSON object")
+	ErrInvalidCursor                    = errors.New("invalid pagination cursor")
+	ErrCategoryNotFound                 = errors.New("category not found")
+	ErrCategoryAlreadyExist             = errors.New("category already exists")
+	ErrVenueAlreadyExist                = errors.New("venue already exists")
+	ErrRoomAlreadyExist                 = errors.New("room already exists")
+	ErrRoomNotFound                     = errors.New("room not found")
+	ErrRoomCapacityExceeded             = errors.New("attendee limit exceeds room capacity")
+	ErrRoomDoubleBooked                 = errors.New("room is booked by another event")
+	ErrEventTransitionNotAllowed        = errors.New("event status change not allowed")
+	ErrEventNotPublished                = errors.New("event is not published")
+	ErrEventCancelled                   = errors.New("event is cancelled")
+	ErrIdempotencyKeyLost               = errors.New("idempotency key reserved by another request")
 )
 
 func Exists(err error, errs []error) bool {
@@ -72,3 +95,18 @@ func Exists(err error, errs []error) bool {
 	}
 	return false
 }
+
+// RetryAfterError tells the worker to retry the task after RetryAfter without
+// counting the attempt as a failure.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,123 @@
+package logutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched against lower cased attribute and field names, a
+// name containing any of them is redacted.
+var sensitiveKeys = []string{"pass", "token", "secret", "authorization", "otp"}
+
+type ctxKey struct{}
+
+// New returns a JSON logger writing records at or above the level.
+func New(w io.Writer, level string) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       parseLevel(level),
+		ReplaceAttr: redact,
+	}))
+}
+
+// WithLogger returns ctx carrying the logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// With returns ctx carrying its logger with the given attributes added.
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, fromContext(ctx).With(args...))
+}
+
+// FromContext returns the logger of ctx, or the default one, tagged with the
+// trace of ctx when there is one.
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := fromContext(ctx)
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		logger = logger.With("trace_id", span.TraceID().String(), "span_id", span.SpanID().String())
+	}
+	return logger
+}
+
+func fromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact masks sensitive attributes, including the sensitive fields of logged
+// structs and maps.
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	if a.Value.Kind() != slog.KindAny {
+		return a
+	}
+
+	switch v := a.Value.Any().(type) {
+	case error, json.Marshaler, interface{ String() string }:
+		return a
+	case nil:
+		return a
+	default:
+		data, err := json.Marshal(v)
+		if err != nil || len(data) == 0 || (data[0] != '{' && data[0] != '[') {
+			return a
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return a
+		}
+		return slog.Any(a.Key, redactValue(decoded))
+	}
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,36 @@
+package logutil
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buf, "info"))
+	ctx = With(ctx, "request_id", "req-1")
+
+	type loginReq struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	FromContext(ctx).Info("login",
+		"req", loginReq{Email: "a@b.c", Password: "hunter2"},
+		"access_token", "eyJhbGciOi",
+		"headers", map[string]string{"Authorization": "Bearer eyJhbGciOi"},
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"hunter2", "eyJhbGciOi"} {
+		if strings.Contains(out, leaked) {
+			t.Fatalf("%q leaked into %s", leaked, out)
+		}
+	}
+	for _, kept := range []string{`"request_id":"req-1"`, `"email":"a@b.c"`} {
+		if !strings.Contains(out, kept) {
+			t.Fatalf("%s missing from %s", kept, out)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package methodutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// EncodeCursor turns the position into an opaque token signed with the secret,
+// so clients can't page from positions the API didn't hand out.
+func EncodeCursor(secret string, position interface{}) (string, error) {
+	payload, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(cursorMAC(secret, payload)), nil
+}
+
+// DecodeCursor verifies the token and reads the position out of it. Any token
+// not made by EncodeCursor with the secret is ErrInvalidCursor.
+func DecodeCursor(secret, token string, position interface{}) error {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return errutil.ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return errutil.ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, cursorMAC(secret, payload)) {
+		return errutil.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, position); err != nil {
+		return errutil.ErrInvalidCursor
+	}
+	return nil
+}
+
+func cursorMAC(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,44 @@
+package methodutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+type testCursor struct {
+	ID       int  `json:"id"`
+	Backward bool `json:"b,omitempty"`
+}
+
+func TestCursor(t *testing.T) {
+	token, err := EncodeCursor("secret", testCursor{ID: 42, Backward: true})
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+
+	var got testCursor
+	if err := DecodeCursor("secret", token, &got); err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if got != (testCursor{ID: 42, Backward: true}) {
+		t.Errorf("DecodeCursor() = %+v, want ID 42 backward", got)
+	}
+
+	forged, _ := EncodeCursor("other", testCursor{ID: 1})
+	tests := map[string]string{
+		"OtherSecret": forged,
+		"Tampered":    "eyJpZCI6MX0" + token[len("eyJpZCI6NDIsImIiOnRydWV9"):],
+		"NoSignature": "eyJpZCI6MX0",
+		"NotBase64":   "!!.!!",
+		"Empty":       "",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := DecodeCursor("secret", token, &got); !errors.Is(err, errutil.ErrInvalidCursor) {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, errutil.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package methodutil
+
+import (
+	"encoding/json"
+
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
+)
+
+// MergePatch applies an RFC 7396 JSON merge patch to the doc. The patch must be
+// a JSON object, nulls in it remove the member.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, errutil.ErrInvalidMergePatch
+	}
+	if _, ok := patchValue.(map[string]interface{}); !ok {
+		return nil, errutil.ErrInvalidMergePatch
+	}
+
+	var docValue interface{}
+	if err := json.Unmarshal(doc, &docValue); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(docValue, patchValue))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
@@ -1,6 +1,8 @@
 package methodutil
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/utils/errutil"
@@ -23,6 +25,29 @@ func PermissionCacheKey(roleID int) string {
 	return config.Redis().MandatoryPrefix + config.Redis().PermissionPrefix + strconv.Itoa(roleID)
 }
 
+func PhoneOtpCacheKey(userID int) string {
+	return config.Redis().MandatoryPrefix + config.Notifier().PhoneOtpPrefix + strconv.Itoa(userID)
+}
+
+func PhoneOtpAttemptsCacheKey(userID int) string {
+	return config.Redis().MandatoryPrefix + config.Notifier().PhoneOtpPrefix + "attempts_" + strconv.Itoa(userID)
+}
+
+func NotificationChannel() string {
+	return config.Redis().MandatoryPrefix + config.Redis().NotificationPrefix
+}
+
+func RateLimitKey(name string) string {
+	return config.Redis().MandatoryPrefix + config.Redis().RateLimitPrefix + name
+}
+
+// IdempotencyKey scopes the client's Idempotency-Key to the user, hashed to keep
+// arbitrary client input out of the key.
+func IdempotencyKey(userID int, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return config.Redis().MandatoryPrefix + config.Redis().IdempotencyPrefix + strconv.Itoa(userID) + "_" + hex.EncodeToString(sum[:])
+}
+
 func ParseJwtToken(token, secret string) (*jwt.Token, error) {
 	keyFunc := func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
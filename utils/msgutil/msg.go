@@ -93,6 +93,158 @@ func UserUpdatedSuccessfully() Data {
 func UserDeletedSuccessfully() Data {
 	return NewMessage().Set("message", "User deleted successfully").Done()
 }
+
+func UserRestored() Data {
+	return NewMessage().Set("message", "User restored successfully").Done()
+}
+
+func EventRestored() Data {
+	return NewMessage().Set("message", "Event restored successfully").Done()
+}
 func EventCapacityExceeded() Data {
 	return NewMessage().Set("message", "Event capacity exceeded").Done()
 }
+
+func NotificationsMarkedRead() Data {
+	return NewMessage().Set("message", "Notifications marked as read").Done()
+}
+
+func PhoneVerificationCodeSent() Data {
+	return NewMessage().Set("message", "Verification code sent").Done()
+}
+
+func PhoneVerifiedSuccessfully() Data {
+	return NewMessage().Set("message", "Phone number verified successfully").Done()
+}
+
+func PhoneAlreadyVerified() Data {
+	return NewMessage().Set("message", "Phone number already verified").Done()
+}
+
+func PhoneAlreadyInUse() Data {
+	return NewMessage().Set("message", "Phone number already in use").Done()
+}
+
+func InvalidOtp() Data {
+	return NewMessage().Set("message", "Invalid or expired verification code").Done()
+}
+
+func NoVerifiedPhoneNumber() Data {
+	return NewMessage().Set("message", "A verified phone number is required to enable SMS").Done()
+}
+
+func NoPushToken() Data {
+	return NewMessage().Set("message", "A push token is required to enable push notifications").Done()
+}
+
+func QueueNotFound() Data {
+	return NewMessage().Set("message", "Queue not found").Done()
+}
+
+func QueuePaused() Data {
+	return NewMessage().Set("message", "Queue paused").Done()
+}
+
+func QueueUnpaused() Data {
+	return NewMessage().Set("message", "Queue unpaused").Done()
+}
+
+func UnsupportedTaskAction() Data {
+	return NewMessage().Set("message", "Action is not supported for tasks in this state").Done()
+}
+
+func InvitationsNotFound() Data {
+	return NewMessage().Set("message", "No invitations found for this event").Done()
+}
+
+func TooManyRequestsMsg() Data {
+	return NewMessage().Set("message", "Too many requests, please try again later").Done()
+}
+
+func InvalidIdempotencyKeyMsg() Data {
+	return NewMessage().Set("message", "Idempotency-Key must be at most 255 characters").Done()
+}
+
+func IdempotencyKeyReusedMsg() Data {
+	return NewMessage().Set("message", "Idempotency-Key was already used with a different request").Done()
+}
+
+func IdempotentRequestInProgressMsg() Data {
+	return NewMessage().Set("message", "A request with this Idempotency-Key is still in progress").Done()
+}
+
+func PreconditionFailedMsg() Data {
+	return NewMessage().Set("message", "The resource was modified, fetch it again and retry").Done()
+}
+
+func InvalidCursorMsg() Data {
+	return NewMessage().Set("message", "Invalid pagination cursor").Done()
+}
+
+func CategoryNotFound() Data {
+	return NewMessage().Set("message", "Category not found").Done()
+}
+
+func CategoryAlreadyExists() Data {
+	return NewMessage().Set("message", "Category already exists").Done()
+}
+
+func CategoryDeleted() Data {
+	return NewMessage().Set("message", "Category deleted").Done()
+}
+
+func TagNotFound() Data {
+	return NewMessage().Set("message", "Tag not found").Done()
+}
+
+func TagDeleted() Data {
+	return NewMessage().Set("message", "Tag deleted").Done()
+}
+
+func VenueNotFound() Data {
+	return NewMessage().Set("message", "Venue not found").Done()
+}
+
+func VenueAlreadyExists() Data {
+	return NewMessage().Set("message", "Venue already exists").Done()
+}
+
+func VenueDeleted() Data {
+	return NewMessage().Set("message", "Venue deleted").Done()
+}
+
+func RoomNotFound() Data {
+	return NewMessage().Set("message", "Room not found").Done()
+}
+
+func RoomAlreadyExists() Data {
+	return NewMessage().Set("message", "Room already exists in this venue").Done()
+}
+
+func RoomDeleted() Data {
+	return NewMessage().Set("message", "Room deleted").Done()
+}
+
+func RoomCapacityExceeded() Data {
+	return NewMessage().Set("message", "Attendee limit is missing or exceeds the capacity of the room").Done()
+}
+
+func RoomDoubleBooked(conflicts interface{}) Data {
+	return NewMessage().Set("message", "The room is booked by other events at this time").Set("conflicts", conflicts).Done()
+}
+
+func EventTransitionNotAllowed(status string) Data {
+	return NewMessage().Set("message", "The event can't be moved from its current status").Set("status", status).Done()
+}
+
+func EventCancelled() Data {
+	return NewMessage().Set("message", "Event is cancelled").Done()
+}
+
+func EventNotPublished() Data {
+	return NewMessage().Set("message", "Event is not open for RSVP").Done()
+}
+
+func UnsupportedMediaTypeMsg() Data {
+	return NewMessage().Set("message", "Unsupported media type").Done()
+}
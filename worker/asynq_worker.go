@@ -1,27 +1,149 @@
 package worker
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/vivasoft-ltd/go-ems/config"
 	"github.com/vivasoft-ltd/go-ems/types"
+	"github.com/vivasoft-ltd/go-ems/utils/errutil"
 )
 
-func StartAsynqWorker(mux *asynq.ServeMux) {
-	worker := asynq.NewServer(
+var errNoQueues = errors.New("no queues to serve")
+
+// AsynqWorker serves the given queues, or every configured queue when none are
+// given. Queues with a concurrency limit get a dedicated server so that they
+// can't starve, or be starved by, the rest; the others share the default pool
+// and are picked by their priority weight.
+type AsynqWorker struct {
+	servers []*asynq.Server
+}
+
+func NewAsynqWorker(queues []string, shutdownTimeout time.Duration) *AsynqWorker {
+	shared, dedicated := splitQueues(queues)
+
+	var servers []*asynq.Server
+	if len(shared) > 0 {
+		servers = append(servers, newServer(config.Asynq().Concurrency, shared, shutdownTimeout))
+	}
+	for _, queue := range sortedQueues(dedicated) {
+		servers = append(servers, newServer(dedicated[queue], map[string]int{queue: 1}, shutdownTimeout))
+	}
+	return &AsynqWorker{servers: servers}
+}
+
+// Start starts processing tasks in the background.
+func (w *AsynqWorker) Start(mux *asynq.ServeMux) error {
+	if len(w.servers) == 0 {
+		return errNoQueues
+	}
+	for _, server := range w.servers {
+		if err := server.Start(mux); err != nil {
+			return fmt.Errorf("could not start worker: %w", err)
+		}
+	}
+	return nil
+}
+
+// Shutdown stops fetching new tasks and waits for the active ones. Tasks still
+// running at the shutdown timeout are pushed back to their queue by asynq.
+func (w *AsynqWorker) Shutdown(ctx context.Context) error {
+	for _, server := range w.servers {
+		server.Stop()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, server := range w.servers {
+			wg.Add(1)
+			go func(server *asynq.Server) {
+				defer wg.Done()
+				server.Shutdown()
+			}(server)
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// splitQueues returns the weighted queues served by the shared pool and the
+// queues with their own concurrency limit.
+func splitQueues(queues []string) (map[string]int, map[string]int) {
+	weights := config.Asynq().QueueWeights()
+
+	selected := weights
+	if len(queues) > 0 {
+		selected = make(map[string]int, len(queues))
+		for _, queue := range queues {
+			weight, ok := weights[queue]
+			if !ok {
+				slog.Warn("queue is not configured, serving it with weight 1", "queue", queue)
+				weight = 1
+			}
+			selected[queue] = weight
+		}
+	}
+
+	shared := map[string]int{}
+	dedicated := map[string]int{}
+	for queue, weight := range selected {
+		if concurrency := config.Asynq().QueueConcurrency[queue]; concurrency > 0 {
+			dedicated[queue] = concurrency
+			continue
+		}
+		shared[queue] = weight
+	}
+	return shared, dedicated
+}
+
+func sortedQueues(queues map[string]int) []string {
+	names := make([]string, 0, len(queues))
+	for queue := range queues {
+		names = append(names, queue)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newServer(concurrency int, queues map[string]int, shutdownTimeout time.Duration) *asynq.Server {
+	slog.Info("starting asynq server", "concurrency", concurrency, "queues", queues)
+
+	return asynq.NewServer(
 		asynq.RedisClientOpt{
 			Addr:     config.Asynq().RedisAddr,
 			DB:       config.Asynq().DB,
 			Password: config.Asynq().Pass,
 		},
 		asynq.Config{
-			Concurrency: config.Asynq().Concurrency,
-			Queues: map[string]int{
-				config.Asynq().Queue: 1,
+			Concurrency:     concurrency,
+			Queues:          queues,
+			StrictPriority:  config.Asynq().StrictPriority,
+			ShutdownTimeout: shutdownTimeout,
+			IsFailure: func(err error) bool {
+				// backing off on request isn't a failed attempt
+				var retryAfterErr *errutil.RetryAfterError
+				return !errors.As(err, &retryAfterErr) || retryAfterErr.RetryAfter <= 0
 			},
 			RetryDelayFunc: func(numOfRetry int, e error, t *asynq.Task) time.Duration {
+				var retryAfterErr *errutil.RetryAfterError
+				if errors.As(e, &retryAfterErr) && retryAfterErr.RetryAfter > 0 {
+					return retryAfterErr.RetryAfter
+				}
+
 				switch t.Type() {
 				case types.AsynqTaskTypeInvitationEmail.String():
 					return config.Asynq().EmailInvitationTaskRetryDelay * time.Second
@@ -29,14 +151,18 @@ func StartAsynqWorker(mux *asynq.ServeMux) {
 					return config.Asynq().EventReminderTaskRetryDelay * time.Second
 				case types.AsynqTaskTypeEventReminderEmail.String():
 					return config.Asynq().EventReminderEmailTaskRetryDelay * time.Second
+				case types.AsynqTaskTypeEventCancellation.String():
+					return config.Asynq().EventCancellationTaskRetryDelay * time.Second
+				case types.AsynqTaskTypeCancellationEmail.String():
+					return config.Asynq().CancellationEmailTaskRetryDelay * time.Second
+				case types.AsynqTaskTypeChannelNotification.String():
+					return config.Asynq().ChannelNotificationTaskRetryDelay * time.Second
+				case types.AsynqTaskTypeInvitationBatch.String():
+					return config.Asynq().InvitationBatchTaskRetryDelay * time.Second
 				default:
 					return asynq.DefaultRetryDelayFunc(numOfRetry, e, t)
 				}
 			},
 		},
 	)
-
-	if err := worker.Run(mux); err != nil {
-		panic(fmt.Sprintf("could not run worker: %v", err))
-	}
 }
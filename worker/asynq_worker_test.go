@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vivasoft-ltd/go-ems/config"
+)
+
+func TestSplitQueues(t *testing.T) {
+	config.LoadConfig()
+	conf := config.Asynq()
+	conf.Queue = "default"
+	conf.TaskQueues = map[string]string{"event:reminder_email": "critical", "event:invitation_batch": "bulk"}
+	conf.QueuePriorities = map[string]int{"critical": 6, "default": 3}
+	conf.QueueConcurrency = map[string]int{"bulk": 2}
+
+	tests := []struct {
+		name          string
+		queues        []string
+		wantShared    map[string]int
+		wantDedicated map[string]int
+	}{
+		{
+			name:          "EveryQueue",
+			wantShared:    map[string]int{"critical": 6, "default": 3},
+			wantDedicated: map[string]int{"bulk": 2},
+		},
+		{
+			name:          "SelectedQueues",
+			queues:        []string{"critical"},
+			wantShared:    map[string]int{"critical": 6},
+			wantDedicated: map[string]int{},
+		},
+		{
+			name:          "DedicatedOnly",
+			queues:        []string{"bulk"},
+			wantShared:    map[string]int{},
+			wantDedicated: map[string]int{"bulk": 2},
+		},
+		{
+			name:          "UnknownQueue",
+			queues:        []string{"adhoc"},
+			wantShared:    map[string]int{"adhoc": 1},
+			wantDedicated: map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shared, dedicated := splitQueues(tt.queues)
+			if !reflect.DeepEqual(shared, tt.wantShared) {
+				t.Errorf("shared = %v, want %v", shared, tt.wantShared)
+			}
+			if !reflect.DeepEqual(dedicated, tt.wantDedicated) {
+				t.Errorf("dedicated = %v, want %v", dedicated, tt.wantDedicated)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package worker
+
+import "time"
+
+// Backoff returns how long to wait before the given retry, starting at 1.
+type Backoff func(retry int) time.Duration
+
+// ConstantBackoff waits the same delay before every retry.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// LinearBackoff waits step, 2*step, 3*step, ... between retries.
+func LinearBackoff(step time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		return time.Duration(retry) * step
+	}
+}
+
+// ExponentialBackoff doubles the delay on every retry, starting at base and
+// capped at max.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		delay := base
+		for i := 1; i < retry && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	poolQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "worker_pool_queue_depth",
+		Help: "Number of tasks waiting for a free worker.",
+	}, []string{"pool"})
+
+	poolBusyWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "worker_pool_busy_workers",
+		Help: "Number of workers currently running a task.",
+	}, []string{"pool"})
+
+	poolTaskFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "worker_pool_task_failures_total",
+		Help: "Number of tasks that failed after exhausting their retries.",
+	}, []string{"pool"})
+)
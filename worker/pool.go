@@ -2,29 +2,80 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"runtime/debug"
 	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrPoolClosed   = errors.New("worker pool is closed")
+	ErrTaskPanicked = errors.New("task panicked")
 )
 
 type Executor interface {
-	// Execute runs the task with the given name and payload.
-	Execute() error
-	// OnError handles errors that occur during task execution.
+	// Execute runs the task. The context is cancelled when the task times out
+	// or the pool is shut down.
+	Execute(ctx context.Context) error
+	// OnError handles the error of the last attempt once the retries are exhausted.
 	OnError(error)
 	// MaxRetries returns the maximum number of retries for the task.
 	MaxRetries() int
+	// Timeout bounds every attempt of the task, 0 uses the pool's default.
+	Timeout() time.Duration
+}
+
+type Option func(*Pool)
+
+// WithName labels the pool's metrics.
+func WithName(name string) Option {
+	return func(p *Pool) {
+		p.name = name
+	}
+}
+
+// WithBackoff sets the delay between retries, LinearBackoff(time.Second) by default.
+func WithBackoff(backoff Backoff) Option {
+	return func(p *Pool) {
+		p.backoff = backoff
+	}
+}
+
+// WithTaskTimeout sets the timeout of tasks that don't have their own. 0, the
+// default, runs them without a timeout.
+func WithTaskTimeout(timeout time.Duration) Option {
+	return func(p *Pool) {
+		p.taskTimeout = timeout
+	}
 }
 
 type Pool struct {
-	numWorkers int
-	tasks      chan Executor
-	start      sync.Once
-	stop       sync.Once
-	quit       chan struct{}
+	name        string
+	numWorkers  int
+	backoff     Backoff
+	taskTimeout time.Duration
+
+	tasks  chan Executor
+	quit   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.RWMutex
+	closed bool
+	start  sync.Once
+	stop   sync.Once
+	wg     sync.WaitGroup
+
+	queueDepth  prometheus.Gauge
+	busyWorkers prometheus.Gauge
+	failures    prometheus.Counter
 }
 
-func NewPool(numWorkers int, taskChannelSize int) *Pool {
+func NewPool(numWorkers int, taskChannelSize int, opts ...Option) *Pool {
 	if numWorkers <= 0 {
 		panic("num workers must be greater than zero")
 	}
@@ -32,87 +83,152 @@ func NewPool(numWorkers int, taskChannelSize int) *Pool {
 		panic("channel size cannot be negative")
 	}
 
-	return &Pool{
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		name:       "default",
 		numWorkers: numWorkers,
+		backoff:    LinearBackoff(time.Second),
 		tasks:      make(chan Executor, taskChannelSize),
-		start:      sync.Once{},
-		stop:       sync.Once{},
 		quit:       make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+
+	p.queueDepth = poolQueueDepth.WithLabelValues(p.name)
+	p.busyWorkers = poolBusyWorkers.WithLabelValues(p.name)
+	p.failures = poolTaskFailures.WithLabelValues(p.name)
+	return p
 }
 
 func (p *Pool) Start() {
 	p.start.Do(func() {
-		backgroundCtx := context.Background()
-		p.startWorkers(backgroundCtx)
+		p.wg.Add(p.numWorkers)
+		for i := 0; i < p.numWorkers; i++ {
+			go p.work(i)
+		}
 	})
 }
 
-func (p *Pool) Stop() {
+// AddTask queues the task, blocking while the queue is full. It fails once the
+// pool is shutting down.
+func (p *Pool) AddTask(t Executor) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.tasks <- t:
+		p.queueDepth.Inc()
+		return nil
+	case <-p.quit:
+		return ErrPoolClosed
+	}
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running ones to
+// finish. When ctx is done first, the running tasks are cancelled, the queued
+// ones are dropped and ctx's error is returned.
+func (p *Pool) Shutdown(ctx context.Context) error {
 	p.stop.Do(func() {
-		close(p.quit)  // First signal to stop accepting new tasks
-		close(p.tasks) // Then close the task channel to stop running tasks and return from infinite loop
+		close(p.quit) // release the AddTask calls blocked on a full queue
+		p.mu.Lock()
+		p.closed = true
+		close(p.tasks)
+		p.mu.Unlock()
 	})
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		slog.Warn("worker pool shutdown, cancelled in-flight tasks", "pool", p.name, "err", ctx.Err())
+		return ctx.Err()
+	}
 }
 
-func (p *Pool) StopWithContext(ctx context.Context) {
-	p.stop.Do(func() {
-		close(p.quit)  // First signal to stop accepting new tasks
-		close(p.tasks) // Then close the task channel to stop running tasks and return from infinite loop
+// Stop shuts the pool down, waiting for every queued task to finish.
+func (p *Pool) Stop() {
+	_ = p.Shutdown(context.Background())
+}
 
-		// Wait for context timeout
-		<-ctx.Done()
-		fmt.Println("Worker pool stopped with context timeout")
-	})
+func (p *Pool) work(workerNum int) {
+	defer p.wg.Done()
+
+	for task := range p.tasks {
+		p.queueDepth.Dec()
+
+		if err := p.ctx.Err(); err != nil {
+			// shutdown timed out, drop what's left in the queue
+			task.OnError(err)
+			continue
+		}
+
+		p.busyWorkers.Inc()
+		if err := p.run(task); err != nil {
+			p.failures.Inc()
+			task.OnError(err)
+		}
+		p.busyWorkers.Dec()
+	}
+	slog.Info("worker stopped", "pool", p.name, "worker", workerNum)
 }
 
-func (p *Pool) AddTask(t Executor) {
-	select {
-	case p.tasks <- t:
-	case <-p.quit:
+// run executes the task, retrying it with the pool's backoff. The wait between
+// retries is cut short when the pool shuts down.
+func (p *Pool) run(task Executor) error {
+	var err error
+	for retry := 0; ; retry++ {
+		if err = p.execute(task); err == nil {
+			return nil
+		}
+		if retry >= task.MaxRetries() || p.ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(retry + 1))
+		select {
+		case <-timer.C:
+		case <-p.ctx.Done():
+			timer.Stop()
+			return err
+		}
+		slog.Info("retrying task", "pool", p.name, "attempt", retry+1, "max_retries", task.MaxRetries(), "err", err)
 	}
 }
 
-func (p *Pool) startWorkers(ctx context.Context) {
-	for i := 0; i < p.numWorkers; i++ {
-		go func(workerNum int) {
-			fmt.Printf("worker number %d started\n", workerNum)
-			for {
-				select {
-				case <-ctx.Done():
-					fmt.Printf("worker number %d stopping due to context cancellation\n", workerNum)
-					return
-				case <-p.quit:
-					fmt.Printf("worker number %d stopping due to quit signal\n", workerNum)
-					return
-				case task, ok := <-p.tasks:
-					if !ok {
-						return
-					}
-
-					// Retry logic
-					var err error
-					for retry := 0; retry <= task.MaxRetries(); retry++ {
-						if err = task.Execute(); err == nil {
-							break
-						}
-						if retry < task.MaxRetries() {
-							time.Sleep(time.Duration(retry+1) * time.Second)
-							fmt.Printf("worker %d retrying task (attempt %d/%d)\n", workerNum, retry+1, task.MaxRetries())
-						}
-					}
-
-					if err != nil {
-						task.OnError(err)
-					}
-
-					// if err := task.Execute(); err != nil {
-					// 	task.OnError(err)
-					// }
-
-					fmt.Printf("worker number %d finished task\n", workerNum)
-				}
-			}
-		}(i)
+// execute runs a single attempt of the task and turns a panic into an error.
+func (p *Pool) execute(task Executor) (err error) {
+	ctx := p.ctx
+	timeout := task.Timeout()
+	if timeout <= 0 {
+		timeout = p.taskTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered task panic", "pool", p.name, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("%w: %v", ErrTaskPanicked, r)
+		}
+	}()
+
+	return task.Execute(ctx)
 }
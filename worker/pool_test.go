@@ -0,0 +1,300 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type errRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *errRecorder) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func (r *errRecorder) all() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]error(nil), r.errs...)
+}
+
+func TestPoolDrainsQueuedTasksOnShutdown(t *testing.T) {
+	pool := NewPool(4, 100, WithName(t.Name()))
+	pool.Start()
+
+	var executed int32
+	for i := 0; i < 100; i++ {
+		task := NewTask(func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&executed, 1)
+			return nil
+		}, nil, 0)
+		if err := pool.AddTask(task); err != nil {
+			t.Fatalf("AddTask() error = %v", err)
+		}
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&executed); got != 100 {
+		t.Errorf("executed %d tasks, want 100", got)
+	}
+	if got := testutil.ToFloat64(poolQueueDepth.WithLabelValues(t.Name())); got != 0 {
+		t.Errorf("queue depth = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(poolBusyWorkers.WithLabelValues(t.Name())); got != 0 {
+		t.Errorf("busy workers = %v, want 0", got)
+	}
+}
+
+func TestPoolRetriesWithBackoff(t *testing.T) {
+	var backoffs []int
+	var mu sync.Mutex
+	backoff := func(retry int) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		backoffs = append(backoffs, retry)
+		return time.Millisecond
+	}
+	pool := NewPool(1, 1, WithName(t.Name()), WithBackoff(backoff))
+	pool.Start()
+	failuresBefore := testutil.ToFloat64(poolTaskFailures.WithLabelValues(t.Name()))
+
+	errFailed := errors.New("failed")
+	var attempts int32
+	recorder := &errRecorder{}
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		atomic.AddInt32(&attempts, 1)
+		return errFailed
+	}, recorder.record, 2))
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+	if errs := recorder.all(); len(errs) != 1 || !errors.Is(errs[0], errFailed) {
+		t.Errorf("OnError got %v, want [%v]", errs, errFailed)
+	}
+	mu.Lock()
+	if len(backoffs) != 2 || backoffs[0] != 1 || backoffs[1] != 2 {
+		t.Errorf("backoff called with %v, want [1 2]", backoffs)
+	}
+	mu.Unlock()
+	if got := testutil.ToFloat64(poolTaskFailures.WithLabelValues(t.Name())) - failuresBefore; got != 1 {
+		t.Errorf("failures = %v, want 1", got)
+	}
+}
+
+func TestPoolTaskTimeout(t *testing.T) {
+	pool := NewPool(1, 1, WithName(t.Name()), WithTaskTimeout(time.Hour))
+	pool.Start()
+
+	recorder := &errRecorder{}
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, recorder.record, 0).WithTimeout(10 * time.Millisecond))
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if errs := recorder.all(); len(errs) != 1 || !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Errorf("OnError got %v, want [%v]", errs, context.DeadlineExceeded)
+	}
+}
+
+func TestPoolRecoversPanics(t *testing.T) {
+	pool := NewPool(1, 2, WithName(t.Name()))
+	pool.Start()
+
+	recorder := &errRecorder{}
+	var executed int32
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		panic("boom")
+	}, recorder.record, 0))
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		atomic.AddInt32(&executed, 1)
+		return nil
+	}, recorder.record, 0))
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if errs := recorder.all(); len(errs) != 1 || !errors.Is(errs[0], ErrTaskPanicked) {
+		t.Errorf("OnError got %v, want [%v]", errs, ErrTaskPanicked)
+	}
+	if got := atomic.LoadInt32(&executed); got != 1 {
+		t.Errorf("worker stopped after a panic, executed %d tasks after it, want 1", got)
+	}
+}
+
+func TestPoolShutdownDeadlineCancelsInFlightTasks(t *testing.T) {
+	pool := NewPool(1, 1, WithName(t.Name()), WithBackoff(ConstantBackoff(time.Hour)))
+	pool.Start()
+
+	started := make(chan struct{})
+	recorder := &errRecorder{}
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, recorder.record, 5))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the cancelled task must not wait out the hour-long backoff
+	deadline := time.After(time.Second)
+	for len(recorder.all()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("in-flight task was not cancelled")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if errs := recorder.all(); !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("OnError got %v, want [%v]", errs, context.Canceled)
+	}
+}
+
+func TestPoolRejectsTasksAfterShutdown(t *testing.T) {
+	pool := NewPool(1, 1, WithName(t.Name()))
+	pool.Start()
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	err := pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0))
+	if !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("AddTask() error = %v, want %v", err, ErrPoolClosed)
+	}
+}
+
+func TestPoolAddTaskBlocksWhileQueueFull(t *testing.T) {
+	pool := NewPool(1, 1, WithName(t.Name()))
+	pool.Start()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}, nil, 0))
+	<-started
+	// fills the queue while the only worker is busy
+	if err := pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0)); err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+
+	added := make(chan error, 1)
+	go func() {
+		added <- pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0))
+	}()
+	select {
+	case err := <-added:
+		t.Fatalf("AddTask() returned %v on a full queue, want it to block", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-added:
+		if err != nil {
+			t.Fatalf("AddTask() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AddTask() still blocked after the queue drained")
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+}
+
+func TestPoolShutdownReleasesBlockedAddTask(t *testing.T) {
+	pool := NewPool(1, 1, WithName(t.Name()))
+	pool.Start()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.AddTask(NewTask(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}, nil, 0))
+	<-started
+	pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0))
+
+	added := make(chan error, 1)
+	go func() {
+		added <- pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0))
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- pool.Shutdown(context.Background()) }()
+	select {
+	case err := <-added:
+		if !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("AddTask() error = %v, want %v", err, ErrPoolClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AddTask() still blocked after shutdown")
+	}
+
+	close(release)
+	if err := <-shutdown; err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+}
+
+func TestPoolConcurrentAddAndShutdown(t *testing.T) {
+	pool := NewPool(4, 1, WithName(t.Name()))
+	pool.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if err := pool.AddTask(NewTask(func(ctx context.Context) error { return nil }, nil, 0)); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(time.Millisecond)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	wg.Wait()
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 package worker
 
+import (
+	"context"
+	"time"
+)
+
 type Task struct {
-	execute      func() error
+	execute      func(ctx context.Context) error
 	errorHandler func(error)
 	maxRetries   int
+	timeout      time.Duration
 }
 
-func NewTask(execute func() error, errorHandler func(error), maxRetries int) *Task {
+func NewTask(execute func(ctx context.Context) error, errorHandler func(error), maxRetries int) *Task {
 	return &Task{
 		execute:      execute,
 		errorHandler: errorHandler,
@@ -14,14 +20,26 @@ func NewTask(execute func() error, errorHandler func(error), maxRetries int) *Ta
 	}
 }
 
-func (t *Task) Execute() error {
-	return t.execute()
+// WithTimeout bounds every attempt of the task, overriding the pool's default.
+func (t *Task) WithTimeout(timeout time.Duration) *Task {
+	t.timeout = timeout
+	return t
+}
+
+func (t *Task) Execute(ctx context.Context) error {
+	return t.execute(ctx)
 }
 
 func (t *Task) OnError(err error) {
-	t.errorHandler(err)
+	if t.errorHandler != nil {
+		t.errorHandler(err)
+	}
 }
 
 func (t *Task) MaxRetries() int {
 	return t.maxRetries
 }
+
+func (t *Task) Timeout() time.Duration {
+	return t.timeout
+}